@@ -0,0 +1,27 @@
+module github.com/wphillipmoore/mq-rest-admin-go/cmd/mqexporter
+
+go 1.25.0
+
+require (
+	github.com/prometheus/client_golang v1.24.1
+	github.com/wphillipmoore/mq-rest-admin-go v1.2.1
+	github.com/wphillipmoore/mq-rest-admin-go/mqrestadmin/exporter v1.2.1
+)
+
+require (
+	github.com/beorn7/perks v1.0.1 // indirect
+	github.com/cespare/xxhash/v2 v2.3.0 // indirect
+	github.com/munnerz/goautoneg v0.0.0-20191010083416-a7dc8b61c822 // indirect
+	github.com/pelletier/go-toml/v2 v2.2.4 // indirect
+	github.com/prometheus/client_model v0.6.2 // indirect
+	github.com/prometheus/common v0.70.1 // indirect
+	github.com/prometheus/procfs v0.21.1 // indirect
+	golang.org/x/sys v0.47.0 // indirect
+	google.golang.org/protobuf v1.36.11 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
+
+replace (
+	github.com/wphillipmoore/mq-rest-admin-go => ../..
+	github.com/wphillipmoore/mq-rest-admin-go/mqrestadmin/exporter => ../../mqrestadmin/exporter
+)
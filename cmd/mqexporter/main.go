@@ -0,0 +1,27 @@
+// Command mqexporter serves IBM MQ queue, channel, queue manager, listener
+// and service metrics for Prometheus on /metrics.
+//
+// Each -profile adds a queue manager from a connection profile, and each
+// -qmgr adds a queue manager reached through the first profile's queue
+// manager as a gateway:
+//
+//	mqexporter -profile prod
+//	mqexporter -profile gateway -qmgr QM2 -qmgr QM3 -poll-interval 30s
+//
+// Profiles are read from the same configuration file as the mqrestadmin
+// command; see the usage text for the flags.
+package main
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+func main() { // coverage-ignore -- process entry point, exercised through run
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	status := run(ctx, os.Args[1:], os.Stderr, newProfileSession, serve)
+	stop()
+	os.Exit(status)
+}
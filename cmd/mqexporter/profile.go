@@ -0,0 +1,29 @@
+package main
+
+import (
+	"github.com/wphillipmoore/mq-rest-admin-go/mqrestadmin"
+	"github.com/wphillipmoore/mq-rest-admin-go/mqrestadmin/config"
+)
+
+// sessionFactory creates the session for a connection profile. Tests
+// replace newProfileSession to inject a mock transport.
+type sessionFactory func(configPath, profileName string) (*mqrestadmin.Session, error)
+
+// newProfileSession creates a session from a connection profile: from the
+// -config file when given, otherwise from the default profile file, with
+// MQREST_* environment overrides applied. An empty profileName selects the
+// default profile. Status responses include attributes the mapping data
+// does not cover, so mapping is never strict.
+func newProfileSession(configPath, profileName string) (*mqrestadmin.Session, error) {
+	var selected config.Profile
+	var err error
+	if configPath != "" {
+		selected, err = config.LoadProfileFile(configPath, profileName)
+	} else {
+		selected, err = config.LoadProfile(profileName)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return selected.NewSession(mqrestadmin.WithMappingStrict(false))
+}
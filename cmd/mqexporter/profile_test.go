@@ -0,0 +1,44 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const testProfiles = `default: dev
+profiles:
+  dev:
+    rest_base_url: https://dev:9443/ibmmq/rest/v2
+    qmgr_name: QM1
+    username: admin
+    password: admin
+  prod:
+    rest_base_url: https://prod:9443/ibmmq/rest/v2
+    qmgr_name: QM2
+    username: admin
+    password: admin
+`
+
+func TestNewProfileSession(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "profiles.yaml")
+	if err := os.WriteFile(path, []byte(testProfiles), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("MQREST_CONFIG", path)
+	t.Setenv("MQREST_PROFILE", "")
+
+	session, err := newProfileSession("", "")
+	if err != nil || session.QmgrName() != "QM1" {
+		t.Fatalf("default profile session = %v, %v", session, err)
+	}
+	session, err = newProfileSession(path, "prod")
+	if err != nil || session.QmgrName() != "QM2" {
+		t.Fatalf("-config session = %v, %v", session, err)
+	}
+	if _, err := newProfileSession(path, "test"); err == nil ||
+		!strings.Contains(err.Error(), `profile "test" not found`) {
+		t.Errorf("unknown profile error = %v", err)
+	}
+}
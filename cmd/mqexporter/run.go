@@ -0,0 +1,157 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+
+	"github.com/wphillipmoore/mq-rest-admin-go/mqrestadmin"
+	"github.com/wphillipmoore/mq-rest-admin-go/mqrestadmin/exporter"
+)
+
+const usageText = `Usage: mqexporter [flags]
+
+Serves IBM MQ queue, channel, queue manager, listener, and service metrics
+for Prometheus on /metrics. Queue managers are polled when Prometheus
+scrapes the exporter, or every -poll-interval when it is set.
+
+Flags:
+`
+
+// settings holds the parsed command line.
+type settings struct {
+	configPath   string
+	profiles     []string
+	qmgrs        []string
+	listen       string
+	queues       string
+	channels     string
+	pollInterval time.Duration
+	pollTimeout  time.Duration
+}
+
+// serveFunc serves handler on address until ctx is done.
+type serveFunc func(ctx context.Context, address string, handler http.Handler) error
+
+// run serves metrics for the command line's queue managers and returns the
+// process exit status: 0 after ctx is done, 1 when the exporter fails, and
+// 2 for a usage error.
+func run(ctx context.Context, args []string, stderr io.Writer, newSession sessionFactory, serve serveFunc) int {
+	settings, err := parseFlags(args, stderr)
+	if errors.Is(err, flag.ErrHelp) {
+		return 0
+	}
+	if err != nil {
+		return 2
+	}
+
+	fleet, err := newFleet(settings, newSession)
+	if err != nil {
+		fmt.Fprintf(stderr, "mqexporter: %v\n", err)
+		return 1
+	}
+	logger := slog.New(slog.NewTextHandler(stderr, nil))
+	collector := exporter.New(fleet,
+		exporter.WithQueues(settings.queues),
+		exporter.WithChannels(settings.channels),
+		exporter.WithPollTimeout(settings.pollTimeout),
+		exporter.WithLogger(logger))
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(collector)
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.HandlerFor(registry, promhttp.HandlerOpts{}))
+
+	if settings.pollInterval > 0 {
+		go func() { _ = collector.Run(ctx, settings.pollInterval) }()
+	}
+	logger.Info("mqexporter serving metrics", "address", settings.listen, "qmgrs", fleet.QmgrNames())
+	if err := serve(ctx, settings.listen, mux); err != nil {
+		fmt.Fprintf(stderr, "mqexporter: %v\n", err)
+		return 1
+	}
+	return 0
+}
+
+// parseFlags parses the command line. The flag package reports errors
+// and usage on stderr.
+func parseFlags(args []string, stderr io.Writer) (settings, error) {
+	var parsed settings
+	flags := flag.NewFlagSet("mqexporter", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.Usage = func() {
+		_, _ = io.WriteString(stderr, usageText)
+		flags.PrintDefaults()
+	}
+	flags.StringVar(&parsed.configPath, "config", "",
+		"profile file (default: $MQREST_CONFIG, or mqrestadmin/profiles.yaml in the user config dir)")
+	flags.Func("profile", "connection profile of a queue manager to poll (repeatable; default: the file's default)",
+		func(name string) error {
+			parsed.profiles = append(parsed.profiles, name)
+			return nil
+		})
+	flags.Func("qmgr", "queue manager to poll through the first profile's queue manager (repeatable)",
+		func(name string) error {
+			parsed.qmgrs = append(parsed.qmgrs, name)
+			return nil
+		})
+	flags.StringVar(&parsed.listen, "listen", ":9157", "address to serve metrics on")
+	flags.StringVar(&parsed.queues, "queues", "*", "queue name pattern")
+	flags.StringVar(&parsed.channels, "channels", "*", "channel name pattern")
+	flags.DurationVar(&parsed.pollInterval, "poll-interval", 0,
+		"poll in the background at this interval and serve the latest poll (default: poll on scrape)")
+	flags.DurationVar(&parsed.pollTimeout, "poll-timeout", 0, "maximum duration of a poll")
+
+	if err := flags.Parse(args); err != nil {
+		return settings{}, err
+	}
+	if flags.NArg() > 0 {
+		fmt.Fprintf(stderr, "unexpected arguments: %s\n", strings.Join(flags.Args(), " "))
+		flags.Usage()
+		return settings{}, errors.New("unexpected arguments")
+	}
+	return parsed, nil
+}
+
+// newFleet creates a session for each profile, the default profile when
+// none is given, and derives a session for each -qmgr from the first.
+func newFleet(settings settings, newSession sessionFactory) (*mqrestadmin.Fleet, error) {
+	profiles := settings.profiles
+	if len(profiles) == 0 {
+		profiles = []string{""}
+	}
+	var sessions []*mqrestadmin.Session
+	for _, profile := range profiles {
+		session, err := newSession(settings.configPath, profile)
+		if err != nil {
+			return nil, err
+		}
+		sessions = append(sessions, session)
+	}
+	for _, qmgr := range settings.qmgrs {
+		sessions = append(sessions, sessions[0].ForQmgr(qmgr))
+	}
+	return mqrestadmin.NewFleet(sessions)
+}
+
+// serve serves handler on address until ctx is done, then shuts the
+// server down.
+func serve(ctx context.Context, address string, handler http.Handler) error {
+	server := &http.Server{Addr: address, Handler: handler, ReadHeaderTimeout: 10 * time.Second}
+	stop := context.AfterFunc(ctx, func() {
+		_ = server.Shutdown(context.Background())
+	})
+	defer stop()
+	if err := server.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,163 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/wphillipmoore/mq-rest-admin-go/mqrestadmin"
+)
+
+// statusTransport answers DISPLAY QMSTATUS with a running queue manager
+// and every other command with a command error, as when no objects match.
+type statusTransport struct {
+	mutex    sync.Mutex
+	requests int
+}
+
+func (transport *statusTransport) PostJSON(_ context.Context, _ string, payload map[string]any,
+	_ map[string]string, _ time.Duration, _ bool,
+) (*mqrestadmin.TransportResponse, error) {
+	transport.mutex.Lock()
+	defer transport.mutex.Unlock()
+	transport.requests++
+	if payload["qualifier"] == "QMSTATUS" {
+		return &mqrestadmin.TransportResponse{StatusCode: 200, Body: `{"commandResponse":[{"completionCode":0,` +
+			`"reasonCode":0,"parameters":{"status":"RUNNING"}}],"overallCompletionCode":0,"overallReasonCode":0}`}, nil
+	}
+	return &mqrestadmin.TransportResponse{StatusCode: 200,
+		Body: `{"commandResponse":[{"completionCode":2,"reasonCode":2085}],` +
+			`"overallCompletionCode":2,"overallReasonCode":3008}`}, nil
+}
+
+func (transport *statusTransport) requestCount() int {
+	transport.mutex.Lock()
+	defer transport.mutex.Unlock()
+	return transport.requests
+}
+
+// testFactory returns a sessionFactory that builds sessions on transport,
+// using the profile name as the queue manager name, and records the
+// profiles it was called with.
+func testFactory(transport mqrestadmin.Transport, profiles *[]string) sessionFactory {
+	return func(_, profileName string) (*mqrestadmin.Session, error) {
+		*profiles = append(*profiles, profileName)
+		if profileName == "" {
+			profileName = "QM1"
+		}
+		return mqrestadmin.NewSession("https://localhost:9443/ibmmq/rest/v2", profileName,
+			mqrestadmin.BasicAuth{Username: "admin", Password: "admin"}, mqrestadmin.WithTransport(transport))
+	}
+}
+
+// scrape returns the /metrics response body from handler.
+func scrape(handler http.Handler) string {
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	return recorder.Body.String()
+}
+
+func TestRun_ServesMetrics(t *testing.T) {
+	var profiles []string
+	var metrics, address string
+	serve := func(_ context.Context, listen string, handler http.Handler) error {
+		address, metrics = listen, scrape(handler)
+		return nil
+	}
+
+	var stderr bytes.Buffer
+	status := run(context.Background(), []string{"-profile", "QM1", "-profile", "QM2", "-qmgr", "QM3"},
+		&stderr, testFactory(&statusTransport{}, &profiles), serve)
+	if status != 0 {
+		t.Fatalf("status = %d, stderr = %s", status, stderr.String())
+	}
+	for _, want := range []string{
+		`mq_up{qmgr="QM1"} 1`, `mq_up{qmgr="QM2"} 1`, `mq_up{qmgr="QM3"} 1`,
+		`mq_qmgr_status{qmgr="QM3",status="RUNNING"} 1`,
+	} {
+		if !strings.Contains(metrics, want) {
+			t.Errorf("metrics missing %q:\n%s", want, metrics)
+		}
+	}
+	if address != ":9157" || strings.Join(profiles, ",") != "QM1,QM2" {
+		t.Errorf("address = %q, profiles = %v", address, profiles)
+	}
+	if !strings.Contains(stderr.String(), `msg="mqexporter serving metrics"`) {
+		t.Errorf("stderr = %s", stderr.String())
+	}
+}
+
+func TestRun_PollInterval(t *testing.T) {
+	var profiles []string
+	transport := &statusTransport{}
+	serve := func(_ context.Context, _ string, handler http.Handler) error {
+		deadline := time.Now().Add(5 * time.Second)
+		for transport.requestCount() < 12 || !strings.Contains(scrape(handler), `mq_up{qmgr="QM1"} 1`) {
+			if time.Now().After(deadline) {
+				return errors.New("no background polls")
+			}
+			time.Sleep(time.Millisecond)
+		}
+		return nil
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var stderr bytes.Buffer
+	if status := run(ctx, []string{"-poll-interval", "1ms", "-queues", "APP.*", "-listen", "127.0.0.1:0"},
+		&stderr, testFactory(transport, &profiles), serve); status != 0 {
+		t.Fatalf("status = %d, stderr = %s", status, stderr.String())
+	}
+	if len(profiles) != 1 || profiles[0] != "" {
+		t.Errorf("profiles = %q, want the default profile", profiles)
+	}
+}
+
+func TestRun_Errors(t *testing.T) {
+	failingFactory := func(_, _ string) (*mqrestadmin.Session, error) {
+		return nil, errors.New(`profile "test" not found`)
+	}
+	serveError := func(context.Context, string, http.Handler) error {
+		return errors.New("listen tcp: address in use")
+	}
+	var profiles []string
+	for _, test := range []struct {
+		args    []string
+		factory sessionFactory
+		status  int
+		stderr  string
+	}{
+		{[]string{"-h"}, failingFactory, 0, "Usage: mqexporter"},
+		{[]string{"-bogus"}, failingFactory, 2, "flag provided but not defined: -bogus"},
+		{[]string{"extra"}, failingFactory, 2, "unexpected arguments: extra"},
+		{nil, failingFactory, 1, `mqexporter: profile "test" not found`},
+		{nil, testFactory(&statusTransport{}, &profiles), 1, "mqexporter: listen tcp: address in use"},
+	} {
+		var stderr bytes.Buffer
+		status := run(context.Background(), test.args, &stderr, test.factory, serveError)
+		if status != test.status || !strings.Contains(stderr.String(), test.stderr) {
+			t.Errorf("run(%q) = %d, stderr %q; want %d, %q", test.args, status, stderr.String(),
+				test.status, test.stderr)
+		}
+	}
+}
+
+func TestServe(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- serve(ctx, "127.0.0.1:0", http.NotFoundHandler()) }()
+	cancel()
+	if err := <-done; err != nil {
+		t.Errorf("serve returned %v after cancel, want nil", err)
+	}
+
+	if err := serve(context.Background(), "127.0.0.1:-1", http.NotFoundHandler()); err == nil {
+		t.Error("serve on an invalid address returned nil")
+	}
+}
@@ -0,0 +1,248 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+const usageText = `Usage: mqrestadmin [flags] <verb> <object> [name] [flags]
+       mqrestadmin [flags] <object> <verb> [name] [flags]
+       mqrestadmin commands
+
+Runs an MQSC command, ensure, or sync operation through the IBM MQ REST API.
+"mqrestadmin commands" lists every available verb and object pair.
+
+Examples:
+  mqrestadmin display queue 'APP.*' --where 'current_queue_depth GT 0' -o table
+  mqrestadmin ensure qlocal APP.Q --set max_queue_depth=5000
+  mqrestadmin channel restart TO.QM2 --sync
+
+Flags:
+  -o, --output FORMAT      table (default), json, yaml, or csv
+      --where CLAUSE       keep rows matching "attribute OP value", where OP is
+                           EQ, NE, LT, GT, LE, GE, LK, NL, CT, or EX
+      --set KEY=VALUE      set a request attribute (repeatable); integers are
+                           sent as numbers, and KEY:=JSON sends a JSON value
+      --attrs A,B,...      attributes to return and show, in order
+      --sync               wait for start or stop to complete
+      --sync-timeout DUR   maximum wait for --sync (default 30s)
+      --poll-interval DUR  status check interval for --sync (default 1s)
+      --mqsc-names         use MQSC attribute names instead of snake_case
+  -p, --profile NAME       connection profile (default: $MQREST_PROFILE, then
+                           the file's default)
+  -c, --config PATH        profile file, YAML, TOML, or JSON (default:
+                           $MQREST_CONFIG, or mqrestadmin/profiles.yaml in
+                           the user config dir)
+  -h, --help               show this help
+
+Profile file (MQREST_* environment variables override any setting, for
+example MQREST_QMGR_NAME):
+  default: dev
+  profiles:
+    dev:
+      rest_base_url: https://localhost:9443/ibmmq/rest/v2
+      qmgr_name: QM1
+      auth: basic            # basic, ltpa, or certificate
+      username: mqadmin
+      password_env: MQ_ADMIN_PASSWORD
+      verify_tls: false
+    prod:
+      rest_base_url: https://mq.example.com:9443/ibmmq/rest/v2
+      qmgr_name: QM1
+      auth: certificate
+      cert_file: /etc/mq/client.pem
+      key_file: /etc/mq/client.key
+      timeout: 60s
+`
+
+// globalSettings selects the connection a command runs against.
+type globalSettings struct {
+	configPath  string
+	profileName string
+	mqscNames   bool
+}
+
+// commandLine is the parsed command line.
+type commandLine struct {
+	settings     globalSettings
+	words        []string
+	output       string
+	where        string
+	set          []string
+	attrs        []string
+	sync         bool
+	syncTimeout  time.Duration
+	pollInterval time.Duration
+	help         bool
+}
+
+// usageError reports a malformed command line; run exits with status 2.
+type usageError struct {
+	message string
+}
+
+func (e *usageError) Error() string {
+	return e.message
+}
+
+func newUsageError(format string, args ...any) error {
+	return &usageError{message: fmt.Sprintf(format, args...)}
+}
+
+// run executes the command line and returns the process exit status: 0 on
+// success, 1 when the command fails, and 2 for a usage error.
+func run(ctx context.Context, args []string, stdout, stderr io.Writer, newSession sessionFactory) int {
+	line, err := parseCommandLine(args)
+	if err == nil && line.help {
+		_, _ = io.WriteString(stdout, usageText)
+		return 0
+	}
+	if err == nil && len(line.words) == 0 {
+		err = newUsageError("missing command")
+	}
+	if err == nil && line.words[0] == "commands" {
+		writeCommandList(stdout)
+		return 0
+	}
+	if err == nil {
+		err = execute(ctx, line, stdout, newSession)
+	}
+	if err == nil {
+		return 0
+	}
+
+	fmt.Fprintf(stderr, "mqrestadmin: %v\n", err)
+	var usage *usageError
+	if errors.As(err, &usage) {
+		fmt.Fprintln(stderr, "Run 'mqrestadmin --help' for usage.")
+		return 2
+	}
+	return 1
+}
+
+func execute(ctx context.Context, line commandLine, stdout io.Writer, newSession sessionFactory) error {
+	invocation, err := resolveInvocation(line)
+	if err != nil {
+		return err
+	}
+	formatter, err := formatterFor(line.output)
+	if err != nil {
+		return err
+	}
+	var filter *whereFilter
+	if line.where != "" {
+		if filter, err = parseWhere(line.where); err != nil {
+			return err
+		}
+	}
+
+	session, err := newSession(line.settings)
+	if err != nil {
+		return err
+	}
+	rows, err := invocation.call(ctx, session)
+	if err != nil {
+		return err
+	}
+	if filter != nil {
+		rows = filter.apply(rows)
+	}
+	return formatter(stdout, rows, tableColumns(rows, line.attrs, invocation.qualifier))
+}
+
+// parseCommandLine separates positional words from flags. Flags may appear
+// anywhere, as "--flag value" or "--flag=value"; "--" ends flag parsing.
+func parseCommandLine(args []string) (commandLine, error) {
+	line := commandLine{output: "table"}
+	for index := 0; index < len(args); index++ {
+		arg := args[index]
+		if arg == "--" {
+			line.words = append(line.words, args[index+1:]...)
+			break
+		}
+		if !strings.HasPrefix(arg, "-") || arg == "-" {
+			line.words = append(line.words, arg)
+			continue
+		}
+
+		flagName, value, hasValue := strings.Cut(arg, "=")
+		takeValue := func() (string, error) {
+			if hasValue {
+				return value, nil
+			}
+			if index+1 >= len(args) {
+				return "", newUsageError("flag %s needs a value", flagName)
+			}
+			index++
+			return args[index], nil
+		}
+
+		if err := line.applyFlag(flagName, takeValue); err != nil {
+			return commandLine{}, err
+		}
+	}
+	return line, nil
+}
+
+// applyFlag records one flag, calling takeValue for flags that have a
+// value.
+func (line *commandLine) applyFlag(flagName string, takeValue func() (string, error)) error {
+	var err error
+	switch flagName {
+	case "-h", "--help":
+		line.help = true
+	case "--sync":
+		line.sync = true
+	case "--mqsc-names":
+		line.settings.mqscNames = true
+	case "-o", "--output":
+		line.output, err = takeValue()
+	case "--where":
+		line.where, err = takeValue()
+	case "--set":
+		var setting string
+		setting, err = takeValue()
+		line.set = append(line.set, setting)
+	case "--attrs":
+		var attrs string
+		attrs, err = takeValue()
+		line.attrs = appendAttrs(line.attrs, attrs)
+	case "--sync-timeout":
+		line.syncTimeout, err = durationValue(flagName, takeValue)
+	case "--poll-interval":
+		line.pollInterval, err = durationValue(flagName, takeValue)
+	case "-p", "--profile":
+		line.settings.profileName, err = takeValue()
+	case "-c", "--config":
+		line.settings.configPath, err = takeValue()
+	default:
+		err = newUsageError("unknown flag %s", flagName)
+	}
+	return err
+}
+
+// appendAttrs appends the names in a comma-separated --attrs value.
+func appendAttrs(attrs []string, value string) []string {
+	for attr := range strings.SplitSeq(value, ",") {
+		if attr = strings.TrimSpace(attr); attr != "" {
+			attrs = append(attrs, attr)
+		}
+	}
+	return attrs
+}
+
+func durationValue(flagName string, takeValue func() (string, error)) (time.Duration, error) {
+	text, err := takeValue()
+	if err != nil {
+		return 0, err
+	}
+	duration, err := time.ParseDuration(text)
+	if err != nil {
+		return 0, newUsageError("invalid %s %q: %v", flagName, text, err)
+	}
+	return duration, nil
+}
@@ -0,0 +1,215 @@
+package main
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/wphillipmoore/mq-rest-admin-go/mqrestadmin"
+)
+
+func TestRun_DisplayQueueTable(t *testing.T) {
+	transport := &mockTransport{}
+	transport.addSuccessResponse(
+		map[string]any{"queue": "APP.A", "curdepth": 3, "maxdepth": 5000},
+		map[string]any{"queue": "APP.B", "curdepth": 0, "maxdepth": 5000},
+	)
+
+	status, stdout, stderr := runCommand(t, testFactory(t, transport, nil),
+		"display", "queue", "APP.*", "--where", "current_queue_depth GT 0", "-o", "table")
+	if status != 0 {
+		t.Fatalf("status = %d, stderr = %q", status, stderr)
+	}
+
+	want := "QUEUE_NAME  CURRENT_QUEUE_DEPTH  MAX_QUEUE_DEPTH\nAPP.A       3                    5000\n"
+	if stdout != want {
+		t.Errorf("stdout =\n%s\nwant\n%s", stdout, want)
+	}
+	payload := transport.payloads[0]
+	if payload["command"] != "DISPLAY" || payload["qualifier"] != "QUEUE" || payload["name"] != "APP.*" {
+		t.Errorf("payload = %v, want DISPLAY QUEUE(APP.*)", payload)
+	}
+}
+
+func TestRun_ObjectVerbOrderAndAttrs(t *testing.T) {
+	transport := &mockTransport{}
+	transport.addSuccessResponse(map[string]any{"queue": "APP.A", "maxdepth": 3, "descr": "A, B"})
+
+	status, stdout, stderr := runCommand(t, testFactory(t, transport, nil),
+		"queue", "display", "APP.A", "--attrs=max_queue_depth,description", "--output=csv")
+	if status != 0 {
+		t.Fatalf("status = %d, stderr = %q", status, stderr)
+	}
+
+	want := "max_queue_depth,description\n3,\"A, B\"\n"
+	if stdout != want {
+		t.Errorf("stdout = %q, want %q", stdout, want)
+	}
+	if got := transport.payloads[0]["responseParameters"]; !reflect.DeepEqual(got, []string{"MAXDEPTH", "DESCR"}) {
+		t.Errorf("responseParameters = %#v, want mapped attrs", got)
+	}
+}
+
+func TestRun_EnsureWithSettings(t *testing.T) {
+	transport := &mockTransport{}
+	transport.addCommandErrorResponse(2, 2085)
+	transport.addSuccessResponse()
+	var captured globalSettings
+
+	status, stdout, stderr := runCommand(t, testFactory(t, transport, &captured),
+		"--profile", "dev", "-c", "/tmp/profiles.yaml",
+		"ensure", "qlocal", "APP.Q", "--set", "max_queue_depth=5000", "--set", "description=Orders",
+		"--set", `cluster_namelist:="CLUSTERS"`, "-o", "json")
+	if status != 0 {
+		t.Fatalf("status = %d, stderr = %q", status, stderr)
+	}
+
+	if captured.profileName != "dev" || captured.configPath != "/tmp/profiles.yaml" {
+		t.Errorf("settings = %+v", captured)
+	}
+	want := "[\n  {\n    \"action\": \"created\",\n    \"changed\": null\n  }\n]\n"
+	if stdout != want {
+		t.Errorf("stdout = %q, want %q", stdout, want)
+	}
+	parameters := transport.payloads[1]["parameters"]
+	wantParameters := map[string]any{"MAXDEPTH": int64(5000), "DESCR": "Orders", "CLUSNL": "CLUSTERS"}
+	if !reflect.DeepEqual(parameters, wantParameters) {
+		t.Errorf("parameters = %#v, want %#v", parameters, wantParameters)
+	}
+}
+
+func TestRun_StartChannelSync(t *testing.T) {
+	transport := &mockTransport{}
+	transport.addSuccessResponse()
+	transport.addSuccessResponse(map[string]any{"channel": "TO.QM2", "status": "RUNNING"})
+
+	status, stdout, stderr := runCommand(t, testFactory(t, transport, nil),
+		"channel", "start", "TO.QM2", "--sync", "--sync-timeout", "5s", "--poll-interval", "1ms", "-o", "yaml")
+	if status != 0 {
+		t.Fatalf("status = %d, stderr = %q", status, stderr)
+	}
+	if !strings.HasPrefix(stdout, "- elapsed_seconds: ") || !strings.Contains(stdout, "  operation: started\n  polls: 1\n") {
+		t.Errorf("stdout = %q, want YAML sync result", stdout)
+	}
+	if transport.payloads[1]["qualifier"] != "CHSTATUS" {
+		t.Errorf("second payload = %v, want DISPLAY CHSTATUS", transport.payloads[1])
+	}
+}
+
+func TestRun_VoidAndSingleObjectCommands(t *testing.T) {
+	transport := &mockTransport{}
+	transport.addSuccessResponse()
+	transport.addSuccessResponse(map[string]any{"QMNAME": "QM1", "DESCR": "Test"})
+	transport.addSuccessResponse()
+	factory := testFactory(t, transport, nil)
+
+	if status, stdout, stderr := runCommand(t, factory, "alter", "qmgr", "--set", "descr=Test", "--mqsc-names"); status != 0 || stdout != "" {
+		t.Errorf("alter qmgr = %d, %q, %q", status, stdout, stderr)
+	}
+	status, stdout, stderr := runCommand(t, factory, "display", "qmgr", "--mqsc-names")
+	if status != 0 || stdout != "DESCR  QMNAME\nTest   QM1\n" {
+		t.Errorf("display qmgr = %d, %q, %q", status, stdout, stderr)
+	}
+	if status, stdout, _ := runCommand(t, factory, "display", "qmgr", "-o", "json"); status != 0 || stdout != "[]\n" {
+		t.Errorf("empty display qmgr = %d, %q", status, stdout)
+	}
+}
+
+func TestRun_HelpAndCommands(t *testing.T) {
+	status, stdout, _ := runCommand(t, nil, "--help")
+	if status != 0 || !strings.HasPrefix(stdout, "Usage: mqrestadmin") {
+		t.Errorf("--help = %d, %q", status, stdout)
+	}
+
+	status, stdout, _ = runCommand(t, nil, "commands")
+	if status != 0 {
+		t.Fatalf("commands status = %d", status)
+	}
+	for _, command := range []string{"display queue\n", "ensure qlocal\n", "start channel [--sync]\n", "restart channel\n"} {
+		if !strings.Contains(stdout, command) {
+			t.Errorf("commands output missing %q", command)
+		}
+	}
+	for _, hidden := range []string{"start channel sync", "display queue seq", "run mqsc"} {
+		if strings.Contains(stdout, hidden) {
+			t.Errorf("commands output lists unsupported %q", hidden)
+		}
+	}
+}
+
+func TestRun_UsageErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     []string
+		wantText string
+	}{
+		{"no command", nil, "missing command"},
+		{"one word", []string{"display"}, "expected a verb and an object"},
+		{"extra word", []string{"display", "queue", "A", "B"}, `unexpected argument "B"`},
+		{"unknown command", []string{"frobnicate", "queue"}, `unknown command "frobnicate" "queue"`},
+		{"unsupported sync", []string{"restart", "queue", "--sync"}, "supports --sync"},
+		{"sync on display", []string{"display", "queue", "--sync"}, "display queue does not support --sync"},
+		{"name not taken", []string{"display", "qmgr", "QM1"}, "display qmgr does not take an object name"},
+		{"set not taken", []string{"restart", "channel", "X", "--set", "a=1"}, "restart channel does not take --set"},
+		{"bad set", []string{"alter", "qmgr", "--set", "novalue"}, `--set "novalue" is not KEY=VALUE`},
+		{"bad json set", []string{"alter", "qmgr", "--set", "a:={"}, "--set a: invalid JSON value"},
+		{"unknown flag", []string{"display", "queue", "--bogus"}, "unknown flag --bogus"},
+		{"missing value", []string{"display", "queue", "-o"}, "flag -o needs a value"},
+		{"bad duration", []string{"start", "channel", "--sync-timeout", "soon"}, `invalid --sync-timeout "soon"`},
+		{"bad poll duration", []string{"start", "channel", "--poll-interval"}, "flag --poll-interval needs a value"},
+		{"bad format", []string{"display", "queue", "-o", "xml"}, `unknown output format "xml"`},
+		{"bad where", []string{"display", "queue", "--where", "depth GT"}, "is not \"attribute OP value\""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status, stdout, stderr := runCommand(t, nil, tt.args...)
+			if status != 2 || stdout != "" {
+				t.Errorf("status = %d, stdout = %q; want 2 and no output", status, stdout)
+			}
+			if !strings.Contains(stderr, tt.wantText) || !strings.Contains(stderr, "mqrestadmin --help") {
+				t.Errorf("stderr = %q, want it to contain %q", stderr, tt.wantText)
+			}
+		})
+	}
+}
+
+func TestRun_CommandFailures(t *testing.T) {
+	factoryErr := errors.New("no profile")
+	failingFactory := func(globalSettings) (*mqrestadmin.Session, error) { return nil, factoryErr }
+	status, _, stderr := runCommand(t, failingFactory, "display", "queue")
+	if status != 1 || stderr != "mqrestadmin: no profile\n" {
+		t.Errorf("factory failure = %d, %q", status, stderr)
+	}
+
+	transport := &mockTransport{}
+	transport.addCommandErrorResponse(2, 2085)
+	status, _, stderr = runCommand(t, testFactory(t, transport, nil), "display", "queue", "MISSING")
+	if status != 1 || !strings.Contains(stderr, "command error") {
+		t.Errorf("command failure = %d, %q", status, stderr)
+	}
+}
+
+func TestParseCommandLine(t *testing.T) {
+	line, err := parseCommandLine([]string{
+		"display", "-", "--attrs", " a, ,b ", "--sync", "-p=prod", "--", "--literal",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(line.words, []string{"display", "-", "--literal"}) {
+		t.Errorf("words = %q", line.words)
+	}
+	if !reflect.DeepEqual(line.attrs, []string{"a", "b"}) || !line.sync || line.settings.profileName != "prod" {
+		t.Errorf("line = %+v", line)
+	}
+	if line.output != "table" || line.syncTimeout != 0 {
+		t.Errorf("defaults = %q %v", line.output, line.syncTimeout)
+	}
+
+	line, err = parseCommandLine([]string{"--poll-interval=250ms"})
+	if err != nil || line.pollInterval != 250*time.Millisecond {
+		t.Errorf("pollInterval = %v, %v", line.pollInterval, err)
+	}
+}
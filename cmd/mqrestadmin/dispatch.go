@@ -0,0 +1,263 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/wphillipmoore/mq-rest-admin-go/mqrestadmin"
+)
+
+var (
+	sessionType        = reflect.TypeFor[*mqrestadmin.Session]()
+	contextType        = reflect.TypeFor[context.Context]()
+	errorType          = reflect.TypeFor[error]()
+	stringType         = reflect.TypeFor[string]()
+	parametersType     = reflect.TypeFor[map[string]any]()
+	rowsType           = reflect.TypeFor[[]map[string]any]()
+	syncConfigType     = reflect.TypeFor[mqrestadmin.SyncConfig]()
+	commandOptionsType = reflect.TypeFor[[]mqrestadmin.CommandOption]()
+	ensureResultType   = reflect.TypeFor[mqrestadmin.EnsureResult]()
+	syncResultType     = reflect.TypeFor[mqrestadmin.SyncResult]()
+)
+
+// invocation is a resolved Session method and the arguments to call it
+// with.
+type invocation struct {
+	method     reflect.Method
+	qualifier  string
+	name       string
+	hasName    bool
+	parameters map[string]any
+	options    []mqrestadmin.CommandOption
+	syncConfig mqrestadmin.SyncConfig
+}
+
+// resolveInvocation finds the Session method named by the command words,
+// in verb-object or object-verb order, and prepares its arguments.
+func resolveInvocation(line commandLine) (*invocation, error) {
+	if len(line.words) < 2 {
+		return nil, newUsageError("expected a verb and an object, e.g. \"display queue\"")
+	}
+	if len(line.words) > 3 {
+		return nil, newUsageError("unexpected argument %q", line.words[3])
+	}
+
+	method, qualifier, err := findMethod(line)
+	if err != nil {
+		return nil, err
+	}
+	parameters, err := parseSettings(line.set)
+	if err != nil {
+		return nil, err
+	}
+	call := &invocation{
+		method:     method,
+		qualifier:  qualifier,
+		parameters: parameters,
+		syncConfig: mqrestadmin.SyncConfig{Timeout: line.syncTimeout, PollInterval: line.pollInterval},
+	}
+	if len(line.words) == 3 {
+		call.name, call.hasName = line.words[2], true
+	}
+	if len(parameters) > 0 {
+		call.options = append(call.options, mqrestadmin.WithRequestParameters(parameters))
+	}
+	if len(line.attrs) > 0 {
+		call.options = append(call.options, mqrestadmin.WithResponseParameters(line.attrs))
+	}
+
+	if err := call.checkArguments(line.sync); err != nil {
+		return nil, err
+	}
+	return call, nil
+}
+
+// findMethod returns the Session method named by the first two command
+// words, in either order, and the object word that qualifies it.
+func findMethod(line commandLine) (reflect.Method, string, error) {
+	first, second := strings.ToLower(line.words[0]), strings.ToLower(line.words[1])
+	for _, pair := range [][2]string{{first, second}, {second, first}} {
+		candidate := titleWord(pair[0]) + titleWord(pair[1])
+		if line.sync && (pair[0] == "start" || pair[0] == "stop") {
+			candidate += "Sync"
+		}
+		if resolved, exists := sessionType.MethodByName(candidate); exists && supportedMethod(resolved.Type) {
+			return resolved, pair[1], nil
+		}
+	}
+	if line.sync {
+		return reflect.Method{}, "", newUsageError("no command %q %q supports --sync", first, second)
+	}
+	return reflect.Method{}, "", newUsageError("unknown command %q %q; run 'mqrestadmin commands' for a list",
+		first, second)
+}
+
+// checkArguments reports a usage error when the method cannot take the
+// name, --set parameters, or --sync given on the command line.
+func (call *invocation) checkArguments(sync bool) error {
+	methodType := call.method.Type
+	if sync && methodType.Out(0) != syncResultType {
+		return newUsageError("%s does not support --sync", commandName(call.method.Name))
+	}
+	if call.hasName && !methodTakes(methodType, stringType) {
+		return newUsageError("%s does not take an object name", commandName(call.method.Name))
+	}
+	if len(call.parameters) > 0 && !methodTakes(methodType, parametersType) && !methodType.IsVariadic() {
+		return newUsageError("%s does not take --set", commandName(call.method.Name))
+	}
+	return nil
+}
+
+// call invokes the method and converts its result to rows.
+func (call *invocation) call(ctx context.Context, session *mqrestadmin.Session) ([]map[string]any, error) {
+	methodType := call.method.Type
+	args := []reflect.Value{reflect.ValueOf(session)}
+	for index := 1; index < methodType.NumIn(); index++ {
+		switch methodType.In(index) {
+		case contextType:
+			args = append(args, reflect.ValueOf(ctx))
+		case stringType:
+			args = append(args, reflect.ValueOf(call.name))
+		case parametersType:
+			args = append(args, reflect.ValueOf(call.parameters))
+		case syncConfigType:
+			args = append(args, reflect.ValueOf(call.syncConfig))
+		case commandOptionsType:
+			args = append(args, reflect.ValueOf(call.options))
+		}
+	}
+
+	var results []reflect.Value
+	if methodType.IsVariadic() {
+		results = call.method.Func.CallSlice(args)
+	} else {
+		results = call.method.Func.Call(args)
+	}
+	if err, _ := results[len(results)-1].Interface().(error); err != nil {
+		return nil, err
+	}
+	if len(results) == 1 {
+		return nil, nil
+	}
+
+	switch result := results[0].Interface().(type) {
+	case []map[string]any:
+		return result, nil
+	case map[string]any:
+		if result == nil {
+			return nil, nil
+		}
+		return []map[string]any{result}, nil
+	case mqrestadmin.EnsureResult:
+		return []map[string]any{{"action": result.Action.String(), "changed": result.Changed}}, nil
+	default:
+		sync := result.(mqrestadmin.SyncResult)
+		return []map[string]any{{
+			"operation":       sync.Operation.String(),
+			"polls":           sync.Polls,
+			"elapsed_seconds": sync.ElapsedSeconds,
+		}}, nil
+	}
+}
+
+// supportedMethod reports whether the CLI can call a method: it takes a
+// context followed by any of a name, request parameters, a SyncConfig, and
+// command options, and returns an error, optionally after rows, a single
+// object, an EnsureResult, or a SyncResult.
+func supportedMethod(methodType reflect.Type) bool {
+	if methodType.NumIn() < 2 || methodType.In(1) != contextType {
+		return false
+	}
+	for index := 2; index < methodType.NumIn(); index++ {
+		if !slices.Contains([]reflect.Type{stringType, parametersType, syncConfigType, commandOptionsType},
+			methodType.In(index)) {
+			return false
+		}
+	}
+
+	switch methodType.NumOut() {
+	case 1:
+		return methodType.Out(0) == errorType
+	case 2:
+		return methodType.Out(1) == errorType && slices.Contains(
+			[]reflect.Type{rowsType, parametersType, ensureResultType, syncResultType}, methodType.Out(0))
+	default:
+		return false
+	}
+}
+
+func methodTakes(methodType reflect.Type, argType reflect.Type) bool {
+	for index := range methodType.NumIn() {
+		if methodType.In(index) == argType {
+			return true
+		}
+	}
+	return false
+}
+
+// parseSettings converts --set arguments to request parameters. KEY=VALUE
+// sends an integer-looking VALUE as a number and anything else as a
+// string; KEY:=JSON sends the decoded JSON value.
+func parseSettings(settings []string) (map[string]any, error) {
+	parameters := map[string]any{}
+	for _, setting := range settings {
+		key, value, found := strings.Cut(setting, "=")
+		if !found || key == "" || key == ":" {
+			return nil, newUsageError("--set %q is not KEY=VALUE", setting)
+		}
+		if rawKey, isJSON := strings.CutSuffix(key, ":"); isJSON {
+			var decoded any
+			if err := json.Unmarshal([]byte(value), &decoded); err != nil {
+				return nil, newUsageError("--set %s: invalid JSON value: %v", rawKey, err)
+			}
+			parameters[rawKey] = decoded
+			continue
+		}
+		if number, err := strconv.ParseInt(value, 10, 64); err == nil {
+			parameters[key] = number
+			continue
+		}
+		parameters[key] = value
+	}
+	return parameters, nil
+}
+
+// writeCommandList prints every supported command as "verb object", with
+// start and stop commands that have sync variants marked.
+func writeCommandList(writer io.Writer) {
+	var commands []string
+	for index := range sessionType.NumMethod() {
+		method := sessionType.Method(index)
+		if !supportedMethod(method.Type) || strings.HasSuffix(method.Name, "Sync") {
+			continue
+		}
+		command := commandName(method.Name)
+		if _, hasSync := sessionType.MethodByName(method.Name + "Sync"); hasSync {
+			command += " [--sync]"
+		}
+		commands = append(commands, command)
+	}
+	slices.Sort(commands)
+	fmt.Fprintln(writer, strings.Join(commands, "\n"))
+}
+
+// commandName converts a method name such as DisplayQueue to "display
+// queue".
+func commandName(methodName string) string {
+	split := strings.IndexFunc(methodName[1:], unicode.IsUpper) + 1
+	return strings.ToLower(methodName[:split]) + " " + strings.ToLower(methodName[split:])
+}
+
+func titleWord(word string) string {
+	if word == "" {
+		return ""
+	}
+	return strings.ToUpper(word[:1]) + word[1:]
+}
@@ -0,0 +1,43 @@
+package main
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestSupportedMethod(t *testing.T) {
+	tests := []struct {
+		name   string
+		method any
+		want   bool
+	}{
+		{"no context", func(*struct{}) string { return "" }, false},
+		{"context first", func(*struct{}, string, context.Context) error { return nil }, false},
+		{"unsupported argument", func(*struct{}, context.Context, int) error { return nil }, false},
+		{"void", func(*struct{}, context.Context, string) error { return nil }, true},
+		{"void without error", func(*struct{}, context.Context) {}, false},
+		{"rows", func(*struct{}, context.Context, string, map[string]any) ([]map[string]any, error) { return nil, nil }, true},
+		{"unsupported result", func(*struct{}, context.Context) (string, error) { return "", nil }, false},
+		{"three results", func(*struct{}, context.Context) (int, int, error) { return 0, 0, nil }, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := supportedMethod(reflect.TypeOf(tt.method)); got != tt.want {
+				t.Errorf("supportedMethod() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCommandNames(t *testing.T) {
+	if got := commandName("DisplayQueue"); got != "display queue" {
+		t.Errorf("commandName() = %q", got)
+	}
+	if got := titleWord("qlocal"); got != "Qlocal" {
+		t.Errorf("titleWord() = %q", got)
+	}
+	if got := titleWord(""); got != "" {
+		t.Errorf("titleWord(\"\") = %q", got)
+	}
+}
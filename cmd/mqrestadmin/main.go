@@ -0,0 +1,22 @@
+// Command mqrestadmin runs IBM MQ administrative commands through the MQ
+// REST API.
+//
+// Every generated Session method is available as a verb and object
+// keyword, in either order:
+//
+//	mqrestadmin display queue 'APP.*' --where 'current_queue_depth GT 0' -o table
+//	mqrestadmin ensure qlocal APP.Q --set max_queue_depth=5000
+//	mqrestadmin channel restart TO.QM2 --sync
+//
+// Connection details come from a profile in a YAML profile file; see
+// the usage text for the file format.
+package main
+
+import (
+	"context"
+	"os"
+)
+
+func main() { // coverage-ignore -- process entry point, exercised through run
+	os.Exit(run(context.Background(), os.Args[1:], os.Stdout, os.Stderr, newProfileSession))
+}
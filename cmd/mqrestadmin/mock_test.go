@@ -0,0 +1,83 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/wphillipmoore/mq-rest-admin-go/mqrestadmin"
+)
+
+// mockTransport is a test Transport that records payloads and returns
+// queued responses.
+type mockTransport struct {
+	payloads  []map[string]any
+	responses []*mqrestadmin.TransportResponse
+}
+
+func (transport *mockTransport) PostJSON(_ context.Context, _ string, payload map[string]any,
+	_ map[string]string, _ time.Duration, _ bool,
+) (*mqrestadmin.TransportResponse, error) {
+	transport.payloads = append(transport.payloads, payload)
+	if len(transport.payloads) > len(transport.responses) {
+		return nil, fmt.Errorf("mock transport: no response configured for call %d", len(transport.payloads))
+	}
+	return transport.responses[len(transport.payloads)-1], nil
+}
+
+// addSuccessResponse queues a successful response with one commandResponse
+// item per row.
+func (transport *mockTransport) addSuccessResponse(rows ...map[string]any) {
+	items := make([]any, len(rows))
+	for index, row := range rows {
+		items[index] = map[string]any{"completionCode": 0, "reasonCode": 0, "parameters": row}
+	}
+	transport.addBody(map[string]any{
+		"overallCompletionCode": 0, "overallReasonCode": 0, "commandResponse": items,
+	})
+}
+
+// addCommandErrorResponse queues an MQSC command error response.
+func (transport *mockTransport) addCommandErrorResponse(completionCode, reasonCode int) {
+	transport.addBody(map[string]any{
+		"overallCompletionCode": completionCode, "overallReasonCode": reasonCode,
+	})
+}
+
+func (transport *mockTransport) addBody(body map[string]any) {
+	encoded, _ := json.Marshal(body)
+	transport.responses = append(transport.responses, &mqrestadmin.TransportResponse{
+		StatusCode: 200, Body: string(encoded), Headers: map[string]string{},
+	})
+}
+
+// testFactory returns a sessionFactory that builds sessions on transport
+// and records the settings it was called with.
+func testFactory(t *testing.T, transport *mockTransport, captured *globalSettings) sessionFactory {
+	t.Helper()
+	return func(settings globalSettings) (*mqrestadmin.Session, error) {
+		if captured != nil {
+			*captured = settings
+		}
+		token := "test"
+		return mqrestadmin.NewSession(
+			"https://localhost:9443/ibmmq/rest/v2",
+			"QM1",
+			mqrestadmin.BasicAuth{Username: "admin", Password: "admin"},
+			mqrestadmin.WithTransport(transport),
+			mqrestadmin.WithCSRFToken(&token),
+			mqrestadmin.WithMapAttributes(!settings.mqscNames),
+		)
+	}
+}
+
+// runCommand runs the CLI and returns its exit status and output.
+func runCommand(t *testing.T, factory sessionFactory, args ...string) (int, string, string) {
+	t.Helper()
+	var stdout, stderr bytes.Buffer
+	status := run(context.Background(), args, &stdout, &stderr, factory)
+	return status, stdout.String(), stderr.String()
+}
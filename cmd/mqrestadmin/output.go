@@ -0,0 +1,111 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strings"
+	"text/tabwriter"
+
+	"gopkg.in/yaml.v3"
+)
+
+// formatter writes rows in one output format. Columns apply to the table
+// and CSV formats; JSON and YAML write every attribute.
+type formatter func(writer io.Writer, rows []map[string]any, columns []string) error
+
+func formatterFor(name string) (formatter, error) {
+	switch name {
+	case "table":
+		return writeTable, nil
+	case "json":
+		return writeJSON, nil
+	case "yaml":
+		return writeYAML, nil
+	case "csv":
+		return writeCSV, nil
+	default:
+		return nil, newUsageError("unknown output format %q (want table, json, yaml, or csv)", name)
+	}
+}
+
+// tableColumns chooses the table and CSV columns: the --attrs list when
+// given, otherwise every attribute in name order with the object's name
+// attribute, where one is recognized, first.
+func tableColumns(rows []map[string]any, attrs []string, qualifier string) []string {
+	if len(attrs) > 0 && !slices.ContainsFunc(attrs, func(attr string) bool { return strings.EqualFold(attr, "all") }) {
+		return attrs
+	}
+
+	present := map[string]bool{}
+	for _, row := range rows {
+		for key := range row {
+			present[key] = true
+		}
+	}
+	columns := slices.Sorted(maps.Keys(present))
+
+	nameColumns := []string{qualifier + "_name", qualifier, "queue_name", "channel_name", "queue_manager_name"}
+	for _, nameColumn := range nameColumns {
+		if index := slices.Index(columns, nameColumn); index >= 0 {
+			columns = append([]string{nameColumn}, slices.Delete(columns, index, index+1)...)
+			break
+		}
+	}
+	return columns
+}
+
+func writeTable(writer io.Writer, rows []map[string]any, columns []string) error {
+	if len(rows) == 0 {
+		return nil
+	}
+	table := tabwriter.NewWriter(writer, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(table, strings.ToUpper(strings.Join(columns, "\t")))
+	for _, row := range rows {
+		cells := make([]string, len(columns))
+		for index, column := range columns {
+			cells[index] = formatCell(row[column])
+		}
+		fmt.Fprintln(table, strings.Join(cells, "\t"))
+	}
+	return table.Flush()
+}
+
+func writeJSON(writer io.Writer, rows []map[string]any, _ []string) error {
+	if rows == nil {
+		rows = []map[string]any{}
+	}
+	encoder := json.NewEncoder(writer)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(rows)
+}
+
+func writeYAML(writer io.Writer, rows []map[string]any, _ []string) error {
+	if rows == nil {
+		rows = []map[string]any{}
+	}
+	encoder := yaml.NewEncoder(writer)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(rows); err != nil {
+		return err
+	}
+	return encoder.Close()
+}
+
+func writeCSV(writer io.Writer, rows []map[string]any, columns []string) error {
+	if len(rows) == 0 {
+		return nil
+	}
+	records := [][]string{columns}
+	for _, row := range rows {
+		record := make([]string, len(columns))
+		for index, column := range columns {
+			record[index] = formatCell(row[column])
+		}
+		records = append(records, record)
+	}
+	return csv.NewWriter(writer).WriteAll(records)
+}
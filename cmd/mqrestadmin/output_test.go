@@ -0,0 +1,54 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// failingWriter rejects every write.
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) { return 0, errors.New("disk full") }
+
+func TestTableColumns(t *testing.T) {
+	rows := []map[string]any{
+		{"description": "A", "channel_name": "TO.QM2"},
+		{"channel_type": "SDR"},
+	}
+	want := []string{"channel_name", "channel_type", "description"}
+	if got := tableColumns(rows, nil, "channel"); !reflect.DeepEqual(got, want) {
+		t.Errorf("tableColumns() = %v, want %v", got, want)
+	}
+	if got := tableColumns(rows, []string{"ALL"}, "channel"); !reflect.DeepEqual(got, want) {
+		t.Errorf("tableColumns(all) = %v, want %v", got, want)
+	}
+	if got := tableColumns(rows, []string{"description"}, "channel"); !reflect.DeepEqual(got, []string{"description"}) {
+		t.Errorf("tableColumns(attrs) = %v", got)
+	}
+}
+
+func TestFormatters_EmptyRows(t *testing.T) {
+	want := map[string]string{"table": "", "csv": "", "json": "[]\n", "yaml": "[]\n"}
+	for name, wantOutput := range want {
+		format, err := formatterFor(name)
+		if err != nil {
+			t.Fatalf("formatterFor(%q): %v", name, err)
+		}
+		var output bytes.Buffer
+		if err := format(&output, nil, nil); err != nil || output.String() != wantOutput {
+			t.Errorf("%s output = %q, %v; want %q", name, output.String(), err, wantOutput)
+		}
+	}
+}
+
+func TestFormatters_WriteErrors(t *testing.T) {
+	rows := []map[string]any{{"queue_name": "APP.Q"}}
+	for _, name := range []string{"table", "json", "yaml", "csv"} {
+		format, _ := formatterFor(name)
+		if err := format(failingWriter{}, rows, []string{"queue_name"}); err == nil {
+			t.Errorf("%s: expected write error", name)
+		}
+	}
+}
@@ -0,0 +1,32 @@
+package main
+
+import (
+	"github.com/wphillipmoore/mq-rest-admin-go/mqrestadmin"
+	"github.com/wphillipmoore/mq-rest-admin-go/mqrestadmin/config"
+)
+
+// sessionFactory creates the session a command runs against. Tests replace
+// newProfileSession to inject a mock transport.
+type sessionFactory func(settings globalSettings) (*mqrestadmin.Session, error)
+
+// newProfileSession creates a session from the selected connection profile:
+// the --config file when given, otherwise the default profile file, with
+// MQREST_* environment overrides applied.
+func newProfileSession(settings globalSettings) (*mqrestadmin.Session, error) {
+	var selected config.Profile
+	var err error
+	if settings.configPath != "" {
+		selected, err = config.LoadProfileFile(settings.configPath, settings.profileName)
+	} else {
+		selected, err = config.LoadProfile(settings.profileName)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var options []mqrestadmin.Option
+	if settings.mqscNames {
+		options = append(options, mqrestadmin.WithMapAttributes(false))
+	}
+	return selected.NewSession(options...)
+}
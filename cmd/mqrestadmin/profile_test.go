@@ -0,0 +1,56 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const testProfiles = `default: dev
+profiles:
+  dev:
+    rest_base_url: https://dev:9443/ibmmq/rest/v2
+    qmgr_name: QM1
+    username: admin
+    password: admin
+  prod:
+    rest_base_url: https://prod:9443/ibmmq/rest/v2
+    qmgr_name: QM2
+    auth: certificate
+`
+
+func writeProfiles(t *testing.T) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "profiles.yaml")
+	if err := os.WriteFile(path, []byte(testProfiles), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestNewProfileSession(t *testing.T) {
+	path := writeProfiles(t)
+	t.Setenv("MQREST_CONFIG", path)
+	t.Setenv("MQREST_PROFILE", "")
+
+	session, err := newProfileSession(globalSettings{mqscNames: true})
+	if err != nil || session.QmgrName() != "QM1" {
+		t.Fatalf("default file session = %v, %v", session, err)
+	}
+
+	t.Setenv("MQREST_QMGR_NAME", "QM9")
+	session, err = newProfileSession(globalSettings{configPath: path, profileName: "dev"})
+	if err != nil || session.QmgrName() != "QM9" {
+		t.Fatalf("--config session = %v, %v", session, err)
+	}
+
+	if _, err := newProfileSession(globalSettings{configPath: path, profileName: "prod"}); err == nil ||
+		!strings.Contains(err.Error(), "requires cert_file") {
+		t.Errorf("invalid profile error = %v", err)
+	}
+	if _, err := newProfileSession(globalSettings{profileName: "test"}); err == nil ||
+		!strings.Contains(err.Error(), `profile "test" not found`) {
+		t.Errorf("unknown profile error = %v", err)
+	}
+}
@@ -0,0 +1,154 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/wphillipmoore/mq-rest-admin-go/mqrestadmin"
+)
+
+// whereOperators lists the comparison operators of the MQSC WHERE clause.
+var whereOperators = []string{"EQ", "NE", "LT", "GT", "LE", "GE", "LK", "NL", "CT", "EX"}
+
+// whereFilter is a parsed --where clause. The filter runs on the client
+// against the rendered attributes, so it uses the same attribute names as
+// the output: snake_case by default, or MQSC names with --mqsc-names.
+type whereFilter struct {
+	attribute string
+	operator  string
+	value     string
+}
+
+// parseWhere parses "attribute OP value". The value may be quoted with
+// single quotes; LK and NL accept a trailing "*" wildcard.
+func parseWhere(clause string) (*whereFilter, error) {
+	fields := strings.Fields(clause)
+	if len(fields) < 3 {
+		return nil, newUsageError("--where %q is not \"attribute OP value\"", clause)
+	}
+	operator := strings.ToUpper(fields[1])
+	if !slices.Contains(whereOperators, operator) {
+		return nil, newUsageError("--where operator %q is not one of %s", fields[1], strings.Join(whereOperators, ", "))
+	}
+
+	value := strings.Join(fields[2:], " ")
+	if len(value) >= 2 && value[0] == '\'' && value[len(value)-1] == '\'' {
+		value = value[1 : len(value)-1]
+	}
+	return &whereFilter{attribute: fields[0], operator: operator, value: value}, nil
+}
+
+// apply returns the rows that match the filter.
+func (filter *whereFilter) apply(rows []map[string]any) []map[string]any {
+	matched := make([]map[string]any, 0, len(rows))
+	for _, row := range rows {
+		if filter.matches(row) {
+			matched = append(matched, row)
+		}
+	}
+	return matched
+}
+
+// matches reports whether a row satisfies the filter. Rows without the
+// attribute never match. Numbers compare numerically when both sides are
+// numeric; other values compare as case-insensitive strings.
+func (filter *whereFilter) matches(row map[string]any) bool {
+	var value any
+	found := false
+	for key, candidate := range row {
+		if strings.EqualFold(key, filter.attribute) {
+			value, found = candidate, true
+			break
+		}
+	}
+	if !found {
+		return false
+	}
+
+	switch filter.operator {
+	case "CT", "EX":
+		contains := slices.ContainsFunc(listItems(value), func(item string) bool {
+			return strings.EqualFold(item, filter.value)
+		})
+		return contains == (filter.operator == "CT")
+	case "LK", "NL":
+		actual := strings.ToUpper(formatCell(value))
+		pattern := strings.ToUpper(filter.value)
+		like := actual == pattern
+		if prefix, isGeneric := strings.CutSuffix(pattern, "*"); isGeneric {
+			like = strings.HasPrefix(actual, prefix)
+		}
+		return like == (filter.operator == "LK")
+	}
+
+	comparison := compareValues(value, filter.value)
+	switch filter.operator {
+	case "EQ":
+		return comparison == 0
+	case "NE":
+		return comparison != 0
+	case "LT":
+		return comparison < 0
+	case "GT":
+		return comparison > 0
+	case "LE":
+		return comparison <= 0
+	default:
+		return comparison >= 0
+	}
+}
+
+// compareValues compares an attribute value with a clause value.
+func compareValues(value any, want string) int {
+	actualText := formatCell(value)
+	actualNumber, actualErr := strconv.ParseFloat(actualText, 64)
+	wantNumber, wantErr := strconv.ParseFloat(want, 64)
+	if actualErr == nil && wantErr == nil {
+		switch {
+		case actualNumber < wantNumber:
+			return -1
+		case actualNumber > wantNumber:
+			return 1
+		default:
+			return 0
+		}
+	}
+	return strings.Compare(strings.ToUpper(actualText), strings.ToUpper(want))
+}
+
+// listItems returns the items of a list value, or the value itself as a
+// one-item list.
+func listItems(value any) []string {
+	switch typed := value.(type) {
+	case []any:
+		items := make([]string, len(typed))
+		for index, item := range typed {
+			items[index] = formatCell(item)
+		}
+		return items
+	case []string:
+		return typed
+	default:
+		return []string{formatCell(value)}
+	}
+}
+
+// formatCell renders a value for table and CSV output and for comparison:
+// lists are comma-separated and nil is empty.
+func formatCell(value any) string {
+	switch typed := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return typed
+	case []any, []string:
+		return strings.Join(listItems(typed), ",")
+	default:
+		if text, isNumber := mqrestadmin.FormatNumber(typed); isNumber {
+			return text
+		}
+		return fmt.Sprint(typed)
+	}
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestParseWhere(t *testing.T) {
+	filter, err := parseWhere("description lk 'Order queue*'")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if *filter != (whereFilter{attribute: "description", operator: "LK", value: "Order queue*"}) {
+		t.Errorf("filter = %+v", *filter)
+	}
+
+	if _, err := parseWhere("depth ABOUT 5"); err == nil || !strings.Contains(err.Error(), `operator "ABOUT" is not one of`) {
+		t.Errorf("bad operator error = %v", err)
+	}
+}
+
+func TestWhereFilterMatches(t *testing.T) {
+	row := map[string]any{
+		"current_queue_depth": float64(12),
+		"queue_name":          "APP.ORDERS",
+		"cluster_namelist":    []any{"CLUS1", "CLUS2"},
+		"max_queue_depth":     json.Number("5000"),
+	}
+	tests := []struct {
+		clause string
+		want   bool
+	}{
+		{"current_queue_depth EQ 12", true},
+		{"current_queue_depth NE 12", false},
+		{"current_queue_depth LT 100", true},
+		{"current_queue_depth GT 100", false},
+		{"current_queue_depth LE 12", true},
+		{"current_queue_depth GE 13", false},
+		{"max_queue_depth EQ 5000", true},
+		{"QUEUE_NAME EQ app.orders", true},
+		{"queue_name LT APP.P", true},
+		{"queue_name GT APP.P", false},
+		{"queue_name LK APP.*", true},
+		{"queue_name LK APP", false},
+		{"queue_name NL SYSTEM.*", true},
+		{"queue_name LK app.orders", true},
+		{"cluster_namelist CT clus2", true},
+		{"cluster_namelist EX CLUS1", false},
+		{"queue_name CT APP.ORDERS", true},
+		{"missing_attribute EQ 1", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.clause, func(t *testing.T) {
+			filter, err := parseWhere(tt.clause)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := filter.matches(row); got != tt.want {
+				t.Errorf("matches() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatCell(t *testing.T) {
+	tests := []struct {
+		value any
+		want  string
+	}{
+		{nil, ""},
+		{"text", "text"},
+		{float64(1.5), "1.5"},
+		{json.Number("42"), "42"},
+		{[]any{"A", float64(2)}, "A,2"},
+		{[]string{"X", "Y"}, "X,Y"},
+		{true, "true"},
+	}
+	for _, tt := range tests {
+		if got := formatCell(tt.value); got != tt.want {
+			t.Errorf("formatCell(%#v) = %q, want %q", tt.value, got, tt.want)
+		}
+	}
+}
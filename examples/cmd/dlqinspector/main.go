@@ -1,7 +1,8 @@
 // Dead letter queue inspector example.
 //
 // Checks the dead letter queue configuration, reports depth and
-// capacity, and suggests actions when messages are present.
+// capacity, and suggests actions when messages are present. When the
+// queue holds messages, they are summarised by reason and destination.
 //
 // Usage:
 //
@@ -33,9 +34,15 @@ func main() {
 		log.Fatal(err)
 	}
 
-	if _, err := examples.PrintDLQInspection(ctx, session); err != nil {
+	report, err := examples.PrintDLQInspection(ctx, session)
+	if err != nil {
 		log.Fatal(err)
 	}
+	if report.CurrentDepth > 0 {
+		if _, err := examples.PrintDLQTriage(ctx, session); err != nil {
+			log.Fatal(err)
+		}
+	}
 }
 
 func envOr(key, fallback string) string {
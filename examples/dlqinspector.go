@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	"github.com/wphillipmoore/mq-rest-admin-go/mqrestadmin"
+	"github.com/wphillipmoore/mq-rest-admin-go/mqrestadmin/dlq"
 )
 
 const criticalDepthPct = 90.0
@@ -104,3 +105,28 @@ func PrintDLQInspection(ctx context.Context, session *mqrestadmin.Session) (DLQR
 	fmt.Printf("  Suggestion: %s\n", report.Suggestion)
 	return report, nil
 }
+
+// PrintDLQTriage browses the messages on the dead letter queue, decodes
+// their dead-letter headers, and prints them grouped by reason and by
+// original destination.
+func PrintDLQTriage(ctx context.Context, session *mqrestadmin.Session) (dlq.Summary, error) {
+	inspector, err := dlq.New(ctx, session)
+	if err != nil {
+		return dlq.Summary{}, err
+	}
+	entries, err := inspector.Browse(ctx)
+	if err != nil {
+		return dlq.Summary{}, err
+	}
+	summary := dlq.Summarize(entries)
+
+	fmt.Printf("\n=== Dead Letter Messages: %s ===\n", inspector.QueueName())
+	fmt.Printf("  Messages:   %d (%d without a dead-letter header)\n", summary.Total, summary.Undecoded)
+	for _, reason := range summary.ByReason {
+		fmt.Printf("  Reason:     %-5d %-32s %d\n", reason.Reason, reason.Name, reason.Count)
+	}
+	for _, destination := range summary.ByDestination {
+		fmt.Printf("  Sent to:    %s on %s  %d\n", destination.Queue, destination.Qmgr, destination.Count)
+	}
+	return summary, nil
+}
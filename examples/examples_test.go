@@ -2,7 +2,10 @@ package examples
 
 import (
 	"context"
+	"encoding/binary"
+	"fmt"
 	"testing"
+	"time"
 
 	"github.com/wphillipmoore/mq-rest-admin-go/mqrestadmin"
 )
@@ -135,6 +138,55 @@ func TestCheckHealth_ListenerError(t *testing.T) {
 	}
 }
 
+// newStateTestSession returns a session whose transport answers the
+// queue manager state resource with state before any MQSC responses.
+func newStateTestSession(t *testing.T, state string) (*mqrestadmin.Session, *messagingTransport) {
+	t.Helper()
+	transport := &messagingTransport{mockTransport: &mockTransport{}}
+	transport.messaging = []*mqrestadmin.TransportResponse{
+		{StatusCode: 200, Body: `{"qmgr":[{"name":"QM1","state":"` + state + `"}]}`},
+	}
+	session, err := mqrestadmin.NewSession("https://localhost:9443/ibmmq/rest/v2", "QM1",
+		mqrestadmin.BasicAuth{Username: "admin", Password: "admin"},
+		mqrestadmin.WithTransport(transport),
+		mqrestadmin.WithMapAttributes(false),
+	)
+	if err != nil {
+		t.Fatalf("NewSession: %v", err)
+	}
+	return session, transport
+}
+
+func TestCheckHealth_QmgrEnded(t *testing.T) {
+	session, transport := newStateTestSession(t, "ended")
+
+	result := CheckHealth(context.Background(), session)
+
+	if result.State != "ended" {
+		t.Errorf("State = %q, want %q", result.State, "ended")
+	}
+	if result.Reachable || result.Passed {
+		t.Errorf("result = %+v, want unreachable and failed", result)
+	}
+	if len(transport.calls) != 0 {
+		t.Errorf("issued %d MQSC commands, want none", len(transport.calls))
+	}
+}
+
+func TestCheckHealth_QmgrRunning(t *testing.T) {
+	session, transport := newStateTestSession(t, "running")
+	transport.addSuccessResponse(map[string]any{"queue_manager_name": "QM1"})
+	transport.addSuccessResponse(map[string]any{"ha_status": "ACTIVE"})
+	transport.addSuccessResponse(map[string]any{"status": "RUNNING"})
+	transport.addSuccessResponse()
+
+	results := PrintHealthCheck(context.Background(), []*mqrestadmin.Session{session})
+
+	if results[0].State != "running" || !results[0].Passed {
+		t.Errorf("result = %+v, want running and passed", results[0])
+	}
+}
+
 func TestPrintHealthCheck_PassAndFail(t *testing.T) {
 	transport := &mockTransport{}
 	// Session 1: healthy
@@ -611,6 +663,81 @@ func TestPrintDLQInspection_Error(t *testing.T) {
 	}
 }
 
+// messagingTransport adds messaging responses to mockTransport.
+type messagingTransport struct {
+	*mockTransport
+	messaging []*mqrestadmin.TransportResponse
+}
+
+func (transport *messagingTransport) SendRequest(_ context.Context, _, _ string, _ []byte,
+	_ map[string]string, _ time.Duration, _ bool,
+) (*mqrestadmin.TransportResponse, error) {
+	if len(transport.messaging) == 0 {
+		return nil, fmt.Errorf("no messaging response configured")
+	}
+	response := transport.messaging[0]
+	transport.messaging = transport.messaging[1:]
+	return response, nil
+}
+
+// deadLetterBody returns a message body with a big-endian MQDLH.
+func deadLetterBody(reason int, queue string) string {
+	body := []byte("DLH ")
+	body = binary.BigEndian.AppendUint32(body, 1)
+	body = binary.BigEndian.AppendUint32(body, uint32(reason))
+	body = append(body, fmt.Sprintf("%-48s%-48s", queue, "QM1")...)
+	body = append(body, make([]byte, 8)...)
+	body = append(body, "MQSTR   "...)
+	body = append(body, make([]byte, 4)...)
+	body = append(body, fmt.Sprintf("%-28s%s", "app", "2026101812000000")...)
+	return string(body) + "payload"
+}
+
+func TestPrintDLQTriage(t *testing.T) {
+	transport := &messagingTransport{mockTransport: &mockTransport{}}
+	transport.addSuccessResponse(map[string]any{"dead_letter_queue_name": "MY.DLQ"})
+	transport.messaging = []*mqrestadmin.TransportResponse{
+		{StatusCode: 200, Body: `{"messages":[{"messageId":"A"},{"messageId":"B"}]}`},
+		{StatusCode: 200, Body: deadLetterBody(2085, "APP.MISSING")},
+		{StatusCode: 200, Body: deadLetterBody(2053, "APP.FULL")},
+	}
+	session, err := mqrestadmin.NewSession("https://localhost:9443/ibmmq/rest/v2", "QM1",
+		mqrestadmin.BasicAuth{Username: "admin", Password: "admin"},
+		mqrestadmin.WithTransport(transport),
+	)
+	if err != nil {
+		t.Fatalf("NewSession: %v", err)
+	}
+
+	summary, err := PrintDLQTriage(context.Background(), session)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if summary.Total != 2 || len(summary.ByReason) != 2 || summary.ByDestination[0].Qmgr != "QM1" {
+		t.Errorf("summary = %+v", summary)
+	}
+}
+
+func TestPrintDLQTriage_Errors(t *testing.T) {
+	transport := &messagingTransport{mockTransport: &mockTransport{}}
+	transport.addCommandErrorResponse(2, 2085)
+	transport.addSuccessResponse(map[string]any{"dead_letter_queue_name": "MY.DLQ"})
+	session, err := mqrestadmin.NewSession("https://localhost:9443/ibmmq/rest/v2", "QM1",
+		mqrestadmin.BasicAuth{Username: "admin", Password: "admin"},
+		mqrestadmin.WithTransport(transport),
+	)
+	if err != nil {
+		t.Fatalf("NewSession: %v", err)
+	}
+
+	if _, err := PrintDLQTriage(context.Background(), session); err == nil {
+		t.Error("expected DisplayQmgr error")
+	}
+	if _, err := PrintDLQTriage(context.Background(), session); err == nil {
+		t.Error("expected browse error")
+	}
+}
+
 // ---------------------------------------------------------------------------
 // queuestatus.go
 // ---------------------------------------------------------------------------
@@ -18,13 +18,17 @@ type ListenerResult struct {
 type QMHealthResult struct {
 	QmgrName      string
 	Reachable     bool
+	State         string
 	Status        string
 	CommandServer string
 	Listeners     []ListenerResult
 	Passed        bool
 }
 
-// CheckHealth runs a health check against a single queue manager.
+// CheckHealth runs a health check against a single queue manager. State is
+// the queue manager state reported by the mqweb server; a queue manager that
+// is not running fails with its state set and no MQSC commands issued, so it
+// is not mistaken for a command failure.
 func CheckHealth(ctx context.Context, session *mqrestadmin.Session) QMHealthResult {
 	result := QMHealthResult{
 		QmgrName:      session.QmgrName(),
@@ -32,6 +36,13 @@ func CheckHealth(ctx context.Context, session *mqrestadmin.Session) QMHealthResu
 		CommandServer: "UNKNOWN",
 	}
 
+	if qmgr, err := session.QmgrState(ctx); err == nil {
+		result.State = string(qmgr.State)
+		if !qmgr.State.Running() {
+			return result
+		}
+	}
+
 	qmgr, err := session.DisplayQmgr(ctx)
 	if err != nil {
 		return result
@@ -83,6 +94,9 @@ func PrintHealthCheck(ctx context.Context, sessions []*mqrestadmin.Session) []QM
 		}
 		fmt.Printf("\n=== %s: %s ===\n", r.QmgrName, verdict)
 		fmt.Printf("  Reachable:      %t\n", r.Reachable)
+		if r.State != "" {
+			fmt.Printf("  State:          %s\n", r.State)
+		}
 		fmt.Printf("  Status:         %s\n", r.Status)
 		fmt.Printf("  Command server: %s\n", r.CommandServer)
 		fmt.Printf("  Listeners:      %d\n", len(r.Listeners))
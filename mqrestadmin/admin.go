@@ -0,0 +1,159 @@
+package mqrestadmin
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+const (
+	installationEndpoint = "/admin/installation"
+	qmgrListEndpoint     = "/admin/qmgr"
+	qmgrEndpoint         = "/admin/qmgr/%s"
+)
+
+// Installation is an IBM MQ installation reported by the mqweb server.
+type Installation struct {
+	Name     string
+	Version  string
+	Platform string
+	// Extended holds the installation's extended attributes, such as its
+	// installation and data paths, as returned by the REST API.
+	Extended map[string]any
+}
+
+// QmgrState is a queue manager's state as reported by the mqweb server.
+type QmgrState string
+
+// QmgrState values.
+const (
+	QmgrStateRunning            QmgrState = "running"
+	QmgrStateRunningAsStandby   QmgrState = "runningAsStandby"
+	QmgrStateRunningElsewhere   QmgrState = "runningElsewhere"
+	QmgrStateStarting           QmgrState = "starting"
+	QmgrStateQuiescing          QmgrState = "quiescing"
+	QmgrStateEndingImmediately  QmgrState = "endingImmediately"
+	QmgrStateEndingPreemptively QmgrState = "endingPreemptively"
+	QmgrStateEnded              QmgrState = "ended"
+	QmgrStateEndedImmediately   QmgrState = "endedImmediately"
+	QmgrStateEndedPreemptively  QmgrState = "endedPreemptively"
+	QmgrStateEndedUnexpectedly  QmgrState = "endedUnexpectedly"
+	QmgrStateBeingDeleted       QmgrState = "beingDeleted"
+	QmgrStateNotAvailable       QmgrState = "stateNotAvailable"
+)
+
+// Running reports whether the queue manager is running and accepting
+// commands on this server.
+func (state QmgrState) Running() bool {
+	return state == QmgrStateRunning
+}
+
+// Standby reports whether the queue manager is a standby instance, or is
+// running on another server.
+func (state QmgrState) Standby() bool {
+	return state == QmgrStateRunningAsStandby || state == QmgrStateRunningElsewhere
+}
+
+// Ended reports whether the queue manager has ended, normally or not.
+func (state QmgrState) Ended() bool {
+	return strings.HasPrefix(string(state), "ended")
+}
+
+// QmgrInfo is a queue manager known to the mqweb server.
+type QmgrInfo struct {
+	Name  string
+	State QmgrState
+	// InstallationName is the installation the queue manager belongs to.
+	InstallationName string
+	// Extended holds the queue manager's extended attributes as returned
+	// by the REST API.
+	Extended map[string]any
+}
+
+// Installations returns the IBM MQ installations on the mqweb server's
+// host, from GET /admin/installation.
+//
+// The installation and queue manager resources are served by mqweb
+// itself, so they answer even when a queue manager or its command server
+// is down. The session's transport must implement RequestTransport.
+func (session *Session) Installations(ctx context.Context) ([]Installation, error) {
+	var body struct {
+		Installation []struct {
+			Name     string         `json:"name"`
+			Version  string         `json:"version"`
+			Platform string         `json:"platform"`
+			Extended map[string]any `json:"extended"`
+		} `json:"installation"`
+	}
+	if err := session.getResource(ctx, installationEndpoint, &body); err != nil {
+		return nil, err
+	}
+
+	installations := make([]Installation, len(body.Installation))
+	for index, installation := range body.Installation {
+		installations[index] = Installation(installation)
+	}
+	return installations, nil
+}
+
+// ListQmgrs returns every queue manager on the mqweb server's host and its
+// state, from GET /admin/qmgr. Like Installations, it answers when
+// queue managers are stopped.
+func (session *Session) ListQmgrs(ctx context.Context) ([]QmgrInfo, error) {
+	return session.getQmgrs(ctx, qmgrListEndpoint)
+}
+
+// QmgrState returns the session's queue manager and its state, from
+// GET /admin/qmgr/{qmgr}. Unlike DisplayQmstatus, it reports a stopped or
+// standby queue manager instead of failing, so health checks can tell a
+// stopped queue manager from an MQSC failure.
+func (session *Session) QmgrState(ctx context.Context) (QmgrInfo, error) {
+	qmgrs, err := session.getQmgrs(ctx, fmt.Sprintf(qmgrEndpoint, url.PathEscape(session.qmgrName)))
+	if err != nil {
+		return QmgrInfo{}, err
+	}
+	if len(qmgrs) == 0 {
+		return QmgrInfo{}, fmt.Errorf("queue manager %s was not reported by the REST API", session.qmgrName)
+	}
+	return qmgrs[0], nil
+}
+
+// getQmgrs reads a queue manager resource.
+func (session *Session) getQmgrs(ctx context.Context, endpoint string) ([]QmgrInfo, error) {
+	var body struct {
+		Qmgr []struct {
+			Name     string         `json:"name"`
+			State    QmgrState      `json:"state"`
+			Extended map[string]any `json:"extended"`
+		} `json:"qmgr"`
+	}
+	if err := session.getResource(ctx, endpoint, &body); err != nil {
+		return nil, err
+	}
+
+	qmgrs := make([]QmgrInfo, len(body.Qmgr))
+	for index, qmgr := range body.Qmgr {
+		qmgrs[index] = QmgrInfo{Name: qmgr.Name, State: qmgr.State, Extended: qmgr.Extended}
+		qmgrs[index].InstallationName, _ = qmgr.Extended["installationName"].(string)
+	}
+	return qmgrs, nil
+}
+
+// getResource issues a GET for an admin resource with all attributes and
+// decodes its JSON body into target. Error statuses and bodies that are not
+// JSON are returned as a ResponseError.
+func (session *Session) getResource(ctx context.Context, endpoint string, target any) error {
+	requestURL := session.restBaseURL + endpoint + "?attributes=*"
+	response, err := session.sendRequest(ctx, http.MethodGet, requestURL, nil,
+		map[string]string{"Accept": "application/json"})
+	if err != nil {
+		return err
+	}
+	if response.StatusCode >= http.StatusBadRequest || json.Unmarshal([]byte(response.Body), target) != nil {
+		return &ResponseError{ResponseText: response.Body, StatusCode: response.StatusCode}
+	}
+	return nil
+}
@@ -0,0 +1,114 @@
+package mqrestadmin
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestInstallations(t *testing.T) {
+	session, transport := newMessagingTestSession()
+	transport.addMessageResponse(200, `{"installation":[{"name":"Installation1","version":"9.4.0.0","platform":"unix",`+
+		`"extended":{"installationPath":"/opt/mqm","dataPath":"/var/mqm"}}]}`, nil)
+
+	installations, err := session.Installations(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []Installation{{
+		Name:     "Installation1",
+		Version:  "9.4.0.0",
+		Platform: "unix",
+		Extended: map[string]any{"installationPath": "/opt/mqm", "dataPath": "/var/mqm"},
+	}}
+	if !reflect.DeepEqual(installations, want) {
+		t.Errorf("installations = %+v, want %+v", installations, want)
+	}
+
+	call := transport.lastCall()
+	if transport.methods[0] != "GET" || call.URL != "https://localhost:9443/ibmmq/rest/v2/admin/installation?attributes=*" {
+		t.Errorf("request = %s %s", transport.methods[0], call.URL)
+	}
+	if call.Headers["Authorization"] == "" || call.Headers["Accept"] != "application/json" {
+		t.Errorf("headers = %v", call.Headers)
+	}
+}
+
+func TestListQmgrs(t *testing.T) {
+	session, transport := newMessagingTestSession()
+	transport.addMessageResponse(200, `{"qmgr":[`+
+		`{"name":"QM1","state":"running","extended":{"installationName":"Installation1"}},`+
+		`{"name":"QM2","state":"endedImmediately"}]}`, nil)
+
+	qmgrs, err := session.ListQmgrs(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(qmgrs) != 2 {
+		t.Fatalf("len(qmgrs) = %d, want 2", len(qmgrs))
+	}
+	if qmgrs[0].Name != "QM1" || !qmgrs[0].State.Running() || qmgrs[0].InstallationName != "Installation1" {
+		t.Errorf("qmgrs[0] = %+v", qmgrs[0])
+	}
+	if qmgrs[1].State != QmgrStateEndedImmediately || !qmgrs[1].State.Ended() || qmgrs[1].InstallationName != "" {
+		t.Errorf("qmgrs[1] = %+v", qmgrs[1])
+	}
+	if url := transport.lastCall().URL; !strings.HasSuffix(url, "/admin/qmgr?attributes=*") {
+		t.Errorf("URL = %q", url)
+	}
+}
+
+func TestQmgrState(t *testing.T) {
+	session, transport := newMessagingTestSession()
+	transport.addMessageResponse(200, `{"qmgr":[{"name":"QM1","state":"runningAsStandby"}]}`, nil)
+	transport.addMessageResponse(200, `{"qmgr":[]}`, nil)
+
+	qmgr, err := session.QmgrState(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if qmgr.State != QmgrStateRunningAsStandby || !qmgr.State.Standby() || qmgr.State.Running() || qmgr.State.Ended() {
+		t.Errorf("qmgr = %+v", qmgr)
+	}
+	if url := transport.lastCall().URL; !strings.HasSuffix(url, "/admin/qmgr/QM1?attributes=*") {
+		t.Errorf("URL = %q", url)
+	}
+
+	if _, err := session.QmgrState(context.Background()); err == nil || !strings.Contains(err.Error(), "QM1") {
+		t.Errorf("error = %v, want missing queue manager", err)
+	}
+}
+
+func TestAdminResources_Errors(t *testing.T) {
+	session, transport := newMessagingTestSession()
+	transport.addMessageResponse(404, `{"error":[{"msgId":"MQWB0009E","reasonCode":2058}]}`, nil)
+	transport.addMessageResponse(200, "not json", nil)
+	transport.addMessageResponse(403, "", nil)
+	transport.addErrorResponse(errors.New("connection refused"))
+
+	_, err := session.QmgrState(context.Background())
+	var responseErr *ResponseError
+	if !errors.As(err, &responseErr) || responseErr.StatusCode != 404 {
+		t.Errorf("error = %v, want 404 ResponseError", err)
+	}
+
+	if _, err := session.Installations(context.Background()); !errors.As(err, &responseErr) || responseErr.StatusCode != 200 {
+		t.Errorf("error = %v, want ResponseError for invalid JSON", err)
+	}
+
+	var authErr *AuthError
+	if _, err := session.ListQmgrs(context.Background()); !errors.As(err, &authErr) {
+		t.Errorf("error = %v, want AuthError", err)
+	}
+
+	if _, err := session.Installations(context.Background()); err == nil || err.Error() != "connection refused" {
+		t.Errorf("error = %v, want transport failure", err)
+	}
+
+	if _, err := newTestSession(newMockTransport()).ListQmgrs(context.Background()); err == nil ||
+		!strings.Contains(err.Error(), "RequestTransport") {
+		t.Errorf("error = %v, want unsupported transport", err)
+	}
+}
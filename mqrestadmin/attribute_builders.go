@@ -0,0 +1,144 @@
+package mqrestadmin
+
+import (
+	"reflect"
+	"strings"
+)
+
+// RequestAttributes is implemented by the typed attribute builders
+// (QlocalAttrs, QremoteAttrs, QaliasAttrs, ChannelAttrs). RequestParameters
+// returns the snake_case parameter map that the attribute mapper translates
+// to MQSC names, so builders require a session with mapping enabled.
+type RequestAttributes interface {
+	RequestParameters() map[string]any
+}
+
+// WithAttributes merges a typed attribute builder into the command's request
+// parameters. Builder values take precedence over entries with the same name
+// supplied via WithRequestParameters.
+func WithAttributes(attributes RequestAttributes) CommandOption {
+	return func(config *commandConfig) {
+		merged := make(map[string]any, len(config.requestParameters))
+		for key, value := range config.requestParameters {
+			merged[key] = value
+		}
+		for key, value := range attributes.RequestParameters() {
+			merged[key] = value
+		}
+		config.requestParameters = merged
+	}
+}
+
+// QlocalAttrs holds typed attributes for DEFINE and ALTER QLOCAL. Zero-valued
+// fields are omitted; use WithRequestParameters to set an attribute to zero
+// or to set attributes not covered here.
+type QlocalAttrs struct {
+	Description         string                  `mq:"description"`
+	Usage               QueueUsage              `mq:"usage"`
+	MaxDepth            int                     `mq:"max_queue_depth"`
+	MaxMessageLength    int                     `mq:"max_message_length"`
+	DefaultPersistence  QueueDefaultPersistence `mq:"default_persistence"`
+	DefaultPriority     int                     `mq:"default_priority"`
+	InhibitGet          QueueAccess             `mq:"inhibit_get"`
+	InhibitPut          QueueAccess             `mq:"inhibit_put"`
+	BackoutThreshold    int                     `mq:"backout_threshold"`
+	BackoutRequeueName  string                  `mq:"backout_requeue_name"`
+	InitiationQueueName string                  `mq:"initiation_queue_name"`
+	ProcessName         string                  `mq:"process_name"`
+	ClusterName         string                  `mq:"cluster_name"`
+	QueueDepthHighLimit int                     `mq:"queue_depth_high_limit"`
+	QueueDepthLowLimit  int                     `mq:"queue_depth_low_limit"`
+}
+
+// RequestParameters returns the non-zero attributes as a snake_case map.
+func (attrs QlocalAttrs) RequestParameters() map[string]any {
+	return structToParameters(attrs)
+}
+
+// QremoteAttrs holds typed attributes for DEFINE and ALTER QREMOTE. Zero-valued
+// fields are omitted.
+type QremoteAttrs struct {
+	Description            string                  `mq:"description"`
+	RemoteQueueName        string                  `mq:"remote_queue_name"`
+	RemoteQueueManagerName string                  `mq:"remote_queue_manager_name"`
+	TransmissionQueueName  string                  `mq:"transmission_queue_name"`
+	DefaultPersistence     QueueDefaultPersistence `mq:"default_persistence"`
+	DefaultPriority        int                     `mq:"default_priority"`
+	InhibitPut             QueueAccess             `mq:"inhibit_put"`
+	ClusterName            string                  `mq:"cluster_name"`
+}
+
+// RequestParameters returns the non-zero attributes as a snake_case map.
+func (attrs QremoteAttrs) RequestParameters() map[string]any {
+	return structToParameters(attrs)
+}
+
+// QaliasAttrs holds typed attributes for DEFINE and ALTER QALIAS. Zero-valued
+// fields are omitted.
+type QaliasAttrs struct {
+	Description        string                  `mq:"description"`
+	TargetQueueName    string                  `mq:"target_queue_name"`
+	TargetType         QueueTargetType         `mq:"target_type"`
+	DefaultPersistence QueueDefaultPersistence `mq:"default_persistence"`
+	DefaultPriority    int                     `mq:"default_priority"`
+	InhibitGet         QueueAccess             `mq:"inhibit_get"`
+	InhibitPut         QueueAccess             `mq:"inhibit_put"`
+	ClusterName        string                  `mq:"cluster_name"`
+}
+
+// RequestParameters returns the non-zero attributes as a snake_case map.
+func (attrs QaliasAttrs) RequestParameters() map[string]any {
+	return structToParameters(attrs)
+}
+
+// ChannelAttrs holds typed attributes for DEFINE and ALTER CHANNEL. Zero-valued
+// fields are omitted.
+type ChannelAttrs struct {
+	ChannelType           ChannelType          `mq:"channel_type"`
+	TransportType         ChannelTransportType `mq:"transport_type"`
+	Description           string               `mq:"description"`
+	ConnectionName        string               `mq:"connection_name"`
+	TransmissionQueueName string               `mq:"transmission_queue_name"`
+	MCAUser               string               `mq:"mca_user"`
+	HeartbeatInterval     int                  `mq:"heartbeat_interval"`
+	DisconnectInterval    int                  `mq:"disconnect_interval"`
+	MaxMessageLength      int                  `mq:"max_message_length"`
+	MaxInstances          int                  `mq:"max_instances"`
+	MaxInstancesPerClient int                  `mq:"max_instances_per_client"`
+	SharingConversations  int                  `mq:"sharing_conversations"`
+	BatchSize             int                  `mq:"batch_size"`
+	SSLCipherSpec         string               `mq:"ssl_cipher_spec"`
+	SSLPeerName           string               `mq:"ssl_peer_name"`
+}
+
+// RequestParameters returns the non-zero attributes as a snake_case map.
+func (attrs ChannelAttrs) RequestParameters() map[string]any {
+	return structToParameters(attrs)
+}
+
+// structToParameters converts a builder struct into a parameter map keyed by
+// its mq struct tags. Named string types are converted to plain strings so
+// the attribute mapper's value maps apply to them.
+func structToParameters(attrs any) map[string]any {
+	value := reflect.ValueOf(attrs)
+	valueType := value.Type()
+	params := make(map[string]any, valueType.NumField())
+
+	for idx := range valueType.NumField() {
+		name, _, _ := strings.Cut(valueType.Field(idx).Tag.Get("mq"), ",")
+		field := value.Field(idx)
+		if name == "" || field.IsZero() {
+			continue
+		}
+		switch field.Kind() {
+		case reflect.String:
+			params[name] = field.String()
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			params[name] = int(field.Int())
+		default:
+			params[name] = field.Interface()
+		}
+	}
+
+	return params
+}
@@ -0,0 +1,220 @@
+package mqrestadmin
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestQlocalAttrs_RequestParameters_OmitsZeroValues(t *testing.T) {
+	params := QlocalAttrs{
+		Description:        "app queue",
+		Usage:              QueueUsageXmitq,
+		MaxDepth:           5000,
+		DefaultPersistence: QueueDefaultPersistenceNotFixed,
+	}.RequestParameters()
+
+	want := map[string]any{
+		"description":         "app queue",
+		"usage":               "XMITQ",
+		"max_queue_depth":     5000,
+		"default_persistence": "not_fixed",
+	}
+	if !reflect.DeepEqual(params, want) {
+		t.Errorf("RequestParameters() = %v, want %v", params, want)
+	}
+}
+
+func TestQlocalAttrs_ValuesArePlainStrings(t *testing.T) {
+	params := QlocalAttrs{Usage: QueueUsageNormal}.RequestParameters()
+
+	if _, isString := params["usage"].(string); !isString {
+		t.Errorf("usage = %T, want plain string", params["usage"])
+	}
+}
+
+func TestTypedAttrs_RequestParameters(t *testing.T) {
+	tests := []struct {
+		name  string
+		attrs RequestAttributes
+		want  map[string]any
+	}{
+		{
+			name: "qremote",
+			attrs: QremoteAttrs{
+				RemoteQueueName:        "TARGET.Q",
+				RemoteQueueManagerName: "QM2",
+				TransmissionQueueName:  "QM2.XMITQ",
+			},
+			want: map[string]any{
+				"remote_queue_name":         "TARGET.Q",
+				"remote_queue_manager_name": "QM2",
+				"transmission_queue_name":   "QM2.XMITQ",
+			},
+		},
+		{
+			name:  "qalias",
+			attrs: QaliasAttrs{TargetQueueName: "APP.Q", TargetType: QueueTargetTypeQueue},
+			want:  map[string]any{"target_queue_name": "APP.Q", "target_type": "QUEUE"},
+		},
+		{
+			name: "channel",
+			attrs: ChannelAttrs{
+				ChannelType:    ChannelTypeSender,
+				TransportType:  ChannelTransportTypeTCP,
+				ConnectionName: "host(1414)",
+				BatchSize:      50,
+			},
+			want: map[string]any{
+				"channel_type":    "SDR",
+				"transport_type":  "TCP",
+				"connection_name": "host(1414)",
+				"batch_size":      50,
+			},
+		},
+		{
+			name:  "empty",
+			attrs: QlocalAttrs{},
+			want:  map[string]any{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.attrs.RequestParameters()
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("RequestParameters() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStructToParameters_OtherKindsAndUntaggedFields(t *testing.T) {
+	params := structToParameters(struct {
+		Enabled  bool   `mq:"enabled"`
+		Ignored  string `mq:""`
+		Disabled bool   `mq:"disabled"`
+	}{Enabled: true, Ignored: "value"})
+
+	want := map[string]any{"enabled": true}
+	if !reflect.DeepEqual(params, want) {
+		t.Errorf("structToParameters() = %v, want %v", params, want)
+	}
+}
+
+func TestWithAttributes_MergesWithRequestParameters(t *testing.T) {
+	config := buildCommandConfig([]CommandOption{
+		WithRequestParameters(map[string]any{"description": "old", "trigger_depth": 5}),
+		WithAttributes(QlocalAttrs{Description: "new", MaxDepth: 100}),
+	})
+
+	want := map[string]any{"description": "new", "trigger_depth": 5, "max_queue_depth": 100}
+	if !reflect.DeepEqual(config.requestParameters, want) {
+		t.Errorf("requestParameters = %v, want %v", config.requestParameters, want)
+	}
+}
+
+func TestWithAttributes_DoesNotMutateCallerMap(t *testing.T) {
+	original := map[string]any{"description": "old"}
+	buildCommandConfig([]CommandOption{
+		WithRequestParameters(original),
+		WithAttributes(QlocalAttrs{Description: "new"}),
+	})
+
+	if original["description"] != "old" {
+		t.Errorf("caller map mutated: %v", original)
+	}
+}
+
+func TestWithAttributes_MapsThroughSession(t *testing.T) {
+	transport := newMockTransport()
+	transport.addSuccessResponse()
+	session := newTestSessionWithMapping(transport)
+
+	err := session.DefineQlocal(context.Background(), "APP.Q",
+		WithAttributes(QlocalAttrs{
+			Usage:              QueueUsageXmitq,
+			MaxDepth:           5000,
+			DefaultPersistence: QueueDefaultPersistenceNotFixed,
+		}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	params, _ := transport.lastCall().Payload["parameters"].(map[string]any)
+	want := map[string]any{"USAGE": "XMITQ", "MAXDEPTH": 5000, "DEFPSIST": "NOTFIXED"}
+	if !reflect.DeepEqual(params, want) {
+		t.Errorf("parameters = %v, want %v", params, want)
+	}
+}
+
+func TestMapRequestAttributes_NamedStringValue(t *testing.T) {
+	mapper, err := newAttributeMapper()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	result, issues := mapper.mapRequestAttributes("queue",
+		map[string]any{"default_persistence": QueueDefaultPersistenceYes}, true)
+	if len(issues) != 0 {
+		t.Fatalf("unexpected issues: %v", issues)
+	}
+	if result["DEFPSIST"] != "YES" {
+		t.Errorf("DEFPSIST = %v, want YES", result["DEFPSIST"])
+	}
+}
+
+func TestMapRequestAttributes_NamedStringCfstructPurge(t *testing.T) {
+	mapper, err := newAttributeMapper()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	result, issues := mapper.mapRequestAttributes("cfstruct",
+		map[string]any{"purge": CfstructPurgeYes}, false)
+	if len(issues) != 0 {
+		t.Fatalf("unexpected issues: %v", issues)
+	}
+	if result["TYPE"] != "PURGE" {
+		t.Errorf("TYPE = %v, want PURGE", result["TYPE"])
+	}
+}
+
+func TestGeneratedEnums_CoverValueMaps(t *testing.T) {
+	mapper, err := newAttributeMapper()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	generated := map[string][]string{
+		"queue.default_persistence": {
+			string(QueueDefaultPersistenceDef), string(QueueDefaultPersistenceNo),
+			string(QueueDefaultPersistenceNotFixed), string(QueueDefaultPersistenceYes),
+		},
+		"cfstruct.purge": {string(CfstructPurgeNo), string(CfstructPurgeYes)},
+	}
+
+	for qualifier, mapping := range mapper.data.Qualifiers {
+		for attribute, values := range mapping.RequestValueMap {
+			constants, exists := generated[qualifier+"."+attribute]
+			if !exists {
+				t.Errorf("no generated enum for %s.%s; run go generate", qualifier, attribute)
+				continue
+			}
+			for value := range values {
+				if !containsString(constants, value) {
+					t.Errorf("no generated constant for %s.%s=%s; run go generate", qualifier, attribute, value)
+				}
+			}
+		}
+	}
+}
+
+func containsString(values []string, target string) bool {
+	for _, value := range values {
+		if value == target {
+			return true
+		}
+	}
+	return false
+}
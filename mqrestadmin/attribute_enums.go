@@ -0,0 +1,64 @@
+package mqrestadmin
+
+// The enums in this file cover closed MQSC vocabularies whose values have no
+// entry in the mapping data's value maps. Their values pass through the
+// attribute mapper unchanged, so the constants hold the native MQSC tokens.
+// Enums for mapped values are generated into attribute_values.go.
+
+// QueueUsage enumerates the values of the queue usage attribute.
+type QueueUsage string
+
+// QueueUsage values.
+const (
+	QueueUsageNormal QueueUsage = "NORMAL"
+	QueueUsageXmitq  QueueUsage = "XMITQ"
+)
+
+// QueueAccess enumerates the values of the queue inhibit_get and inhibit_put
+// attributes.
+type QueueAccess string
+
+// QueueAccess values.
+const (
+	QueueAccessEnabled  QueueAccess = "ENABLED"
+	QueueAccessDisabled QueueAccess = "DISABLED"
+)
+
+// QueueTargetType enumerates the values of the alias queue target_type
+// attribute.
+type QueueTargetType string
+
+// QueueTargetType values.
+const (
+	QueueTargetTypeQueue QueueTargetType = "QUEUE"
+	QueueTargetTypeTopic QueueTargetType = "TOPIC"
+)
+
+// ChannelType enumerates the values of the channel channel_type attribute.
+type ChannelType string
+
+// ChannelType values.
+const (
+	ChannelTypeSender           ChannelType = "SDR"
+	ChannelTypeServer           ChannelType = "SVR"
+	ChannelTypeReceiver         ChannelType = "RCVR"
+	ChannelTypeRequester        ChannelType = "RQSTR"
+	ChannelTypeClientConnection ChannelType = "CLNTCONN"
+	ChannelTypeServerConnection ChannelType = "SVRCONN"
+	ChannelTypeClusterSender    ChannelType = "CLUSSDR"
+	ChannelTypeClusterReceiver  ChannelType = "CLUSRCVR"
+	ChannelTypeAMQP             ChannelType = "AMQP"
+	ChannelTypeMQTT             ChannelType = "MQTT"
+)
+
+// ChannelTransportType enumerates the values of the channel transport_type
+// attribute.
+type ChannelTransportType string
+
+// ChannelTransportType values.
+const (
+	ChannelTransportTypeTCP     ChannelTransportType = "TCP"
+	ChannelTransportTypeLU62    ChannelTransportType = "LU62"
+	ChannelTransportTypeNetBIOS ChannelTransportType = "NETBIOS"
+	ChannelTransportTypeSPX     ChannelTransportType = "SPX"
+)
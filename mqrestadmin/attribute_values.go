@@ -0,0 +1,23 @@
+// Code generated by genvalues from mapping-data.json; DO NOT EDIT.
+
+package mqrestadmin
+
+// CfstructPurge enumerates the mapped values of the cfstruct purge attribute.
+type CfstructPurge string
+
+// CfstructPurge values.
+const (
+	CfstructPurgeNo  CfstructPurge = "no"
+	CfstructPurgeYes CfstructPurge = "yes"
+)
+
+// QueueDefaultPersistence enumerates the mapped values of the queue default_persistence attribute.
+type QueueDefaultPersistence string
+
+// QueueDefaultPersistence values.
+const (
+	QueueDefaultPersistenceDef      QueueDefaultPersistence = "def"
+	QueueDefaultPersistenceNo       QueueDefaultPersistence = "no"
+	QueueDefaultPersistenceNotFixed QueueDefaultPersistence = "not_fixed"
+	QueueDefaultPersistenceYes      QueueDefaultPersistence = "yes"
+)
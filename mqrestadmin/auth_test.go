@@ -143,7 +143,7 @@ func TestBuildHeaders_BasicAuth(t *testing.T) {
 		csrfToken:   ptrString("local"),
 	}
 
-	headers := session.buildHeaders()
+	headers := session.buildHeaders("")
 
 	if headers["Accept"] != "application/json" {
 		t.Errorf("Accept = %q", headers["Accept"])
@@ -162,7 +162,7 @@ func TestBuildHeaders_NoCSRFToken(t *testing.T) {
 		csrfToken:   nil,
 	}
 
-	headers := session.buildHeaders()
+	headers := session.buildHeaders("")
 
 	if _, hasCSRF := headers["ibm-mq-rest-csrf-token"]; hasCSRF {
 		t.Error("should not include CSRF token when nil")
@@ -176,7 +176,7 @@ func TestBuildHeaders_GatewayQmgr(t *testing.T) {
 		csrfToken:   ptrString("local"),
 	}
 
-	headers := session.buildHeaders()
+	headers := session.buildHeaders("")
 
 	if headers["ibm-mq-rest-gateway-qmgr"] != "GATEWAY" {
 		t.Errorf("gateway-qmgr = %q, want GATEWAY", headers["ibm-mq-rest-gateway-qmgr"])
@@ -0,0 +1,219 @@
+package mqrestadmin
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"strings"
+)
+
+// Capabilities describes the queue manager and mqweb installation behind a
+// session, as detected by Session.Capabilities.
+type Capabilities struct {
+	// Platform is the queue manager's MQSC PLATFORM value, such as "UNIX",
+	// "WINDOWSNT" or "MVS".
+	Platform string
+	// CommandLevel is the queue manager's CMDLEVEL, such as 940.
+	CommandLevel int
+	// QmgrVersion is the queue manager's VERSION, such as "09040000". z/OS
+	// queue managers do not report it.
+	QmgrVersion string
+	// InstallationVersion is the version of the mqweb server's installation,
+	// such as "9.4.0.0", or empty when the session's transport does not
+	// implement RequestTransport.
+	InstallationVersion string
+}
+
+// ZOS reports whether the queue manager runs on z/OS.
+func (capabilities Capabilities) ZOS() bool {
+	return capabilities.Platform == "MVS" || capabilities.Platform == "ZOS"
+}
+
+// Supports reports whether the queue manager's platform and command level
+// support an MQSC command and qualifier, such as "DISPLAY" and "USAGE".
+// Commands not known to be specific to one platform or to need a later
+// command level are reported as supported, and so is every command when
+// the command level is unknown.
+func (capabilities Capabilities) Supports(command, qualifier string) bool {
+	return capabilities.requirement(strings.ToUpper(command), strings.ToUpper(qualifier)) == ""
+}
+
+// requirement describes the platform or command level an upper-case
+// command and qualifier require, or returns empty when the queue manager
+// supports them.
+func (capabilities Capabilities) requirement(command, qualifier string) string {
+	platformOnly := distributedOnlyCommands
+	required := "a distributed platform"
+	if !capabilities.ZOS() {
+		platformOnly = zosOnlyCommands
+		required = "z/OS"
+	}
+	if platformOnly[command+" "+qualifier] || platformOnly["* "+qualifier] {
+		return required
+	}
+	level := max(minimumCommandLevels[command+" "+qualifier], minimumCommandLevels["* "+qualifier])
+	if capabilities.CommandLevel != 0 && capabilities.CommandLevel < level {
+		return fmt.Sprintf("command level %d", level)
+	}
+	return ""
+}
+
+// minimumCommandLevels lists MQSC commands added after the first queue
+// managers the REST API can administer, with the CMDLEVEL that introduced
+// them, keyed as for zosOnlyCommands.
+var minimumCommandLevels = map[string]int{
+	"* APSTATUS": 912,
+	"* AUTHREC":  710,
+	"* CHLAUTH":  710,
+	"* COMMINFO": 710,
+	"* PUBSUB":   700,
+	"* SBSTATUS": 700,
+	"* SUB":      700,
+	"* TOPIC":    700,
+	"* TPSTATUS": 700,
+}
+
+// zosOnlyCommands and distributedOnlyCommands list the MQSC commands only
+// one platform supports, keyed by "COMMAND QUALIFIER". A "*" command
+// matches every command on the qualifier.
+var (
+	zosOnlyCommands = map[string]bool{
+		"* ARCHIVE":        true,
+		"* BSDS":           true,
+		"* BUFFPOOL":       true,
+		"* CFSTATUS":       true,
+		"* CFSTRUCT":       true,
+		"* CMDSERV":        true,
+		"* GROUP":          true,
+		"* INDOUBT":        true,
+		"* LOG":            true,
+		"* MAXSMSGS":       true,
+		"* PSID":           true,
+		"* SMDS":           true,
+		"* SMDSCONN":       true,
+		"* STGCLASS":       true,
+		"* SYSTEM":         true,
+		"* THREAD":         true,
+		"* TPIPE":          true,
+		"* TRACE":          true,
+		"* USAGE":          true,
+		"ALTER SECURITY":   true,
+		"DISPLAY CHINIT":   true,
+		"STOP CHINIT":      true,
+		"DISPLAY SECURITY": true,
+		"RVERIFY SECURITY": true,
+		"MOVE QLOCAL":      true,
+		"RESET QSTATS":     true,
+		"START QMGR":       true,
+		"STOP QMGR":        true,
+	}
+	distributedOnlyCommands = map[string]bool{
+		"* AUTHREC":        true,
+		"* AUTHSERV":       true,
+		"* COMMINFO":       true,
+		"* ENTAUTH":        true,
+		"* LSSTATUS":       true,
+		"* QMSTATUS":       true,
+		"* SERVICE":        true,
+		"* SVSTATUS":       true,
+		"ALTER LISTENER":   true,
+		"DEFINE LISTENER":  true,
+		"DELETE LISTENER":  true,
+		"DISPLAY LISTENER": true,
+	}
+)
+
+// Capabilities detects the queue manager's platform, command level and
+// version with DISPLAY QMGR, and the mqweb installation's version from
+// GET /admin/installation. The result is cached on the session and later
+// calls return it without contacting the server.
+//
+// Once capabilities are cached, command methods reject commands the
+// queue manager's platform does not support, such as DISPLAY USAGE or
+// DISPLAY CFSTRUCT on a distributed queue manager, commands its command
+// level predates, such as DISPLAY APSTATUS below 912, and the z/OS-only
+// CMDSCOPE and QSGDISP attributes (WithCommandScope, WithQsgDisposition)
+// on other platforms, with an UnsupportedError before sending them.
+// Commands routed to another queue manager with WithTargetQmgr are not
+// checked, and neither is RunMQSC.
+func (session *Session) Capabilities(ctx context.Context) (Capabilities, error) {
+	if session.capabilities != nil {
+		return *session.capabilities, nil
+	}
+
+	qmgr, err := session.DisplayQmgr(ctx)
+	if err != nil {
+		return Capabilities{}, err
+	}
+	capabilities := Capabilities{
+		Platform:     cmp.Or(stringAttribute(qmgr, "platform"), stringAttribute(qmgr, "PLATFORM")),
+		CommandLevel: intValue(convertInteger(cmp.Or(qmgr["command_level"], qmgr["CMDLEVEL"]))),
+		QmgrVersion:  cmp.Or(stringAttribute(qmgr, "version"), stringAttribute(qmgr, "VERSION")),
+	}
+
+	if _, supported := session.transport.(RequestTransport); supported {
+		capabilities.InstallationVersion, err = session.installationVersion(ctx)
+		if err != nil {
+			return Capabilities{}, err
+		}
+	}
+
+	session.capabilities = &capabilities
+	return capabilities, nil
+}
+
+// installationVersion returns the version of the installation the
+// session's queue manager belongs to, or of the only installation when the
+// host has one.
+func (session *Session) installationVersion(ctx context.Context) (string, error) {
+	installations, err := session.Installations(ctx)
+	if err != nil || len(installations) == 0 {
+		return "", err
+	}
+	if len(installations) == 1 {
+		return installations[0].Version, nil
+	}
+
+	qmgr, err := session.QmgrState(ctx)
+	if err != nil {
+		return "", err
+	}
+	for _, installation := range installations {
+		if installation.Name == qmgr.InstallationName {
+			return installation.Version, nil
+		}
+	}
+	return "", nil
+}
+
+// zosOnlyAttributes lists the MQSC request attributes only z/OS supports.
+var zosOnlyAttributes = []string{"CMDSCOPE", "QSGDISP"}
+
+// checkSupported returns an UnsupportedError when cached capabilities show
+// the session's queue manager does not support a command or one of its
+// MQSC request parameters. Commands for another queue manager are not
+// checked.
+func (session *Session) checkSupported(command, qualifier, targetQmgr string, params map[string]any) error {
+	capabilities := session.capabilities
+	if capabilities == nil || (targetQmgr != "" && targetQmgr != session.qmgrName) {
+		return nil
+	}
+
+	if required := capabilities.requirement(command, qualifier); required != "" {
+		return &UnsupportedError{Command: command + " " + qualifier, Platform: capabilities.Platform, Requires: required}
+	}
+	if capabilities.ZOS() {
+		return nil
+	}
+	for _, attribute := range zosOnlyAttributes {
+		if _, exists := params[attribute]; exists {
+			return &UnsupportedError{
+				Command:   command + " " + qualifier,
+				Attribute: attribute,
+				Platform:  capabilities.Platform,
+				Requires:  "z/OS",
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,194 @@
+package mqrestadmin
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+// newDistributedSession returns a session whose queue manager reports a
+// distributed platform, and its capabilities.
+func newDistributedSession(t *testing.T) (*Session, *mockRequestTransport, Capabilities) {
+	t.Helper()
+	session, transport := newMessagingTestSession()
+	transport.addSuccessResponse(map[string]any{"PLATFORM": "UNIX", "CMDLEVEL": float64(940), "VERSION": "09040000"})
+	transport.addMessageResponse(200, `{"installation":[{"name":"Installation1","version":"9.4.0.0"}]}`, nil)
+
+	capabilities, err := session.Capabilities(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return session, transport, capabilities
+}
+
+func TestCapabilities_Distributed(t *testing.T) {
+	session, transport, capabilities := newDistributedSession(t)
+
+	want := Capabilities{Platform: "UNIX", CommandLevel: 940, QmgrVersion: "09040000", InstallationVersion: "9.4.0.0"}
+	if capabilities != want {
+		t.Errorf("capabilities = %+v, want %+v", capabilities, want)
+	}
+	if capabilities.ZOS() || capabilities.Supports("display", "usage") || !capabilities.Supports("DISPLAY", "QMSTATUS") {
+		t.Errorf("distributed capabilities misreport support: %+v", capabilities)
+	}
+
+	cached, err := session.Capabilities(context.Background())
+	if err != nil || cached != want || transport.callCount() != 2 {
+		t.Errorf("cached = %+v, %v after %d calls, want no new requests", cached, err, transport.callCount())
+	}
+}
+
+func TestCapabilities_DistributedRejectsZOSCommands(t *testing.T) {
+	session, transport, _ := newDistributedSession(t)
+
+	_, err := session.DisplayUsage(context.Background(), "*")
+	var unsupportedErr *UnsupportedError
+	if !errors.As(err, &unsupportedErr) || unsupportedErr.Command != "DISPLAY USAGE" {
+		t.Fatalf("error = %v, want UnsupportedError", err)
+	}
+	if err.Error() != "mqrestadmin unsupported: DISPLAY USAGE requires z/OS (queue manager platform UNIX)" {
+		t.Errorf("Error() = %q", err.Error())
+	}
+
+	_, err = session.DisplayQueue(context.Background(), "APP.*", WithCommandScope("*"))
+	if !errors.As(err, &unsupportedErr) || unsupportedErr.Attribute != "CMDSCOPE" {
+		t.Fatalf("error = %v, want UnsupportedError for CMDSCOPE", err)
+	}
+	if err.Error() != "mqrestadmin unsupported: CMDSCOPE on DISPLAY QUEUE requires z/OS (queue manager platform UNIX)" {
+		t.Errorf("Error() = %q", err.Error())
+	}
+	if transport.callCount() != 2 {
+		t.Errorf("sent %d requests, want unsupported commands rejected before sending", transport.callCount())
+	}
+
+	transport.addSuccessResponse()
+	transport.addSuccessResponse()
+	if _, err := session.DisplayQueue(context.Background(), "APP.*"); err != nil {
+		t.Errorf("DisplayQueue error = %v, want it supported", err)
+	}
+	if _, err := session.DisplayUsage(context.Background(), "*", WithTargetQmgr("MVS1")); err != nil {
+		t.Errorf("routed command error = %v, want it unchecked", err)
+	}
+}
+
+func TestCapabilities_ZOS(t *testing.T) {
+	transport := newMockTransport()
+	transport.addSuccessResponse(map[string]any{"platform": "MVS", "command_level": int64(930)})
+	session := newTestSession(transport)
+
+	capabilities, err := session.Capabilities(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !capabilities.ZOS() || capabilities.CommandLevel != 930 || capabilities.InstallationVersion != "" {
+		t.Errorf("capabilities = %+v", capabilities)
+	}
+	if !capabilities.Supports("DISPLAY", "CFSTRUCT") || capabilities.Supports("DEFINE", "LISTENER") {
+		t.Errorf("z/OS capabilities misreport support: %+v", capabilities)
+	}
+
+	_, err = session.DisplayQmstatus(context.Background())
+	var unsupportedErr *UnsupportedError
+	if !errors.As(err, &unsupportedErr) || unsupportedErr.Requires != "a distributed platform" {
+		t.Errorf("error = %v, want UnsupportedError", err)
+	}
+
+	transport.addSuccessResponse()
+	if _, err := session.DisplayQueue(context.Background(), "*", WithQsgDisposition(QsgDispositionShared)); err != nil {
+		t.Errorf("QSGDISP error = %v, want it supported on z/OS", err)
+	}
+}
+
+func TestCapabilities_CommandLevelAndChinit(t *testing.T) {
+	tests := []struct {
+		capabilities Capabilities
+		command      string
+		qualifier    string
+		want         bool
+	}{
+		{Capabilities{Platform: "UNIX", CommandLevel: 910}, "DISPLAY", "APSTATUS", false},
+		{Capabilities{Platform: "UNIX", CommandLevel: 912}, "DISPLAY", "APSTATUS", true},
+		{Capabilities{Platform: "UNIX", CommandLevel: 701}, "SET", "CHLAUTH", false},
+		{Capabilities{Platform: "UNIX"}, "DISPLAY", "APSTATUS", true},
+		{Capabilities{Platform: "UNIX", CommandLevel: 940}, "START", "CHINIT", true},
+		{Capabilities{Platform: "UNIX", CommandLevel: 940}, "DISPLAY", "CHINIT", false},
+		{Capabilities{Platform: "UNIX", CommandLevel: 940}, "STOP", "CHINIT", false},
+		{Capabilities{Platform: "MVS", CommandLevel: 930}, "DISPLAY", "CHINIT", true},
+	}
+	for _, tt := range tests {
+		if got := tt.capabilities.Supports(tt.command, tt.qualifier); got != tt.want {
+			t.Errorf("%+v Supports(%s, %s) = %v, want %v", tt.capabilities, tt.command, tt.qualifier, got, tt.want)
+		}
+	}
+
+	transport := newMockTransport()
+	transport.addSuccessResponse(map[string]any{"PLATFORM": "UNIX", "CMDLEVEL": float64(910)})
+	session := newTestSession(transport)
+	if _, err := session.Capabilities(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	_, err := session.DisplayApstatus(context.Background(), "APP")
+	var unsupportedErr *UnsupportedError
+	if !errors.As(err, &unsupportedErr) || unsupportedErr.Requires != "command level 912" {
+		t.Fatalf("error = %v, want UnsupportedError", err)
+	}
+	if err.Error() != "mqrestadmin unsupported: DISPLAY APSTATUS requires command level 912 (queue manager platform UNIX)" {
+		t.Errorf("Error() = %q", err.Error())
+	}
+	transport.addSuccessResponse()
+	if err := session.StartChinit(context.Background(), ""); err != nil {
+		t.Errorf("StartChinit() = %v, want it supported on a distributed queue manager", err)
+	}
+}
+
+func TestCapabilities_InstallationVersion(t *testing.T) {
+	session, transport := newMessagingTestSession()
+	transport.addSuccessResponse(map[string]any{"PLATFORM": "UNIX"})
+	transport.addMessageResponse(200, `{"installation":[{"name":"Installation1","version":"9.3.0.0"},`+
+		`{"name":"Installation2","version":"9.4.0.0"}]}`, nil)
+	transport.addMessageResponse(200, `{"qmgr":[{"name":"QM1","state":"running","extended":{"installationName":"Installation2"}}]}`, nil)
+
+	capabilities, err := session.Capabilities(context.Background())
+	if err != nil || capabilities.InstallationVersion != "9.4.0.0" {
+		t.Errorf("capabilities = %+v, %v, want the queue manager's installation", capabilities, err)
+	}
+
+	for _, responses := range [][]string{
+		{`{"installation":[]}`},
+		{`{"installation":[{"name":"A"},{"name":"B"}]}`, `{"qmgr":[{"name":"QM1","extended":{"installationName":"C"}}]}`},
+	} {
+		session, transport := newMessagingTestSession()
+		transport.addSuccessResponse(map[string]any{"PLATFORM": "UNIX"})
+		for _, response := range responses {
+			transport.addMessageResponse(200, response, nil)
+		}
+		capabilities, err := session.Capabilities(context.Background())
+		if err != nil || capabilities.InstallationVersion != "" {
+			t.Errorf("capabilities = %+v, %v, want no installation version", capabilities, err)
+		}
+	}
+}
+
+func TestCapabilities_Errors(t *testing.T) {
+	session, transport := newMessagingTestSession()
+	transport.addCommandErrorResponse(2, 2035)
+	if _, err := session.Capabilities(context.Background()); err == nil {
+		t.Error("expected DISPLAY QMGR error")
+	}
+
+	transport.addSuccessResponse(map[string]any{"PLATFORM": "UNIX"})
+	transport.addMessageResponse(500, "error", nil)
+	if _, err := session.Capabilities(context.Background()); err == nil {
+		t.Error("expected installation error")
+	}
+
+	transport.addSuccessResponse(map[string]any{"PLATFORM": "UNIX"})
+	transport.addMessageResponse(200, `{"installation":[{"name":"A"},{"name":"B"}]}`, nil)
+	transport.addMessageResponse(404, "", nil)
+	if _, err := session.Capabilities(context.Background()); err == nil {
+		t.Error("expected queue manager state error")
+	}
+	if session.capabilities != nil {
+		t.Error("failed detection was cached")
+	}
+}
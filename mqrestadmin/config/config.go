@@ -0,0 +1,409 @@
+// Package config loads queue manager connection profiles from a YAML, TOML,
+// or JSON file and builds sessions from them.
+//
+// A profile file names one or more profiles and, optionally, the default:
+//
+//	default: dev
+//	profiles:
+//	  dev:
+//	    rest_base_url: https://localhost:9443/ibmmq/rest/v2
+//	    qmgr_name: QM1
+//	    username: mqadmin
+//	    password_env: MQ_ADMIN_PASSWORD
+//	    verify_tls: false
+//
+// Every profile setting maps onto a mqrestadmin Option and can be overridden
+// with an MQREST_* environment variable, so deployments can keep one file
+// and vary a setting per environment:
+//
+//	session, err := config.NewSessionFromConfig("dev")
+package config
+
+import (
+	"bytes"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/pelletier/go-toml/v2"
+	"gopkg.in/yaml.v3"
+
+	"github.com/wphillipmoore/mq-rest-admin-go/mqrestadmin"
+)
+
+// PathEnvironmentVariable names the environment variable that overrides
+// the default profile file path.
+const PathEnvironmentVariable = "MQREST_CONFIG"
+
+// ProfileEnvironmentVariable names the environment variable that selects
+// the profile when no name is given.
+const ProfileEnvironmentVariable = "MQREST_PROFILE"
+
+// Profile holds the connection settings for one queue manager. Unset
+// optional fields leave the corresponding session default in place.
+type Profile struct {
+	// Name is the profile's key in the file. It is not read from the
+	// profile itself.
+	Name string `json:"-" yaml:"-" toml:"-"`
+
+	RESTBaseURL string `json:"rest_base_url" yaml:"rest_base_url" toml:"rest_base_url"`
+	QmgrName    string `json:"qmgr_name" yaml:"qmgr_name" toml:"qmgr_name"`
+	GatewayQmgr string `json:"gateway_qmgr" yaml:"gateway_qmgr" toml:"gateway_qmgr"`
+
+	// Auth is "basic" (the default), "ltpa", or "certificate".
+	Auth     string `json:"auth" yaml:"auth" toml:"auth"`
+	Username string `json:"username" yaml:"username" toml:"username"`
+	Password string `json:"password" yaml:"password" toml:"password"`
+	// PasswordEnv names an environment variable holding the password.
+	PasswordEnv string `json:"password_env" yaml:"password_env" toml:"password_env"`
+	// PasswordFile names a file holding the password. Surrounding
+	// whitespace is ignored.
+	PasswordFile string `json:"password_file" yaml:"password_file" toml:"password_file"`
+	CertFile     string `json:"cert_file" yaml:"cert_file" toml:"cert_file"`
+	KeyFile      string `json:"key_file" yaml:"key_file" toml:"key_file"`
+	// CAFile names a PEM file of certificate authorities that verify the
+	// REST API server's certificate, in place of the system roots.
+	CAFile string `json:"ca_file" yaml:"ca_file" toml:"ca_file"`
+
+	VerifyTLS *bool `json:"verify_tls" yaml:"verify_tls" toml:"verify_tls"`
+	// Timeout is a duration such as "30s".
+	Timeout   string  `json:"timeout" yaml:"timeout" toml:"timeout"`
+	CSRFToken *string `json:"csrf_token" yaml:"csrf_token" toml:"csrf_token"`
+
+	MapAttributes *bool `json:"map_attributes" yaml:"map_attributes" toml:"map_attributes"`
+	MappingStrict *bool `json:"mapping_strict" yaml:"mapping_strict" toml:"mapping_strict"`
+	// MappingOverridesFile names a JSON file of mapping overrides, applied
+	// in MappingOverridesMode: "merge" (the default) or "replace".
+	MappingOverridesFile string `json:"mapping_overrides_file" yaml:"mapping_overrides_file" toml:"mapping_overrides_file"`
+	MappingOverridesMode string `json:"mapping_overrides_mode" yaml:"mapping_overrides_mode" toml:"mapping_overrides_mode"`
+	ConvertValues        *bool  `json:"convert_values" yaml:"convert_values" toml:"convert_values"`
+	// NumberMode is "float64" (the default), "json", or "int64".
+	NumberMode string `json:"number_mode" yaml:"number_mode" toml:"number_mode"`
+	// TimeZone is an IANA time zone name such as "Europe/London".
+	TimeZone string `json:"time_zone" yaml:"time_zone" toml:"time_zone"`
+}
+
+// File is the contents of a profile file.
+type File struct {
+	// Default is the profile used when no name is given.
+	Default  string             `json:"default" yaml:"default" toml:"default"`
+	Profiles map[string]Profile `json:"profiles" yaml:"profiles" toml:"profiles"`
+}
+
+// DefaultPath returns the profile file path: the MQREST_CONFIG environment
+// variable, or mqrestadmin/profiles.yaml under the user configuration
+// directory.
+func DefaultPath() (string, error) {
+	if path := os.Getenv(PathEnvironmentVariable); path != "" {
+		return path, nil
+	}
+	directory, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("locate profile file: %w", err)
+	}
+	return filepath.Join(directory, "mqrestadmin", "profiles.yaml"), nil
+}
+
+// Load reads a profile file. The format follows the file extension: .yaml
+// or .yml, .toml, or .json. Unknown settings are errors, so that a
+// misspelled key is not silently ignored.
+func Load(path string) (*File, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read profile file: %w", err)
+	}
+
+	var file File
+	switch extension := strings.ToLower(filepath.Ext(path)); extension {
+	case ".yaml", ".yml":
+		decoder := yaml.NewDecoder(bytes.NewReader(data))
+		decoder.KnownFields(true)
+		err = decoder.Decode(&file)
+		if errors.Is(err, io.EOF) {
+			err = nil
+		}
+	case ".toml":
+		decoder := toml.NewDecoder(bytes.NewReader(data))
+		decoder.DisallowUnknownFields()
+		err = decoder.Decode(&file)
+	case ".json":
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.DisallowUnknownFields()
+		err = decoder.Decode(&file)
+	default:
+		return nil, fmt.Errorf("profile file %s: unsupported format %q (want .yaml, .yml, .toml, or .json)", path, extension)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("parse profile file %s: %w", path, err)
+	}
+	return &file, nil
+}
+
+// Profile returns the named profile. An empty name selects the file's
+// default, or its only profile when it has exactly one.
+func (file *File) Profile(name string) (Profile, error) {
+	if name == "" {
+		name = file.Default
+	}
+	if name == "" && len(file.Profiles) == 1 {
+		for only := range file.Profiles {
+			name = only
+		}
+	}
+	if name == "" {
+		return Profile{}, errors.New("profile file has no default profile; name one")
+	}
+	selected, exists := file.Profiles[name]
+	if !exists {
+		return Profile{}, fmt.Errorf("profile %q not found", name)
+	}
+	selected.Name = name
+	return selected, nil
+}
+
+// LoadProfile returns the named profile from the default profile file,
+// with MQREST_* environment overrides applied. An empty name selects the
+// MQREST_PROFILE environment variable, then the file's default. When the
+// default file does not exist and no name is given, the profile comes from
+// the environment alone.
+func LoadProfile(name string) (Profile, error) {
+	path, err := DefaultPath()
+	if err != nil {
+		return Profile{}, err
+	}
+	if name == "" {
+		name = os.Getenv(ProfileEnvironmentVariable)
+	}
+	if _, err := os.Stat(path); name == "" && errors.Is(err, fs.ErrNotExist) {
+		var selected Profile
+		if err := selected.ApplyEnvironment(); err != nil {
+			return Profile{}, err
+		}
+		return selected, nil
+	}
+	return LoadProfileFile(path, name)
+}
+
+// LoadProfileFile returns the named profile from the given profile file,
+// with MQREST_* environment overrides applied. An empty name selects the
+// MQREST_PROFILE environment variable, then the file's default.
+func LoadProfileFile(path, name string) (Profile, error) {
+	file, err := Load(path)
+	if err != nil {
+		return Profile{}, err
+	}
+	if name == "" {
+		name = os.Getenv(ProfileEnvironmentVariable)
+	}
+	selected, err := file.Profile(name)
+	if err != nil {
+		return Profile{}, fmt.Errorf("%s: %w", path, err)
+	}
+	if err := selected.ApplyEnvironment(); err != nil {
+		return Profile{}, err
+	}
+	return selected, nil
+}
+
+// NewSessionFromConfig creates a session from the named profile, loaded as
+// by LoadProfile. Options are applied after the profile's own settings.
+func NewSessionFromConfig(name string, opts ...mqrestadmin.Option) (*mqrestadmin.Session, error) {
+	selected, err := LoadProfile(name)
+	if err != nil {
+		return nil, err
+	}
+	return selected.NewSession(opts...)
+}
+
+// NewSession creates a session from the profile. Options are applied after
+// the profile's own settings, so they take precedence.
+func (profile Profile) NewSession(opts ...mqrestadmin.Option) (*mqrestadmin.Session, error) {
+	if profile.RESTBaseURL == "" || profile.QmgrName == "" {
+		return nil, fmt.Errorf("profile %q must set rest_base_url and qmgr_name", profile.Name)
+	}
+	credentials, err := profile.Credentials()
+	if err != nil {
+		return nil, err
+	}
+	options, err := profile.Options()
+	if err != nil {
+		return nil, err
+	}
+	return mqrestadmin.NewSession(profile.RESTBaseURL, profile.QmgrName, credentials, append(options, opts...)...)
+}
+
+// Credentials returns the session credentials for the profile's auth type.
+// The password comes from PasswordEnv, then PasswordFile, then Password.
+func (profile Profile) Credentials() (mqrestadmin.Credentials, error) {
+	switch profile.Auth {
+	case "", "basic", "ltpa":
+		password, err := profile.password()
+		if err != nil {
+			return nil, err
+		}
+		if profile.Auth == "ltpa" {
+			return mqrestadmin.LTPAAuth{Username: profile.Username, Password: password}, nil
+		}
+		return mqrestadmin.BasicAuth{Username: profile.Username, Password: password}, nil
+	case "certificate":
+		if profile.CertFile == "" {
+			return nil, errors.New("certificate auth requires cert_file")
+		}
+		return mqrestadmin.CertificateAuth{CertPath: profile.CertFile, KeyPath: profile.KeyFile}, nil
+	default:
+		return nil, fmt.Errorf("unknown auth type %q (want basic, ltpa, or certificate)", profile.Auth)
+	}
+}
+
+func (profile Profile) password() (string, error) {
+	if profile.PasswordEnv != "" {
+		password, found := os.LookupEnv(profile.PasswordEnv)
+		if !found {
+			return "", fmt.Errorf("password environment variable %s is not set", profile.PasswordEnv)
+		}
+		return password, nil
+	}
+	if profile.PasswordFile != "" {
+		data, err := os.ReadFile(profile.PasswordFile)
+		if err != nil {
+			return "", fmt.Errorf("read password file: %w", err)
+		}
+		return strings.TrimSpace(string(data)), nil
+	}
+	return profile.Password, nil
+}
+
+// Options converts the profile's optional settings to session options.
+func (profile Profile) Options() ([]mqrestadmin.Option, error) {
+	options, err := profile.connectionOptions()
+	if err != nil {
+		return nil, err
+	}
+	responseOptions, err := profile.responseOptions()
+	if err != nil {
+		return nil, err
+	}
+	return append(options, responseOptions...), nil
+}
+
+// connectionOptions converts the settings that control how requests are
+// sent.
+func (profile Profile) connectionOptions() ([]mqrestadmin.Option, error) {
+	var options []mqrestadmin.Option
+	if profile.GatewayQmgr != "" {
+		options = append(options, mqrestadmin.WithGatewayQmgr(profile.GatewayQmgr))
+	}
+	if profile.VerifyTLS != nil {
+		options = append(options, mqrestadmin.WithVerifyTLS(*profile.VerifyTLS))
+	}
+	if profile.CAFile != "" {
+		pool, err := profile.rootCAs()
+		if err != nil {
+			return nil, err
+		}
+		options = append(options, mqrestadmin.WithRootCAs(pool))
+	}
+	if profile.Timeout != "" {
+		timeout, err := time.ParseDuration(profile.Timeout)
+		if err != nil {
+			return nil, fmt.Errorf("invalid timeout %q: %w", profile.Timeout, err)
+		}
+		options = append(options, mqrestadmin.WithTimeout(timeout))
+	}
+	if profile.CSRFToken != nil {
+		token := *profile.CSRFToken
+		options = append(options, mqrestadmin.WithCSRFToken(&token))
+	}
+	return options, nil
+}
+
+// responseOptions converts the settings that control how responses are
+// mapped and converted.
+func (profile Profile) responseOptions() ([]mqrestadmin.Option, error) {
+	var options []mqrestadmin.Option
+	if profile.MapAttributes != nil {
+		options = append(options, mqrestadmin.WithMapAttributes(*profile.MapAttributes))
+	}
+	if profile.MappingStrict != nil {
+		options = append(options, mqrestadmin.WithMappingStrict(*profile.MappingStrict))
+	}
+	if profile.MappingOverridesFile != "" {
+		option, err := profile.mappingOverrides()
+		if err != nil {
+			return nil, err
+		}
+		options = append(options, option)
+	}
+	if profile.ConvertValues != nil {
+		options = append(options, mqrestadmin.WithConvertValues(*profile.ConvertValues))
+	}
+	if profile.NumberMode != "" {
+		mode, err := parseNumberMode(profile.NumberMode)
+		if err != nil {
+			return nil, err
+		}
+		options = append(options, mqrestadmin.WithNumberMode(mode))
+	}
+	if profile.TimeZone != "" {
+		location, err := time.LoadLocation(profile.TimeZone)
+		if err != nil {
+			return nil, fmt.Errorf("invalid time_zone %q: %w", profile.TimeZone, err)
+		}
+		options = append(options, mqrestadmin.WithTimeLocation(location))
+	}
+	return options, nil
+}
+
+func (profile Profile) rootCAs() (*x509.CertPool, error) {
+	data, err := os.ReadFile(profile.CAFile)
+	if err != nil {
+		return nil, fmt.Errorf("read CA file: %w", err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(data) {
+		return nil, fmt.Errorf("CA file %s holds no PEM certificates", profile.CAFile)
+	}
+	return pool, nil
+}
+
+func (profile Profile) mappingOverrides() (mqrestadmin.Option, error) {
+	var mode mqrestadmin.MappingOverrideMode
+	switch profile.MappingOverridesMode {
+	case "", "merge":
+		mode = mqrestadmin.MappingOverrideMerge
+	case "replace":
+		mode = mqrestadmin.MappingOverrideReplace
+	default:
+		return nil, fmt.Errorf("unknown mapping_overrides_mode %q (want merge or replace)", profile.MappingOverridesMode)
+	}
+
+	data, err := os.ReadFile(profile.MappingOverridesFile)
+	if err != nil {
+		return nil, fmt.Errorf("read mapping overrides: %w", err)
+	}
+	var overrides map[string]any
+	if err := json.Unmarshal(data, &overrides); err != nil {
+		return nil, fmt.Errorf("parse mapping overrides %s: %w", profile.MappingOverridesFile, err)
+	}
+	return mqrestadmin.WithMappingOverrides(overrides, mode), nil
+}
+
+func parseNumberMode(name string) (mqrestadmin.NumberMode, error) {
+	switch name {
+	case "float64":
+		return mqrestadmin.NumberFloat64, nil
+	case "json", "json.Number":
+		return mqrestadmin.NumberJSON, nil
+	case "int64":
+		return mqrestadmin.NumberInt64, nil
+	default:
+		return 0, fmt.Errorf("unknown number_mode %q (want float64, json, or int64)", name)
+	}
+}
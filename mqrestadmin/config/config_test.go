@@ -0,0 +1,373 @@
+package config
+
+import (
+	"context"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/wphillipmoore/mq-rest-admin-go/mqrestadmin"
+)
+
+const yamlProfiles = `default: dev
+profiles:
+  dev:
+    rest_base_url: https://dev:9443/ibmmq/rest/v2
+    qmgr_name: QM1
+    username: mqadmin
+    password: secret
+    verify_tls: false
+  prod:
+    rest_base_url: https://prod:9443/ibmmq/rest/v2
+    qmgr_name: QM2
+    auth: certificate
+    cert_file: /etc/mq/client.pem
+`
+
+const tomlProfiles = `default = "dev"
+
+[profiles.dev]
+rest_base_url = "https://dev:9443/ibmmq/rest/v2"
+qmgr_name = "QM1"
+username = "mqadmin"
+password = "secret"
+verify_tls = false
+`
+
+const jsonProfiles = `{
+  "default": "dev",
+  "profiles": {
+    "dev": {
+      "rest_base_url": "https://dev:9443/ibmmq/rest/v2",
+      "qmgr_name": "QM1",
+      "username": "mqadmin",
+      "password": "secret",
+      "verify_tls": false
+    }
+  }
+}`
+
+func writeFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func boolPointer(value bool) *bool { return &value }
+
+func TestLoad_Formats(t *testing.T) {
+	want := Profile{
+		Name:        "dev",
+		RESTBaseURL: "https://dev:9443/ibmmq/rest/v2",
+		QmgrName:    "QM1",
+		Username:    "mqadmin",
+		Password:    "secret",
+		VerifyTLS:   boolPointer(false),
+	}
+	for _, path := range []string{
+		writeFile(t, "profiles.yaml", yamlProfiles),
+		writeFile(t, "profiles.YML", yamlProfiles),
+		writeFile(t, "profiles.toml", tomlProfiles),
+		writeFile(t, "profiles.json", jsonProfiles),
+	} {
+		t.Run(filepath.Base(path), func(t *testing.T) {
+			file, err := Load(path)
+			if err != nil {
+				t.Fatalf("Load() error: %v", err)
+			}
+			got, err := file.Profile("")
+			if err != nil || !reflect.DeepEqual(got, want) {
+				t.Errorf("Profile() = %+v, %v; want %+v", got, err, want)
+			}
+		})
+	}
+}
+
+func TestLoad_EmptyYAML(t *testing.T) {
+	file, err := Load(writeFile(t, "profiles.yaml", ""))
+	if err != nil || file.Default != "" || len(file.Profiles) != 0 {
+		t.Errorf("Load(empty) = %+v, %v", file, err)
+	}
+}
+
+func TestLoad_Errors(t *testing.T) {
+	tests := []struct {
+		name     string
+		path     string
+		wantText string
+	}{
+		{"missing", filepath.Join(t.TempDir(), "absent.yaml"), "read profile file"},
+		{"extension", writeFile(t, "profiles.ini", ""), `unsupported format ".ini"`},
+		{"yaml unknown key", writeFile(t, "profiles.yaml", "profiles:\n  dev:\n    qmgr: QM1\n"), "field qmgr not found"},
+		{"toml unknown key", writeFile(t, "profiles.toml", "[profiles.dev]\nqmgr = \"QM1\"\n"), "parse profile file"},
+		{"json unknown key", writeFile(t, "profiles.json", `{"profiles": {"dev": {"qmgr": "QM1"}}}`), `unknown field "qmgr"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Load(tt.path); err == nil || !strings.Contains(err.Error(), tt.wantText) {
+				t.Errorf("Load() error = %v, want it to contain %q", err, tt.wantText)
+			}
+		})
+	}
+}
+
+func TestFileProfile_Selection(t *testing.T) {
+	file := &File{Profiles: map[string]Profile{"only": {QmgrName: "QM3"}}}
+	if got, err := file.Profile(""); err != nil || got.Name != "only" || got.QmgrName != "QM3" {
+		t.Errorf("single profile = %+v, %v", got, err)
+	}
+
+	file.Profiles["other"] = Profile{}
+	if _, err := file.Profile(""); err == nil || !strings.Contains(err.Error(), "no default profile") {
+		t.Errorf("no default error = %v", err)
+	}
+	if _, err := file.Profile("test"); err == nil || !strings.Contains(err.Error(), `profile "test" not found`) {
+		t.Errorf("unknown profile error = %v", err)
+	}
+}
+
+func TestDefaultPath(t *testing.T) {
+	t.Setenv(PathEnvironmentVariable, "/etc/mqrest/profiles.toml")
+	if path, err := DefaultPath(); err != nil || path != "/etc/mqrest/profiles.toml" {
+		t.Errorf("env path = %q, %v", path, err)
+	}
+
+	t.Setenv(PathEnvironmentVariable, "")
+	t.Setenv("XDG_CONFIG_HOME", "/home/test/.config")
+	if path, err := DefaultPath(); err != nil || path != "/home/test/.config/mqrestadmin/profiles.yaml" {
+		t.Errorf("user config path = %q, %v", path, err)
+	}
+
+	t.Setenv("XDG_CONFIG_HOME", "")
+	t.Setenv("HOME", "")
+	if _, err := DefaultPath(); err == nil || !strings.Contains(err.Error(), "locate profile file") {
+		t.Errorf("missing home error = %v", err)
+	}
+	if _, err := LoadProfile(""); err == nil || !strings.Contains(err.Error(), "locate profile file") {
+		t.Errorf("LoadProfile missing home error = %v", err)
+	}
+}
+
+func TestLoadProfile(t *testing.T) {
+	t.Setenv(PathEnvironmentVariable, writeFile(t, "profiles.yaml", yamlProfiles))
+	t.Setenv(ProfileEnvironmentVariable, "")
+
+	selected, err := LoadProfile("")
+	if err != nil || selected.Name != "dev" {
+		t.Errorf("default profile = %+v, %v", selected, err)
+	}
+
+	t.Setenv(ProfileEnvironmentVariable, "prod")
+	t.Setenv("MQREST_QMGR_NAME", "QM9")
+	selected, err = LoadProfile("")
+	if err != nil || selected.Name != "prod" || selected.QmgrName != "QM9" {
+		t.Errorf("MQREST_PROFILE profile = %+v, %v", selected, err)
+	}
+
+	t.Setenv("MQREST_VERIFY_TLS", "maybe")
+	if _, err := LoadProfile("dev"); err == nil || !strings.Contains(err.Error(), "MQREST_VERIFY_TLS") {
+		t.Errorf("bad override error = %v", err)
+	}
+}
+
+func TestLoadProfile_EnvironmentOnly(t *testing.T) {
+	t.Setenv(PathEnvironmentVariable, filepath.Join(t.TempDir(), "absent.yaml"))
+	t.Setenv(ProfileEnvironmentVariable, "")
+	t.Setenv("MQREST_REST_BASE_URL", "https://env:9443/ibmmq/rest/v2")
+	t.Setenv("MQREST_QMGR_NAME", "QM1")
+
+	selected, err := LoadProfile("")
+	if err != nil || selected.RESTBaseURL != "https://env:9443/ibmmq/rest/v2" || selected.QmgrName != "QM1" {
+		t.Errorf("environment profile = %+v, %v", selected, err)
+	}
+
+	t.Setenv("MQREST_CONVERT_VALUES", "nope")
+	if _, err := LoadProfile(""); err == nil {
+		t.Error("expected invalid override error")
+	}
+	if _, err := LoadProfile("dev"); err == nil || !strings.Contains(err.Error(), "read profile file") {
+		t.Errorf("named profile without file error = %v", err)
+	}
+}
+
+func TestLoadProfileFile_Errors(t *testing.T) {
+	t.Setenv(ProfileEnvironmentVariable, "")
+	path := writeFile(t, "profiles.yaml", yamlProfiles)
+	if _, err := LoadProfileFile(path, "test"); err == nil || !strings.Contains(err.Error(), path+`: profile "test" not found`) {
+		t.Errorf("unknown profile error = %v", err)
+	}
+	if _, err := LoadProfileFile(filepath.Join(t.TempDir(), "absent.json"), "dev"); err == nil {
+		t.Error("expected missing file error")
+	}
+}
+
+func TestNewSessionFromConfig(t *testing.T) {
+	t.Setenv(PathEnvironmentVariable, writeFile(t, "profiles.yaml", yamlProfiles))
+	t.Setenv(ProfileEnvironmentVariable, "")
+
+	session, err := NewSessionFromConfig("dev", mqrestadmin.WithMapAttributes(false))
+	if err != nil || session.QmgrName() != "QM1" {
+		t.Fatalf("NewSessionFromConfig() = %v, %v", session, err)
+	}
+	if _, err := NewSessionFromConfig("test"); err == nil {
+		t.Error("expected unknown profile error")
+	}
+}
+
+func TestProfileNewSession_Errors(t *testing.T) {
+	tests := []struct {
+		name     string
+		profile  Profile
+		wantText string
+	}{
+		{"missing url", Profile{Name: "dev", QmgrName: "QM1"}, `profile "dev" must set rest_base_url and qmgr_name`},
+		{"bad auth", Profile{RESTBaseURL: "https://dev", QmgrName: "QM1", Auth: "kerberos"}, "unknown auth type"},
+		{"bad option", Profile{RESTBaseURL: "https://dev", QmgrName: "QM1", Timeout: "soon"}, `invalid timeout "soon"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := tt.profile.NewSession(); err == nil || !strings.Contains(err.Error(), tt.wantText) {
+				t.Errorf("NewSession() error = %v, want it to contain %q", err, tt.wantText)
+			}
+		})
+	}
+}
+
+func TestProfileCredentials(t *testing.T) {
+	t.Setenv("TEST_MQ_PASSWORD", "from-env")
+	passwordFile := writeFile(t, "password", "from-file\n")
+	tests := []struct {
+		name    string
+		profile Profile
+		want    mqrestadmin.Credentials
+	}{
+		{"basic", Profile{Username: "admin", Password: "secret"},
+			mqrestadmin.BasicAuth{Username: "admin", Password: "secret"}},
+		{"password env", Profile{Auth: "basic", Username: "admin", Password: "ignored", PasswordEnv: "TEST_MQ_PASSWORD"},
+			mqrestadmin.BasicAuth{Username: "admin", Password: "from-env"}},
+		{"password file", Profile{Auth: "ltpa", Username: "admin", PasswordFile: passwordFile},
+			mqrestadmin.LTPAAuth{Username: "admin", Password: "from-file"}},
+		{"certificate", Profile{Auth: "certificate", CertFile: "client.pem", KeyFile: "client.key"},
+			mqrestadmin.CertificateAuth{CertPath: "client.pem", KeyPath: "client.key"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.profile.Credentials()
+			if err != nil || !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Credentials() = %#v, %v; want %#v", got, err, tt.want)
+			}
+		})
+	}
+
+	failures := []struct {
+		name     string
+		profile  Profile
+		wantText string
+	}{
+		{"unset password env", Profile{PasswordEnv: "TEST_MQ_UNSET_PASSWORD"}, "TEST_MQ_UNSET_PASSWORD is not set"},
+		{"missing password file", Profile{Auth: "ltpa", PasswordFile: filepath.Join(t.TempDir(), "absent")}, "read password file"},
+		{"missing cert file", Profile{Auth: "certificate"}, "requires cert_file"},
+		{"unknown auth", Profile{Auth: "kerberos"}, `unknown auth type "kerberos"`},
+	}
+	for _, tt := range failures {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := tt.profile.Credentials(); err == nil || !strings.Contains(err.Error(), tt.wantText) {
+				t.Errorf("Credentials() error = %v, want it to contain %q", err, tt.wantText)
+			}
+		})
+	}
+}
+
+func TestProfileOptions(t *testing.T) {
+	token := "custom"
+	overrides := writeFile(t, "overrides.json", `{"qualifiers": {}}`)
+	caFile := writeFile(t, "ca.pem", string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: testCertificate(t)})))
+	profile := Profile{
+		GatewayQmgr:          "GW1",
+		VerifyTLS:            boolPointer(false),
+		CAFile:               caFile,
+		Timeout:              "5s",
+		CSRFToken:            &token,
+		MapAttributes:        boolPointer(true),
+		MappingStrict:        boolPointer(false),
+		MappingOverridesFile: overrides,
+		ConvertValues:        boolPointer(true),
+		NumberMode:           "int64",
+		TimeZone:             "UTC",
+	}
+	options, err := profile.Options()
+	if err != nil || len(options) != 11 {
+		t.Fatalf("Options() = %d options, %v; want 11", len(options), err)
+	}
+	if options, err := (Profile{}).Options(); err != nil || len(options) != 0 {
+		t.Errorf("empty Options() = %d options, %v", len(options), err)
+	}
+
+	for _, mode := range []string{"merge", "replace"} {
+		profile := Profile{MappingOverridesFile: overrides, MappingOverridesMode: mode}
+		if _, err := profile.Options(); err != nil {
+			t.Errorf("mapping_overrides_mode %s: %v", mode, err)
+		}
+	}
+	for _, mode := range []string{"float64", "json", "json.Number"} {
+		if _, err := (Profile{NumberMode: mode}).Options(); err != nil {
+			t.Errorf("number_mode %s: %v", mode, err)
+		}
+	}
+
+	failures := []struct {
+		name     string
+		profile  Profile
+		wantText string
+	}{
+		{"timeout", Profile{Timeout: "soon"}, `invalid timeout "soon"`},
+		{"CA file", Profile{CAFile: filepath.Join(t.TempDir(), "absent.pem")}, "read CA file"},
+		{"CA PEM", Profile{CAFile: writeFile(t, "bad.pem", "not a certificate")}, "holds no PEM certificates"},
+		{"overrides mode", Profile{MappingOverridesFile: overrides, MappingOverridesMode: "mix"}, `unknown mapping_overrides_mode "mix"`},
+		{"overrides file", Profile{MappingOverridesFile: filepath.Join(t.TempDir(), "absent.json")}, "read mapping overrides"},
+		{"overrides json", Profile{MappingOverridesFile: writeFile(t, "bad.json", "{")}, "parse mapping overrides"},
+		{"number mode", Profile{NumberMode: "decimal"}, `unknown number_mode "decimal"`},
+		{"time zone", Profile{TimeZone: "Nowhere/Special"}, `invalid time_zone "Nowhere/Special"`},
+	}
+	for _, tt := range failures {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := tt.profile.Options(); err == nil || !strings.Contains(err.Error(), tt.wantText) {
+				t.Errorf("Options() error = %v, want it to contain %q", err, tt.wantText)
+			}
+		})
+	}
+}
+
+// testCertificate returns the DER certificate of a TLS server that is
+// closed when the test ends.
+func testCertificate(t *testing.T) []byte {
+	t.Helper()
+	server := httptest.NewTLSServer(http.NotFoundHandler())
+	t.Cleanup(server.Close)
+	return server.Certificate().Raw
+}
+
+func TestProfileNewSession_CAFile(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"commandResponse":[],"overallCompletionCode":0,"overallReasonCode":0}`))
+	}))
+	defer server.Close()
+	caFile := writeFile(t, "ca.pem", string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})))
+
+	profile := Profile{RESTBaseURL: server.URL, QmgrName: "QM1", Username: "admin", Password: "secret", CAFile: caFile}
+	session, err := profile.NewSession()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := session.DisplayQmgr(context.Background()); err != nil {
+		t.Errorf("DisplayQmgr() with ca_file = %v", err)
+	}
+}
@@ -0,0 +1,76 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+)
+
+// EnvironmentPrefix starts the name of every environment variable that
+// overrides a profile setting. The rest of the name is the setting's key
+// in upper case, for example MQREST_QMGR_NAME or MQREST_VERIFY_TLS.
+const EnvironmentPrefix = "MQREST_"
+
+// passwordSources lists the environment keys of the password settings.
+var passwordSources = []string{"PASSWORD", "PASSWORD_ENV", "PASSWORD_FILE"}
+
+// ApplyEnvironment overrides the profile's settings with any MQREST_*
+// environment variables that are set. Boolean variables accept the values
+// understood by strconv.ParseBool. Setting any of MQREST_PASSWORD,
+// MQREST_PASSWORD_ENV, or MQREST_PASSWORD_FILE replaces all three of the
+// profile's password settings, so an override is not hidden by a password
+// source that takes precedence in the file.
+func (profile *Profile) ApplyEnvironment() error {
+	for _, key := range passwordSources {
+		if _, found := os.LookupEnv(EnvironmentPrefix + key); found {
+			profile.Password, profile.PasswordEnv, profile.PasswordFile = "", "", ""
+			break
+		}
+	}
+
+	texts := map[string]*string{
+		"REST_BASE_URL":          &profile.RESTBaseURL,
+		"QMGR_NAME":              &profile.QmgrName,
+		"GATEWAY_QMGR":           &profile.GatewayQmgr,
+		"AUTH":                   &profile.Auth,
+		"USERNAME":               &profile.Username,
+		"PASSWORD":               &profile.Password,
+		"PASSWORD_ENV":           &profile.PasswordEnv,
+		"PASSWORD_FILE":          &profile.PasswordFile,
+		"CERT_FILE":              &profile.CertFile,
+		"KEY_FILE":               &profile.KeyFile,
+		"CA_FILE":                &profile.CAFile,
+		"TIMEOUT":                &profile.Timeout,
+		"MAPPING_OVERRIDES_FILE": &profile.MappingOverridesFile,
+		"MAPPING_OVERRIDES_MODE": &profile.MappingOverridesMode,
+		"NUMBER_MODE":            &profile.NumberMode,
+		"TIME_ZONE":              &profile.TimeZone,
+	}
+	for key, field := range texts {
+		if value, found := os.LookupEnv(EnvironmentPrefix + key); found {
+			*field = value
+		}
+	}
+	if value, found := os.LookupEnv(EnvironmentPrefix + "CSRF_TOKEN"); found {
+		profile.CSRFToken = &value
+	}
+
+	booleans := map[string]**bool{
+		"VERIFY_TLS":     &profile.VerifyTLS,
+		"MAP_ATTRIBUTES": &profile.MapAttributes,
+		"MAPPING_STRICT": &profile.MappingStrict,
+		"CONVERT_VALUES": &profile.ConvertValues,
+	}
+	for key, field := range booleans {
+		value, found := os.LookupEnv(EnvironmentPrefix + key)
+		if !found {
+			continue
+		}
+		enabled, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("invalid %s%s %q: want true or false", EnvironmentPrefix, key, value)
+		}
+		*field = &enabled
+	}
+	return nil
+}
@@ -0,0 +1,113 @@
+package config
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/wphillipmoore/mq-rest-admin-go/mqrestadmin"
+)
+
+func TestApplyEnvironment(t *testing.T) {
+	environment := map[string]string{
+		"MQREST_REST_BASE_URL":          "https://env:9443/ibmmq/rest/v2",
+		"MQREST_QMGR_NAME":              "QM1",
+		"MQREST_GATEWAY_QMGR":           "GW1",
+		"MQREST_AUTH":                   "ltpa",
+		"MQREST_USERNAME":               "mqadmin",
+		"MQREST_PASSWORD":               "secret",
+		"MQREST_PASSWORD_ENV":           "MQ_PASSWORD",
+		"MQREST_PASSWORD_FILE":          "/run/secrets/mq",
+		"MQREST_CERT_FILE":              "client.pem",
+		"MQREST_KEY_FILE":               "client.key",
+		"MQREST_CA_FILE":                "ca.pem",
+		"MQREST_TIMEOUT":                "45s",
+		"MQREST_CSRF_TOKEN":             "token",
+		"MQREST_MAPPING_OVERRIDES_FILE": "overrides.json",
+		"MQREST_MAPPING_OVERRIDES_MODE": "replace",
+		"MQREST_NUMBER_MODE":            "json",
+		"MQREST_TIME_ZONE":              "UTC",
+		"MQREST_VERIFY_TLS":             "false",
+		"MQREST_MAP_ATTRIBUTES":         "0",
+		"MQREST_MAPPING_STRICT":         "true",
+		"MQREST_CONVERT_VALUES":         "TRUE",
+	}
+	for key, value := range environment {
+		t.Setenv(key, value)
+	}
+
+	profile := Profile{Name: "dev", QmgrName: "FILE", Timeout: "10s"}
+	if err := profile.ApplyEnvironment(); err != nil {
+		t.Fatalf("ApplyEnvironment() error: %v", err)
+	}
+	token := "token"
+	want := Profile{
+		Name:                 "dev",
+		RESTBaseURL:          "https://env:9443/ibmmq/rest/v2",
+		QmgrName:             "QM1",
+		GatewayQmgr:          "GW1",
+		Auth:                 "ltpa",
+		Username:             "mqadmin",
+		Password:             "secret",
+		PasswordEnv:          "MQ_PASSWORD",
+		PasswordFile:         "/run/secrets/mq",
+		CertFile:             "client.pem",
+		KeyFile:              "client.key",
+		CAFile:               "ca.pem",
+		VerifyTLS:            boolPointer(false),
+		Timeout:              "45s",
+		CSRFToken:            &token,
+		MapAttributes:        boolPointer(false),
+		MappingStrict:        boolPointer(true),
+		MappingOverridesFile: "overrides.json",
+		MappingOverridesMode: "replace",
+		ConvertValues:        boolPointer(true),
+		NumberMode:           "json",
+		TimeZone:             "UTC",
+	}
+	if !reflect.DeepEqual(profile, want) {
+		t.Errorf("profile = %+v, want %+v", profile, want)
+	}
+}
+
+func TestApplyEnvironment_KeepsUnsetFields(t *testing.T) {
+	profile := Profile{QmgrName: "QM1", VerifyTLS: boolPointer(false)}
+	if err := profile.ApplyEnvironment(); err != nil {
+		t.Fatalf("ApplyEnvironment() error: %v", err)
+	}
+	if profile.QmgrName != "QM1" || *profile.VerifyTLS || profile.CSRFToken != nil {
+		t.Errorf("profile = %+v, want it unchanged", profile)
+	}
+}
+
+func TestApplyEnvironment_InvalidBoolean(t *testing.T) {
+	t.Setenv("MQREST_MAPPING_STRICT", "sometimes")
+	var profile Profile
+	err := profile.ApplyEnvironment()
+	if err == nil || !strings.Contains(err.Error(), `invalid MQREST_MAPPING_STRICT "sometimes"`) {
+		t.Errorf("ApplyEnvironment() error = %v", err)
+	}
+}
+
+func TestApplyEnvironment_PasswordOverridesEverySource(t *testing.T) {
+	t.Setenv("TEST_MQ_PASSWORD", "from-file-env")
+	t.Setenv("MQREST_PASSWORD", "from-override")
+	profile := Profile{Username: "admin", Password: "file", PasswordEnv: "TEST_MQ_PASSWORD", PasswordFile: "/run/secrets/mq"}
+	if err := profile.ApplyEnvironment(); err != nil {
+		t.Fatalf("ApplyEnvironment() error: %v", err)
+	}
+	credentials, err := profile.Credentials()
+	want := mqrestadmin.BasicAuth{Username: "admin", Password: "from-override"}
+	if err != nil || credentials != want {
+		t.Errorf("Credentials() = %#v, %v; want %#v", credentials, err, want)
+	}
+
+	t.Setenv("MQREST_PASSWORD_ENV", "TEST_MQ_PASSWORD")
+	profile = Profile{Password: "file", PasswordFile: "/run/secrets/mq"}
+	if err := profile.ApplyEnvironment(); err != nil {
+		t.Fatalf("ApplyEnvironment() error: %v", err)
+	}
+	if password, err := profile.password(); err != nil || password != "from-file-env" {
+		t.Errorf("password() = %q, %v; want the MQREST_PASSWORD_ENV variable", password, err)
+	}
+}
@@ -0,0 +1,218 @@
+package mqrestadmin
+
+import (
+	"strings"
+	"time"
+)
+
+// Response value types declared in the mapping data's response_type_map.
+const (
+	valueTypeInteger     = "integer"
+	valueTypeIntegerList = "integer_list"
+	valueTypeList        = "list"
+	valueTypeDate        = "date"
+	valueTypeTime        = "time"
+)
+
+const (
+	mqscDateLayout = "2006-01-02"
+	dateSuffix     = "_date"
+	timeSuffix     = "_time"
+	// timestampSuffix names the attribute that replaces a merged
+	// <stem>_date/<stem>_time pair.
+	timestampSuffix = "_timestamp"
+)
+
+// mqscTimeLayouts lists the time formats the REST API uses. MQSC displays
+// times with dots; some fields use colons.
+var mqscTimeLayouts = []string{"15.04.05", "15:04:05"}
+
+// responseTypes returns the qualifier's response type metadata keyed by the
+// mapped snake_case attribute name.
+func (mapper *attributeMapper) responseTypes(qualifier string) map[string]string {
+	qualifierData, exists := mapper.data.Qualifiers[qualifier]
+	if !exists || len(qualifierData.ResponseTypeMap) == 0 {
+		return nil
+	}
+
+	types := make(map[string]string, len(qualifierData.ResponseTypeMap))
+	for mqscName, valueType := range qualifierData.ResponseTypeMap {
+		if snakeName, exists := qualifierData.ResponseKeyMap[mqscName]; exists {
+			types[snakeName] = valueType
+		}
+	}
+	return types
+}
+
+// applyValueConversion converts mapped response values to native Go types
+// when the session has value conversion enabled. It runs after
+// applyResponseMapping and relies on snake_case attribute names, so it is a
+// no-op when attribute mapping is disabled.
+func (session *Session) applyValueConversion(mappingQualifier string, objects []map[string]any) []map[string]any {
+	if !session.convertValues || session.mapper == nil || mappingQualifier == "" || len(objects) == 0 {
+		return objects
+	}
+
+	location := session.timeLocation
+	if location == nil {
+		location = time.UTC
+	}
+
+	types := session.mapper.responseTypes(mappingQualifier)
+	converted := make([]map[string]any, len(objects))
+	for idx, object := range objects {
+		converted[idx] = convertResponseObject(object, types, location)
+	}
+	return converted
+}
+
+// convertResponseObject returns a copy of object with blank strings replaced
+// by nil, typed attributes parsed, and <stem>_date/<stem>_time pairs merged
+// into a single <stem>_timestamp time.Time. Values that fail to parse are
+// left unchanged.
+func convertResponseObject(object map[string]any, types map[string]string, location *time.Location) map[string]any {
+	result := make(map[string]any, len(object))
+
+	for key, value := range object {
+		if text, isString := value.(string); isString && strings.TrimSpace(text) == "" {
+			result[key] = nil
+			continue
+		}
+
+		switch types[key] {
+		case valueTypeInteger:
+			result[key] = convertInteger(value)
+		case valueTypeIntegerList:
+			result[key] = convertIntegerList(value)
+		case valueTypeList:
+			result[key] = convertList(value)
+		default:
+			result[key] = value
+		}
+	}
+
+	for key, valueType := range types {
+		if valueType != valueTypeDate || !strings.HasSuffix(key, dateSuffix) {
+			continue
+		}
+		if _, exists := result[key]; !exists {
+			continue
+		}
+		mergeDateTime(result, strings.TrimSuffix(key, dateSuffix), types, location)
+	}
+
+	return result
+}
+
+// mergeDateTime replaces <stem>_date and its matching <stem>_time with a
+// <stem>_timestamp holding a time.Time, or nil when the date is blank. A date
+// without a time partner becomes a time.Time at midnight in place.
+func mergeDateTime(object map[string]any, stem string, types map[string]string, location *time.Location) {
+	dateKey := stem + dateSuffix
+	timeKey := stem + timeSuffix
+
+	dateValue := object[dateKey]
+	timeValue, hasTime := object[timeKey]
+	if types[timeKey] != valueTypeTime || !hasTime {
+		if dateText, isString := dateValue.(string); isString {
+			if parsed, err := time.ParseInLocation(mqscDateLayout, strings.TrimSpace(dateText), location); err == nil {
+				object[dateKey] = parsed
+			}
+		}
+		return
+	}
+
+	if dateValue == nil {
+		delete(object, dateKey)
+		delete(object, timeKey)
+		object[stem+timestampSuffix] = nil
+		return
+	}
+
+	dateText, dateIsString := dateValue.(string)
+	timeText, timeIsString := timeValue.(string)
+	if !dateIsString || !timeIsString {
+		return
+	}
+
+	timestamp, parsed := parseMQSCTimestamp(strings.TrimSpace(dateText), strings.TrimSpace(timeText), location)
+	if !parsed {
+		return
+	}
+
+	delete(object, dateKey)
+	delete(object, timeKey)
+	object[stem+timestampSuffix] = timestamp
+}
+
+func parseMQSCTimestamp(dateText, timeText string, location *time.Location) (time.Time, bool) {
+	for _, timeLayout := range mqscTimeLayouts {
+		timestamp, err := time.ParseInLocation(mqscDateLayout+" "+timeLayout, dateText+" "+timeText, location)
+		if err == nil {
+			return timestamp, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// convertInteger returns value as an int64 when it is an integral number or
+// a numeric string.
+func convertInteger(value any) any {
+	if number, isInteger := Int64Value(value); isInteger {
+		return number
+	}
+	return value
+}
+
+// convertList splits a space- or comma-delimited string into a []string and
+// normalizes a JSON array of strings to []string.
+func convertList(value any) any {
+	switch typed := value.(type) {
+	case string:
+		return splitList(typed)
+	case []any:
+		items := make([]string, 0, len(typed))
+		for _, item := range typed {
+			text, isString := item.(string)
+			if !isString {
+				return value
+			}
+			items = append(items, strings.TrimSpace(text))
+		}
+		return items
+	}
+	return value
+}
+
+// convertIntegerList converts a delimited string or JSON array of numbers to
+// []int64. Blank entries are dropped; any unparseable entry leaves the value
+// unchanged.
+func convertIntegerList(value any) any {
+	var items []any
+	switch typed := value.(type) {
+	case string:
+		for _, item := range splitList(typed) {
+			items = append(items, item)
+		}
+	case []any:
+		items = typed
+	default:
+		return value
+	}
+
+	numbers := make([]int64, 0, len(items))
+	for _, item := range items {
+		number, isInteger := convertInteger(item).(int64)
+		if !isInteger {
+			return value
+		}
+		numbers = append(numbers, number)
+	}
+	return numbers
+}
+
+func splitList(text string) []string {
+	return strings.FieldsFunc(text, func(r rune) bool {
+		return r == ' ' || r == ','
+	})
+}
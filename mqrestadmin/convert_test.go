@@ -0,0 +1,336 @@
+package mqrestadmin
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func newConvertingTestSession(transport *mockTransport) *Session {
+	session := newTestSessionWithMapping(transport)
+	session.convertValues = true
+	return session
+}
+
+func TestNewSession_WithConvertValues(t *testing.T) {
+	location := time.FixedZone("EST", -5*60*60)
+	session, err := NewSession(
+		"https://localhost:9443/ibmmq/rest/v2",
+		"QM1",
+		BasicAuth{Username: "admin", Password: "pass"},
+		WithTransport(newMockTransport()),
+		WithConvertValues(true),
+		WithTimeLocation(location),
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !session.convertValues {
+		t.Error("convertValues should be true")
+	}
+	if session.timeLocation != location {
+		t.Errorf("timeLocation = %v, want %v", session.timeLocation, location)
+	}
+}
+
+func TestNewSession_ConvertValuesDefaultsOff(t *testing.T) {
+	session, err := NewSession(
+		"https://localhost:9443/ibmmq/rest/v2",
+		"QM1",
+		BasicAuth{Username: "admin", Password: "pass"},
+		WithTransport(newMockTransport()),
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if session.convertValues {
+		t.Error("convertValues should default to false")
+	}
+	if session.timeLocation != time.UTC {
+		t.Errorf("timeLocation = %v, want UTC", session.timeLocation)
+	}
+}
+
+func TestDisplayQstatus_ConvertValues(t *testing.T) {
+	transport := newMockTransport()
+	transport.addSuccessResponse(map[string]any{
+		"QUEUE":    "APP.Q",
+		"CURDEPTH": "42",
+		"IPPROCS":  float64(2),
+		"LGETDATE": "2026-03-01",
+		"LGETTIME": "10.30.45",
+		"LPUTDATE": "",
+		"LPUTTIME": "",
+		"QTIME":    "1500 2300",
+		"MSGAGE":   "",
+	})
+	session := newConvertingTestSession(transport)
+
+	rows, err := session.DisplayQstatus(context.Background(), "APP.Q")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	row := rows[0]
+
+	if row["current_queue_depth"] != int64(42) {
+		t.Errorf("current_queue_depth = %#v, want int64(42)", row["current_queue_depth"])
+	}
+	if row["open_input_count"] != int64(2) {
+		t.Errorf("open_input_count = %#v, want int64(2)", row["open_input_count"])
+	}
+	want := time.Date(2026, 3, 1, 10, 30, 45, 0, time.UTC)
+	if got, _ := row["last_get_timestamp"].(time.Time); !got.Equal(want) {
+		t.Errorf("last_get_timestamp = %#v, want %v", row["last_get_timestamp"], want)
+	}
+	if _, exists := row["last_get_date"]; exists {
+		t.Error("last_get_date should be merged into last_get_timestamp")
+	}
+	if _, exists := row["last_get_time"]; exists {
+		t.Error("last_get_time should be merged into last_get_timestamp")
+	}
+	if value, exists := row["last_put_timestamp"]; !exists || value != nil {
+		t.Errorf("last_put_timestamp = %#v, want nil", value)
+	}
+	if !reflect.DeepEqual(row["on_queue_time"], []int64{1500, 2300}) {
+		t.Errorf("on_queue_time = %#v, want [1500 2300]", row["on_queue_time"])
+	}
+	if value, exists := row["oldest_message_age"]; !exists || value != nil {
+		t.Errorf("oldest_message_age = %#v, want nil", value)
+	}
+	if row["queue_name"] != "APP.Q" {
+		t.Errorf("queue_name = %#v, want APP.Q", row["queue_name"])
+	}
+}
+
+func TestDisplayQueue_ConvertValuesDisabled(t *testing.T) {
+	transport := newMockTransport()
+	transport.addSuccessResponse(map[string]any{"CURDEPTH": "42", "ALTDATE": "2026-03-01", "ALTTIME": "10.30.45"})
+	session := newTestSessionWithMapping(transport)
+
+	rows, err := session.DisplayQueue(context.Background(), "APP.Q")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if rows[0]["current_queue_depth"] != "42" {
+		t.Errorf("current_queue_depth = %#v, want raw string", rows[0]["current_queue_depth"])
+	}
+	if rows[0]["alteration_date"] != "2026-03-01" {
+		t.Errorf("alteration_date = %#v, want raw string", rows[0]["alteration_date"])
+	}
+}
+
+func TestDisplayQueue_ConvertValuesUsesTimeLocation(t *testing.T) {
+	transport := newMockTransport()
+	transport.addSuccessResponse(map[string]any{"ALTDATE": "2026-03-01", "ALTTIME": "23:59:00"})
+	session := newConvertingTestSession(transport)
+	location := time.FixedZone("CET", 60*60)
+	session.timeLocation = location
+
+	rows, err := session.DisplayQueue(context.Background(), "APP.Q")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := time.Date(2026, 3, 1, 23, 59, 0, 0, location)
+	if got, _ := rows[0]["alteration_timestamp"].(time.Time); !got.Equal(want) {
+		t.Errorf("alteration_timestamp = %#v, want %v", rows[0]["alteration_timestamp"], want)
+	}
+}
+
+func TestApplyValueConversion_NilLocationDefaultsToUTC(t *testing.T) {
+	session := newConvertingTestSession(newMockTransport())
+	session.timeLocation = nil
+
+	rows := session.applyValueConversion("queue", []map[string]any{
+		{"alteration_date": "2026-03-01", "alteration_time": "01.02.03"},
+	})
+	got, _ := rows[0]["alteration_timestamp"].(time.Time)
+	if got.Location() != time.UTC {
+		t.Errorf("location = %v, want UTC", got.Location())
+	}
+}
+
+func TestApplyValueConversion_MappingDisabled(t *testing.T) {
+	session := newTestSession(newMockTransport())
+	session.convertValues = true
+	objects := []map[string]any{{"curdepth": "5"}}
+
+	rows := session.applyValueConversion("", objects)
+	if rows[0]["curdepth"] != "5" {
+		t.Errorf("curdepth = %#v, want unchanged", rows[0]["curdepth"])
+	}
+}
+
+func TestConvertResponseObject(t *testing.T) {
+	types := map[string]string{
+		"count":         valueTypeInteger,
+		"names":         valueTypeList,
+		"times":         valueTypeIntegerList,
+		"created_date":  valueTypeDate,
+		"created_time":  valueTypeTime,
+		"backup_date":   valueTypeDate,
+		"odd_date":      valueTypeDate,
+		"odd_time":      valueTypeTime,
+		"bad_date":      valueTypeDate,
+		"bad_time":      valueTypeTime,
+		"not_a_date_at": valueTypeDate,
+	}
+
+	tests := []struct {
+		name   string
+		object map[string]any
+		want   map[string]any
+	}{
+		{
+			name:   "integer string",
+			object: map[string]any{"count": " 17 "},
+			want:   map[string]any{"count": int64(17)},
+		},
+		{
+			name:   "integer float",
+			object: map[string]any{"count": float64(17)},
+			want:   map[string]any{"count": int64(17)},
+		},
+		{
+			name:   "integer int",
+			object: map[string]any{"count": 17},
+			want:   map[string]any{"count": int64(17)},
+		},
+		{
+			name:   "non-integral float unchanged",
+			object: map[string]any{"count": 1.5},
+			want:   map[string]any{"count": 1.5},
+		},
+		{
+			name:   "non-numeric string unchanged",
+			object: map[string]any{"count": "AUTO"},
+			want:   map[string]any{"count": "AUTO"},
+		},
+		{
+			name:   "list string",
+			object: map[string]any{"names": "Q1 Q2,Q3"},
+			want:   map[string]any{"names": []string{"Q1", "Q2", "Q3"}},
+		},
+		{
+			name:   "list array",
+			object: map[string]any{"names": []any{"Q1 ", "Q2"}},
+			want:   map[string]any{"names": []string{"Q1", "Q2"}},
+		},
+		{
+			name:   "list array with non-string unchanged",
+			object: map[string]any{"names": []any{"Q1", float64(2)}},
+			want:   map[string]any{"names": []any{"Q1", float64(2)}},
+		},
+		{
+			name:   "list non-string unchanged",
+			object: map[string]any{"names": float64(3)},
+			want:   map[string]any{"names": float64(3)},
+		},
+		{
+			name:   "integer list array",
+			object: map[string]any{"times": []any{float64(1), "2"}},
+			want:   map[string]any{"times": []int64{1, 2}},
+		},
+		{
+			name:   "integer list unparseable unchanged",
+			object: map[string]any{"times": "1 x"},
+			want:   map[string]any{"times": "1 x"},
+		},
+		{
+			name:   "integer list non-list unchanged",
+			object: map[string]any{"times": true},
+			want:   map[string]any{"times": true},
+		},
+		{
+			name:   "blank string becomes nil",
+			object: map[string]any{"description": "   "},
+			want:   map[string]any{"description": nil},
+		},
+		{
+			name:   "date without time partner",
+			object: map[string]any{"backup_date": "2026-01-02"},
+			want:   map[string]any{"backup_date": time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC)},
+		},
+		{
+			name:   "date with absent time partner",
+			object: map[string]any{"created_date": "2026-01-02"},
+			want:   map[string]any{"created_date": time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC)},
+		},
+		{
+			name:   "unparseable lone date unchanged",
+			object: map[string]any{"backup_date": "someday"},
+			want:   map[string]any{"backup_date": "someday"},
+		},
+		{
+			name:   "non-string date pair unchanged",
+			object: map[string]any{"odd_date": float64(1), "odd_time": "10.00.00"},
+			want:   map[string]any{"odd_date": float64(1), "odd_time": "10.00.00"},
+		},
+		{
+			name:   "unparseable pair unchanged",
+			object: map[string]any{"bad_date": "2026-13-45", "bad_time": "10.00.00"},
+			want:   map[string]any{"bad_date": "2026-13-45", "bad_time": "10.00.00"},
+		},
+		{
+			name:   "type without _date suffix ignored",
+			object: map[string]any{"not_a_date_at": "2026-01-02"},
+			want:   map[string]any{"not_a_date_at": "2026-01-02"},
+		},
+		{
+			name:   "merged pair",
+			object: map[string]any{"created_date": "2026-01-02", "created_time": "03:04:05"},
+			want:   map[string]any{"created_timestamp": time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := convertResponseObject(tt.object, types, time.UTC)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("convertResponseObject() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResponseTypes_UnknownQualifier(t *testing.T) {
+	mapper, err := newAttributeMapper()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if types := mapper.responseTypes("nonexistent"); types != nil {
+		t.Errorf("responseTypes = %v, want nil", types)
+	}
+}
+
+func TestResponseTypes_SkipsUnmappedNames(t *testing.T) {
+	mapper, err := newAttributeMapperWithOverrides(map[string]any{
+		"qualifiers": map[string]any{
+			"queue": map[string]any{
+				"response_type_map": map[string]string{"NOSUCHKEY": valueTypeInteger},
+			},
+		},
+	}, MappingOverrideMerge)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	types := mapper.responseTypes("queue")
+	if types["max_queue_depth"] != valueTypeInteger {
+		t.Errorf("max_queue_depth type = %q, want integer", types["max_queue_depth"])
+	}
+	for name := range types {
+		if name == "NOSUCHKEY" {
+			t.Error("unmapped MQSC name should not appear in snake_case types")
+		}
+	}
+}
+
+func TestValuesMatch_NilCurrentMatchesEmpty(t *testing.T) {
+	if !valuesMatch("", nil) {
+		t.Error("empty desired value should match nil current value")
+	}
+	if valuesMatch("x", nil) {
+		t.Error("non-empty desired value should not match nil current value")
+	}
+}
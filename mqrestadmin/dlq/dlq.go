@@ -0,0 +1,235 @@
+// Package dlq browses and triages a queue manager's dead-letter queue
+// through the messaging REST API.
+//
+// An Inspector lists the messages on the dead-letter queue, decodes the
+// MQDLH header in front of each one, and summarises them by reason and
+// original destination. Retry moves a single message back to the queue it
+// was originally sent to:
+//
+//	inspector, err := dlq.New(ctx, session)
+//	entries, err := inspector.Browse(ctx)
+//	summary := dlq.Summarize(entries)
+//	for _, reason := range summary.ByReason {
+//		fmt.Println(reason.Name, reason.Count)
+//	}
+//	_, err = inspector.Retry(ctx, entries[0].Message.MessageID)
+package dlq
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/wphillipmoore/mq-rest-admin-go/mqrestadmin"
+)
+
+// ErrMessageNotFound is returned by Retry when the message is no longer on
+// the dead-letter queue.
+var ErrMessageNotFound = errors.New("message is not on the dead-letter queue")
+
+// retriedProperties lists the message descriptor fields Retry copies from
+// the dead-lettered message to the message it puts.
+var retriedProperties = []string{"persistence", "replyTo"}
+
+// Inspector browses one dead-letter queue.
+type Inspector struct {
+	session   *mqrestadmin.Session
+	messaging *mqrestadmin.Messaging
+	queue     string
+}
+
+// New returns an inspector for the dead-letter queue configured on the
+// session's queue manager (its dead_letter_queue_name, or DEADQ when
+// attribute mapping is disabled).
+func New(ctx context.Context, session *mqrestadmin.Session) (*Inspector, error) {
+	qmgr, err := session.DisplayQmgr(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, key := range []string{"dead_letter_queue_name", "DEADQ"} {
+		if name, isString := qmgr[key].(string); isString && strings.TrimSpace(name) != "" {
+			return NewForQueue(session, strings.TrimSpace(name)), nil
+		}
+	}
+	return nil, fmt.Errorf("queue manager %s has no dead-letter queue configured", session.QmgrName())
+}
+
+// NewForQueue returns an inspector for the named queue, which holds
+// dead-lettered messages.
+func NewForQueue(session *mqrestadmin.Session, queue string) *Inspector {
+	return &Inspector{session: session, messaging: session.Messaging(), queue: queue}
+}
+
+// QueueName returns the name of the inspected queue.
+func (inspector *Inspector) QueueName() string {
+	return inspector.queue
+}
+
+// Entry is one message on the dead-letter queue.
+type Entry struct {
+	// Message is the message as browsed, including its MQDLH.
+	Message mqrestadmin.Message
+	// Header is the decoded MQDLH, valid when Err is nil.
+	Header Header
+	// Payload is the original message data after the MQDLH.
+	Payload []byte
+	// Err is the error decoding the MQDLH, or nil.
+	Err error
+}
+
+// Browse reads every message on the dead-letter queue without removing it
+// and decodes its MQDLH. Messages removed by another application while
+// browsing are skipped. A message without a valid MQDLH is returned with
+// Err set.
+func (inspector *Inspector) Browse(ctx context.Context) ([]Entry, error) {
+	listed, err := inspector.messaging.List(ctx, inspector.queue)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]Entry, 0, len(listed))
+	for _, descriptor := range listed {
+		entry, found, err := inspector.browseEntry(ctx, descriptor.MessageID)
+		if err != nil {
+			return entries, err
+		}
+		if found {
+			entries = append(entries, entry)
+		}
+	}
+	return entries, nil
+}
+
+// browseEntry browses and decodes one message by ID.
+func (inspector *Inspector) browseEntry(ctx context.Context, messageID string) (Entry, bool, error) {
+	message, err := inspector.messaging.Browse(ctx, inspector.queue, mqrestadmin.WithMessageID(messageID))
+	if err != nil || message == nil {
+		return Entry{}, false, err
+	}
+	entry := Entry{Message: *message}
+	entry.Header, entry.Payload, entry.Err = DecodeHeader(message.Body)
+	return entry, true, nil
+}
+
+// ReasonCount is the number of dead-lettered messages with one reason.
+type ReasonCount struct {
+	Reason int
+	Name   string
+	Count  int
+}
+
+// DestinationCount is the number of dead-lettered messages originally
+// sent to one queue.
+type DestinationCount struct {
+	Queue string
+	Qmgr  string
+	Count int
+}
+
+// Summary groups dead-lettered messages by reason and by original
+// destination, each ordered by descending count.
+type Summary struct {
+	Total int
+	// Undecoded counts the messages without a valid MQDLH, which are left
+	// out of ByReason and ByDestination.
+	Undecoded     int
+	ByReason      []ReasonCount
+	ByDestination []DestinationCount
+}
+
+// Summarize groups entries by reason and original destination.
+func Summarize(entries []Entry) Summary {
+	summary := Summary{Total: len(entries)}
+	reasons := map[int]int{}
+	destinations := map[DestinationCount]int{}
+	for _, entry := range entries {
+		if entry.Err != nil {
+			summary.Undecoded++
+			continue
+		}
+		reasons[entry.Header.Reason]++
+		destinations[DestinationCount{Queue: entry.Header.DestinationQueue, Qmgr: entry.Header.DestinationQmgr}]++
+	}
+
+	for reason, count := range reasons {
+		summary.ByReason = append(summary.ByReason, ReasonCount{Reason: reason, Name: ReasonName(reason), Count: count})
+	}
+	slices.SortFunc(summary.ByReason, func(left, right ReasonCount) int {
+		return cmp.Or(cmp.Compare(right.Count, left.Count), cmp.Compare(left.Reason, right.Reason))
+	})
+
+	for destination, count := range destinations {
+		destination.Count = count
+		summary.ByDestination = append(summary.ByDestination, destination)
+	}
+	slices.SortFunc(summary.ByDestination, func(left, right DestinationCount) int {
+		return cmp.Or(cmp.Compare(right.Count, left.Count),
+			cmp.Compare(left.Qmgr, right.Qmgr), cmp.Compare(left.Queue, right.Queue))
+	})
+	return summary
+}
+
+// Retry moves one message from the dead-letter queue back to its original
+// destination queue, without its MQDLH. The message is browsed and its
+// header checked before it is removed, and it is put back on the
+// dead-letter queue if the put to the destination fails.
+//
+// Only messages whose original destination is the session's queue
+// manager can be retried, because the messaging REST API puts to local
+// queues only. The retried message keeps its correlation ID, persistence
+// and reply-to queue; the queue manager assigns a new message ID.
+func (inspector *Inspector) Retry(ctx context.Context, messageID string) (Entry, error) {
+	entry, found, err := inspector.browseEntry(ctx, messageID)
+	if err != nil {
+		return Entry{}, err
+	}
+	if !found {
+		return Entry{}, ErrMessageNotFound
+	}
+	if entry.Err != nil {
+		return entry, entry.Err
+	}
+	if qmgr := entry.Header.DestinationQmgr; qmgr != "" && qmgr != inspector.session.QmgrName() {
+		return entry, fmt.Errorf("message %s was sent to queue manager %s and cannot be retried from %s",
+			messageID, qmgr, inspector.session.QmgrName())
+	}
+
+	removed, err := inspector.messaging.Get(ctx, inspector.queue, mqrestadmin.WithMessageID(messageID))
+	if err != nil {
+		return entry, err
+	}
+	if removed == nil {
+		return entry, ErrMessageNotFound
+	}
+
+	retried := mqrestadmin.Message{
+		Body:          entry.Payload,
+		ContentType:   mqrestadmin.BinaryContentType,
+		CorrelationID: removed.CorrelationID,
+		Properties:    map[string]string{},
+	}
+	if entry.Header.Format == FormatString {
+		retried.ContentType = mqrestadmin.TextContentType
+	}
+	for _, name := range retriedProperties {
+		if value, exists := removed.Properties[name]; exists {
+			retried.Properties[name] = value
+		}
+	}
+
+	if _, err := inspector.messaging.Put(ctx, entry.Header.DestinationQueue, retried); err != nil {
+		restored := mqrestadmin.Message{
+			Body:          removed.Body,
+			ContentType:   mqrestadmin.BinaryContentType,
+			CorrelationID: removed.CorrelationID,
+		}
+		if _, restoreErr := inspector.messaging.Put(ctx, inspector.queue, restored); restoreErr != nil {
+			return entry, errors.Join(err, fmt.Errorf("restore to %s: %w", inspector.queue, restoreErr))
+		}
+		return entry, err
+	}
+	return entry, nil
+}
@@ -0,0 +1,363 @@
+package dlq
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/wphillipmoore/mq-rest-admin-go/mqrestadmin"
+)
+
+type storedMessage struct {
+	id          string
+	body        string
+	correlation string
+	headers     map[string]string
+}
+
+// queueTransport is a fake REST API holding messages per queue. It answers
+// DISPLAY QMGR with qmgr, and messaging requests from its queues.
+type queueTransport struct {
+	qmgr     map[string]any
+	queues   map[string][]storedMessage
+	failPut  map[string]int
+	browseFn func(queue, messageID string) (*mqrestadmin.TransportResponse, bool)
+	nextID   int
+}
+
+func newQueueTransport() *queueTransport {
+	return &queueTransport{queues: map[string][]storedMessage{}, failPut: map[string]int{}}
+}
+
+func (transport *queueTransport) add(queue, body string, headers map[string]string) string {
+	transport.nextID++
+	id := fmt.Sprintf("%048X", transport.nextID)
+	transport.queues[queue] = append(transport.queues[queue],
+		storedMessage{id: id, body: body, correlation: headers["ibm-mq-md-correlationId"], headers: headers})
+	return id
+}
+
+func (transport *queueTransport) PostJSON(_ context.Context, _ string, _ map[string]any,
+	_ map[string]string, _ time.Duration, _ bool,
+) (*mqrestadmin.TransportResponse, error) {
+	body, _ := json.Marshal(map[string]any{
+		"overallCompletionCode": 0,
+		"overallReasonCode":     0,
+		"commandResponse":       []any{map[string]any{"completionCode": 0, "reasonCode": 0, "parameters": transport.qmgr}},
+	})
+	return &mqrestadmin.TransportResponse{StatusCode: 200, Body: string(body)}, nil
+}
+
+func (transport *queueTransport) SendRequest(_ context.Context, method, requestURL string, body []byte,
+	headers map[string]string, _ time.Duration, _ bool,
+) (*mqrestadmin.TransportResponse, error) {
+	parsed, _ := url.Parse(requestURL)
+	parts := strings.Split(parsed.Path, "/")
+	queue, resource := parts[len(parts)-2], parts[len(parts)-1]
+	messageID := parsed.Query().Get("messageId")
+
+	switch {
+	case resource == "messagelist":
+		var messages []map[string]any
+		for _, message := range transport.queues[queue] {
+			messages = append(messages, map[string]any{"messageId": message.id, "format": "MQDEAD"})
+		}
+		listBody, _ := json.Marshal(map[string]any{"messages": messages})
+		return &mqrestadmin.TransportResponse{StatusCode: 200, Body: string(listBody)}, nil
+	case method == http.MethodPost:
+		if status := transport.failPut[queue]; status != 0 {
+			return &mqrestadmin.TransportResponse{StatusCode: status, Body: "put failed"}, nil
+		}
+		id := transport.add(queue, string(body), headers)
+		return &mqrestadmin.TransportResponse{StatusCode: 201, Headers: map[string]string{"Ibm-Mq-Md-Messageid": id}}, nil
+	}
+
+	if transport.browseFn != nil && method == http.MethodGet {
+		if response, handled := transport.browseFn(queue, messageID); handled {
+			return response, nil
+		}
+	}
+	for index, message := range transport.queues[queue] {
+		if message.id != messageID {
+			continue
+		}
+		if method == http.MethodDelete {
+			transport.queues[queue] = append(transport.queues[queue][:index], transport.queues[queue][index+1:]...)
+		}
+		responseHeaders := map[string]string{
+			"Content-Type":            "application/octet-stream",
+			"Ibm-Mq-Md-Messageid":     message.id,
+			"Ibm-Mq-Md-Correlationid": message.correlation,
+			"Ibm-Mq-Md-Persistence":   "persistent",
+			"Ibm-Mq-Md-Replyto":       "APP.REPLY",
+			"Ibm-Mq-Md-Priority":      "4",
+		}
+		return &mqrestadmin.TransportResponse{StatusCode: 200, Body: message.body, Headers: responseHeaders}, nil
+	}
+	return &mqrestadmin.TransportResponse{StatusCode: 204}, nil
+}
+
+func newTestInspector(t *testing.T, transport *queueTransport) *Inspector {
+	t.Helper()
+	session, err := mqrestadmin.NewSession("https://localhost:9443/ibmmq/rest/v2", "QM1",
+		mqrestadmin.BasicAuth{Username: "admin", Password: "admin"},
+		mqrestadmin.WithTransport(transport),
+	)
+	if err != nil {
+		t.Fatalf("NewSession: %v", err)
+	}
+	return NewForQueue(session, "DLQ")
+}
+
+func deadLetter(reason int, queue, qmgr, format, payload string) string {
+	return string(buildHeader(binary.BigEndian, reason, queue, qmgr, format, "20261018", "01020300", []byte(payload)))
+}
+
+func TestNew(t *testing.T) {
+	transport := newQueueTransport()
+	transport.qmgr = map[string]any{"DEADQ": "SYSTEM.DEAD.LETTER.QUEUE  "}
+	session, _ := mqrestadmin.NewSession("https://localhost:9443/ibmmq/rest/v2", "QM1",
+		mqrestadmin.BasicAuth{Username: "admin", Password: "admin"}, mqrestadmin.WithTransport(transport))
+
+	inspector, err := New(context.Background(), session)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if inspector.QueueName() != "SYSTEM.DEAD.LETTER.QUEUE" {
+		t.Errorf("QueueName() = %q", inspector.QueueName())
+	}
+
+	transport.qmgr = map[string]any{"DEADQ": " "}
+	if _, err := New(context.Background(), session); err == nil || !strings.Contains(err.Error(), "no dead-letter queue") {
+		t.Errorf("error = %v, want no dead-letter queue", err)
+	}
+
+	failing, _ := mqrestadmin.NewSession("https://localhost:9443/ibmmq/rest/v2", "QM1",
+		mqrestadmin.BasicAuth{Username: "admin", Password: "admin"}, mqrestadmin.WithTransport(&erroringTransport{}))
+	if _, err := New(context.Background(), failing); err == nil {
+		t.Error("expected DisplayQmgr error")
+	}
+}
+
+type erroringTransport struct{ queueTransport }
+
+func (*erroringTransport) PostJSON(context.Context, string, map[string]any, map[string]string, time.Duration, bool,
+) (*mqrestadmin.TransportResponse, error) {
+	return nil, errors.New("connection refused")
+}
+
+func TestBrowseAndSummarize(t *testing.T) {
+	transport := newQueueTransport()
+	transport.add("DLQ", deadLetter(2085, "APP.MISSING", "QM1", "MQSTR", "a"), nil)
+	transport.add("DLQ", deadLetter(2053, "APP.FULL", "QM1", "MQSTR", "b"), nil)
+	transport.add("DLQ", deadLetter(2085, "APP.MISSING", "QM1", "MQSTR", "c"), nil)
+	transport.add("DLQ", "no header", nil)
+	gone := transport.add("DLQ", deadLetter(2085, "APP.GONE", "QM1", "MQSTR", "d"), nil)
+	transport.browseFn = func(_, messageID string) (*mqrestadmin.TransportResponse, bool) {
+		return &mqrestadmin.TransportResponse{StatusCode: 204}, messageID == gone
+	}
+	inspector := newTestInspector(t, transport)
+
+	entries, err := inspector.Browse(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(entries) != 4 {
+		t.Fatalf("entries = %d, want 4 (the removed message skipped)", len(entries))
+	}
+	if string(entries[0].Payload) != "a" || entries[0].Header.DestinationQueue != "APP.MISSING" ||
+		!errors.Is(entries[3].Err, ErrNoHeader) {
+		t.Errorf("entries = %+v", entries)
+	}
+	if len(transport.queues["DLQ"]) != 5 {
+		t.Errorf("browse removed messages: %d left", len(transport.queues["DLQ"]))
+	}
+
+	summary := Summarize(entries)
+	want := Summary{
+		Total:     4,
+		Undecoded: 1,
+		ByReason: []ReasonCount{
+			{Reason: 2085, Name: "MQRC_UNKNOWN_OBJECT_NAME", Count: 2},
+			{Reason: 2053, Name: "MQRC_Q_FULL", Count: 1},
+		},
+		ByDestination: []DestinationCount{
+			{Queue: "APP.MISSING", Qmgr: "QM1", Count: 2},
+			{Queue: "APP.FULL", Qmgr: "QM1", Count: 1},
+		},
+	}
+	if !reflect.DeepEqual(summary, want) {
+		t.Errorf("summary = %+v, want %+v", summary, want)
+	}
+}
+
+func TestBrowse_Errors(t *testing.T) {
+	transport := newQueueTransport()
+	transport.add("DLQ", deadLetter(2085, "APP.Q", "QM1", "MQSTR", "a"), nil)
+	transport.browseFn = func(string, string) (*mqrestadmin.TransportResponse, bool) {
+		return &mqrestadmin.TransportResponse{StatusCode: 500, Body: "boom"}, true
+	}
+	inspector := newTestInspector(t, transport)
+
+	var messagingErr *mqrestadmin.MessagingError
+	if _, err := inspector.Browse(context.Background()); !errors.As(err, &messagingErr) {
+		t.Errorf("browse error = %v, want MessagingError", err)
+	}
+
+	session, _ := mqrestadmin.NewSession("https://localhost:9443/ibmmq/rest/v2", "QM1",
+		mqrestadmin.BasicAuth{Username: "admin", Password: "admin"}, mqrestadmin.WithTransport(&postOnlyTransport{}))
+	if _, err := NewForQueue(session, "DLQ").Browse(context.Background()); err == nil {
+		t.Error("expected list error without RequestTransport")
+	}
+}
+
+type postOnlyTransport struct{}
+
+func (*postOnlyTransport) PostJSON(context.Context, string, map[string]any, map[string]string, time.Duration, bool,
+) (*mqrestadmin.TransportResponse, error) {
+	return nil, errors.New("unused")
+}
+
+func TestRetry(t *testing.T) {
+	transport := newQueueTransport()
+	id := transport.add("DLQ", deadLetter(2053, "APP.Q", "QM1", "MQSTR", "order 42"),
+		map[string]string{"ibm-mq-md-correlationId": "C0FFEE"})
+	inspector := newTestInspector(t, transport)
+
+	entry, err := inspector.Retry(context.Background(), id)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if entry.Header.Reason != 2053 || len(transport.queues["DLQ"]) != 0 {
+		t.Errorf("entry = %+v, DLQ = %v", entry, transport.queues["DLQ"])
+	}
+	retried := transport.queues["APP.Q"]
+	if len(retried) != 1 || retried[0].body != "order 42" {
+		t.Fatalf("APP.Q = %+v, want the payload without its header", retried)
+	}
+	wantHeaders := map[string]string{
+		"Content-Type":            mqrestadmin.TextContentType,
+		"ibm-mq-md-correlationId": "C0FFEE",
+		"ibm-mq-md-persistence":   "persistent",
+		"ibm-mq-md-replyTo":       "APP.REPLY",
+	}
+	for name, value := range wantHeaders {
+		if retried[0].headers[name] != value {
+			t.Errorf("header %s = %q, want %q", name, retried[0].headers[name], value)
+		}
+	}
+	if _, copied := retried[0].headers["ibm-mq-md-priority"]; copied {
+		t.Error("priority should not be copied")
+	}
+}
+
+func TestRetry_BinaryPayload(t *testing.T) {
+	transport := newQueueTransport()
+	id := transport.add("DLQ", deadLetter(2053, "APP.Q", "", "", "\x00\x01"), nil)
+	inspector := newTestInspector(t, transport)
+
+	if _, err := inspector.Retry(context.Background(), id); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := transport.queues["APP.Q"][0].headers["Content-Type"]; got != mqrestadmin.BinaryContentType {
+		t.Errorf("Content-Type = %q, want binary", got)
+	}
+}
+
+func TestRetry_PutFailureRestores(t *testing.T) {
+	transport := newQueueTransport()
+	body := deadLetter(2053, "APP.Q", "QM1", "MQSTR", "order 42")
+	id := transport.add("DLQ", body, map[string]string{"ibm-mq-md-correlationId": "C0FFEE"})
+	transport.failPut["APP.Q"] = 500
+	inspector := newTestInspector(t, transport)
+
+	_, err := inspector.Retry(context.Background(), id)
+	var messagingErr *mqrestadmin.MessagingError
+	if !errors.As(err, &messagingErr) || messagingErr.Queue != "APP.Q" {
+		t.Fatalf("error = %v, want the put failure", err)
+	}
+	restored := transport.queues["DLQ"]
+	if len(restored) != 1 || restored[0].body != body || restored[0].correlation != "C0FFEE" {
+		t.Errorf("DLQ = %+v, want the original message restored", restored)
+	}
+
+	id = restored[0].id
+	transport.failPut["DLQ"] = 500
+	_, err = inspector.Retry(context.Background(), id)
+	if err == nil || !strings.Contains(err.Error(), "restore to DLQ") {
+		t.Errorf("error = %v, want restore failure", err)
+	}
+}
+
+func TestRetry_Refusals(t *testing.T) {
+	transport := newQueueTransport()
+	remote := transport.add("DLQ", deadLetter(2087, "APP.Q", "QM2", "MQSTR", "x"), nil)
+	plain := transport.add("DLQ", "no header", nil)
+	inspector := newTestInspector(t, transport)
+
+	if _, err := inspector.Retry(context.Background(), remote); err == nil || !strings.Contains(err.Error(), "queue manager QM2") {
+		t.Errorf("remote error = %v", err)
+	}
+	if _, err := inspector.Retry(context.Background(), plain); !errors.Is(err, ErrNoHeader) {
+		t.Errorf("plain error = %v, want ErrNoHeader", err)
+	}
+	if _, err := inspector.Retry(context.Background(), "MISSING"); !errors.Is(err, ErrMessageNotFound) {
+		t.Errorf("missing error = %v, want ErrMessageNotFound", err)
+	}
+	if len(transport.queues["DLQ"]) != 2 {
+		t.Errorf("refused retries removed messages: %v", transport.queues["DLQ"])
+	}
+
+	transport.browseFn = func(string, string) (*mqrestadmin.TransportResponse, bool) {
+		return &mqrestadmin.TransportResponse{StatusCode: 500, Body: "boom"}, true
+	}
+	if _, err := inspector.Retry(context.Background(), remote); err == nil {
+		t.Error("expected browse error")
+	}
+}
+
+func TestRetry_GetRaces(t *testing.T) {
+	transport := newQueueTransport()
+	id := transport.add("DLQ", deadLetter(2053, "APP.Q", "QM1", "MQSTR", "x"), nil)
+	message := transport.queues["DLQ"][0]
+	// Browse still sees the message after another application removed it.
+	transport.browseFn = func(string, string) (*mqrestadmin.TransportResponse, bool) {
+		return &mqrestadmin.TransportResponse{StatusCode: 200, Body: message.body}, true
+	}
+	transport.queues["DLQ"] = nil
+	inspector := newTestInspector(t, transport)
+
+	if _, err := inspector.Retry(context.Background(), id); !errors.Is(err, ErrMessageNotFound) {
+		t.Errorf("error = %v, want ErrMessageNotFound", err)
+	}
+
+	transport.queues["DLQ"] = []storedMessage{{id: "BAD", body: message.body}}
+	transport.browseFn = func(string, string) (*mqrestadmin.TransportResponse, bool) {
+		return &mqrestadmin.TransportResponse{StatusCode: 200, Body: message.body}, true
+	}
+	session, _ := mqrestadmin.NewSession("https://localhost:9443/ibmmq/rest/v2", "QM1",
+		mqrestadmin.BasicAuth{Username: "admin", Password: "admin"},
+		mqrestadmin.WithTransport(&deleteFailingTransport{transport}))
+	if _, err := NewForQueue(session, "DLQ").Retry(context.Background(), "BAD"); err == nil {
+		t.Error("expected get error")
+	}
+}
+
+type deleteFailingTransport struct{ *queueTransport }
+
+func (transport *deleteFailingTransport) SendRequest(ctx context.Context, method, requestURL string, body []byte,
+	headers map[string]string, timeout time.Duration, verifyTLS bool,
+) (*mqrestadmin.TransportResponse, error) {
+	if method == http.MethodDelete {
+		return nil, errors.New("connection reset")
+	}
+	return transport.queueTransport.SendRequest(ctx, method, requestURL, body, headers, timeout, verifyTLS)
+}
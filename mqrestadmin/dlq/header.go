@@ -0,0 +1,110 @@
+package dlq
+
+import (
+	"encoding/binary"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// HeaderLength is the length in bytes of a version 1 MQDLH.
+const HeaderLength = 172
+
+const (
+	headerStrucID = "DLH "
+	headerVersion = 1
+
+	// FormatString is the MQFMT_STRING message format.
+	FormatString = "MQSTR"
+)
+
+// ErrNoHeader is returned by DecodeHeader when a message body does not
+// start with an MQDLH.
+var ErrNoHeader = errors.New("message does not start with a dead-letter header")
+
+// Header is a decoded MQDLH, the dead-letter header the queue manager puts
+// in front of a message it moves to the dead-letter queue.
+type Header struct {
+	// Reason is the MQRC or MQFB code explaining why the message was
+	// dead-lettered.
+	Reason int
+	// DestinationQueue and DestinationQmgr name where the message was
+	// originally sent.
+	DestinationQueue string
+	DestinationQmgr  string
+	// Encoding, CodedCharSetID and Format describe the original message
+	// data that follows the header.
+	Encoding       int
+	CodedCharSetID int
+	Format         string
+	// PutApplType and PutApplName identify the application that put the
+	// message on the dead-letter queue.
+	PutApplType int
+	PutApplName string
+	// PutTime is when the message was put on the dead-letter queue, in
+	// UTC, or the zero time when the header's date and time are not valid.
+	PutTime time.Time
+}
+
+// ReasonName returns the MQRC or MQFB constant name for the header's
+// reason code; see ReasonName.
+func (header Header) ReasonName() string {
+	return ReasonName(header.Reason)
+}
+
+// DecodeHeader decodes the MQDLH at the start of a dead-letter queue
+// message body and returns it with the original message data that
+// follows it. Integer fields are read in whichever byte order yields the
+// MQDLH version, so headers written by big- and little-endian queue
+// managers are both decoded. Character fields must be ASCII.
+func DecodeHeader(body []byte) (Header, []byte, error) {
+	if len(body) < HeaderLength || string(body[:4]) != headerStrucID {
+		return Header{}, nil, ErrNoHeader
+	}
+
+	var order binary.ByteOrder = binary.LittleEndian
+	if order.Uint32(body[4:8]) != headerVersion {
+		order = binary.BigEndian
+	}
+	if order.Uint32(body[4:8]) != headerVersion {
+		return Header{}, nil, errors.New("unsupported dead-letter header version")
+	}
+
+	integer := func(offset int) int {
+		return int(int32(order.Uint32(body[offset : offset+4])))
+	}
+	text := func(offset, length int) string {
+		return strings.TrimRight(string(body[offset:offset+length]), " \x00")
+	}
+
+	header := Header{
+		Reason:           integer(8),
+		DestinationQueue: text(12, 48),
+		DestinationQmgr:  text(60, 48),
+		Encoding:         integer(108),
+		CodedCharSetID:   integer(112),
+		Format:           text(116, 8),
+		PutApplType:      integer(124),
+		PutApplName:      text(128, 28),
+		PutTime:          parsePutTime(text(156, 8), text(164, 8)),
+	}
+	return header, body[HeaderLength:], nil
+}
+
+// parsePutTime combines an MQDLH PutDate (YYYYMMDD) and PutTime (HHMMSSTH)
+// into a UTC time.
+func parsePutTime(date, clock string) time.Time {
+	if len(clock) != 8 {
+		return time.Time{}
+	}
+	parsed, err := time.Parse("20060102150405", date+clock[:6])
+	if err != nil {
+		return time.Time{}
+	}
+	hundredths, err := strconv.Atoi(clock[6:])
+	if err != nil {
+		return time.Time{}
+	}
+	return parsed.Add(time.Duration(hundredths) * 10 * time.Millisecond)
+}
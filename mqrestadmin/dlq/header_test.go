@@ -0,0 +1,103 @@
+package dlq
+
+import (
+	"encoding/binary"
+	"errors"
+	"testing"
+	"time"
+)
+
+// buildHeader returns an MQDLH in the given byte order followed by payload.
+func buildHeader(order binary.AppendByteOrder, reason int, queue, qmgr, format, date, clock string, payload []byte) []byte {
+	body := make([]byte, 0, HeaderLength+len(payload))
+	text := func(value string, length int) {
+		field := make([]byte, length)
+		for index := range field {
+			field[index] = ' '
+		}
+		copy(field, value)
+		body = append(body, field...)
+	}
+	integer := func(value int) {
+		body = order.AppendUint32(body, uint32(int32(value)))
+	}
+
+	text("DLH ", 4)
+	integer(1)
+	integer(reason)
+	text(queue, 48)
+	text(qmgr, 48)
+	integer(546)
+	integer(1208)
+	text(format, 8)
+	integer(6)
+	text("amqrmppa", 28)
+	text(date, 8)
+	text(clock, 8)
+	return append(body, payload...)
+}
+
+func TestDecodeHeader(t *testing.T) {
+	for _, order := range []binary.AppendByteOrder{binary.LittleEndian, binary.BigEndian} {
+		body := buildHeader(order, 2085, "APP.Q", "QM1", "MQSTR", "20261018", "13450712", []byte("hello"))
+
+		header, payload, err := DecodeHeader(body)
+		if err != nil {
+			t.Fatalf("%v: unexpected error: %v", order, err)
+		}
+		want := Header{
+			Reason:           2085,
+			DestinationQueue: "APP.Q",
+			DestinationQmgr:  "QM1",
+			Encoding:         546,
+			CodedCharSetID:   1208,
+			Format:           "MQSTR",
+			PutApplType:      6,
+			PutApplName:      "amqrmppa",
+			PutTime:          time.Date(2026, 10, 18, 13, 45, 7, 120*int(time.Millisecond), time.UTC),
+		}
+		if header != want {
+			t.Errorf("%v: header = %+v, want %+v", order, header, want)
+		}
+		if string(payload) != "hello" {
+			t.Errorf("%v: payload = %q", order, payload)
+		}
+		if header.ReasonName() != "MQRC_UNKNOWN_OBJECT_NAME" {
+			t.Errorf("ReasonName() = %q", header.ReasonName())
+		}
+	}
+}
+
+func TestDecodeHeader_Errors(t *testing.T) {
+	valid := buildHeader(binary.LittleEndian, 2053, "APP.Q", "QM1", "MQSTR", "20261018", "13450712", nil)
+
+	if _, _, err := DecodeHeader(valid[:HeaderLength-1]); !errors.Is(err, ErrNoHeader) {
+		t.Errorf("short body error = %v, want ErrNoHeader", err)
+	}
+	if _, _, err := DecodeHeader([]byte("plain message text that is not a dead-letter header")); !errors.Is(err, ErrNoHeader) {
+		t.Errorf("plain body error = %v, want ErrNoHeader", err)
+	}
+
+	badVersion := append([]byte{}, valid...)
+	binary.LittleEndian.PutUint32(badVersion[4:8], 7)
+	if _, _, err := DecodeHeader(badVersion); err == nil || errors.Is(err, ErrNoHeader) {
+		t.Errorf("bad version error = %v, want a version error", err)
+	}
+}
+
+func TestParsePutTime_Invalid(t *testing.T) {
+	for _, clock := range [][2]string{{"20261018", "1345"}, {"2026XX18", "13450712"}, {"20261018", "134507XX"}} {
+		if parsed := parsePutTime(clock[0], clock[1]); !parsed.IsZero() {
+			t.Errorf("parsePutTime(%q, %q) = %v, want zero", clock[0], clock[1], parsed)
+		}
+	}
+}
+
+func TestReasonName(t *testing.T) {
+	if name := ReasonName(258); name != "MQFB_EXPIRATION" {
+		t.Errorf("ReasonName(258) = %q", name)
+	}
+	if name := ReasonName(9999); name != "unknown" {
+		t.Errorf("ReasonName(9999) = %q, want unknown", name)
+	}
+}
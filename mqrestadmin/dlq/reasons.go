@@ -0,0 +1,67 @@
+package dlq
+
+// reasonNames maps the reason codes commonly found in dead-letter headers
+// to their MQFB and MQRC constant names.
+var reasonNames = map[int]string{
+	256:  "MQFB_QUIT",
+	258:  "MQFB_EXPIRATION",
+	259:  "MQFB_COA",
+	260:  "MQFB_COD",
+	262:  "MQFB_CHANNEL_COMPLETED",
+	263:  "MQFB_CHANNEL_FAIL_RETRY",
+	264:  "MQFB_CHANNEL_FAIL",
+	265:  "MQFB_APPL_CANNOT_BE_STARTED",
+	266:  "MQFB_TM_ERROR",
+	267:  "MQFB_APPL_TYPE_ERROR",
+	268:  "MQFB_STOPPED_BY_MSG_EXIT",
+	269:  "MQFB_ACTIVITY",
+	271:  "MQFB_XMIT_Q_MSG_ERROR",
+	275:  "MQFB_PAN",
+	276:  "MQFB_NAN",
+	277:  "MQFB_STOPPED_BY_CHAD_EXIT",
+	279:  "MQFB_STOPPED_BY_PUBSUB_EXIT",
+	280:  "MQFB_NOT_A_REPOSITORY_MSG",
+	281:  "MQFB_BIND_OPEN_CLUSRCVR_DEL",
+	282:  "MQFB_MAX_ACTIVITIES",
+	283:  "MQFB_NOT_FORWARDED",
+	284:  "MQFB_NOT_DELIVERED",
+	285:  "MQFB_UNSUPPORTED_FORWARDING",
+	286:  "MQFB_UNSUPPORTED_DELIVERY",
+	2001: "MQRC_ALIAS_BASE_Q_TYPE_ERROR",
+	2030: "MQRC_MSG_TOO_BIG_FOR_Q",
+	2031: "MQRC_MSG_TOO_BIG_FOR_Q_MGR",
+	2035: "MQRC_NOT_AUTHORIZED",
+	2048: "MQRC_PERSISTENT_NOT_ALLOWED",
+	2051: "MQRC_PUT_INHIBITED",
+	2052: "MQRC_Q_DELETED",
+	2053: "MQRC_Q_FULL",
+	2056: "MQRC_Q_SPACE_NOT_AVAILABLE",
+	2057: "MQRC_Q_TYPE_ERROR",
+	2082: "MQRC_UNKNOWN_ALIAS_BASE_Q",
+	2085: "MQRC_UNKNOWN_OBJECT_NAME",
+	2086: "MQRC_UNKNOWN_OBJECT_Q_MGR",
+	2087: "MQRC_UNKNOWN_REMOTE_Q_MGR",
+	2091: "MQRC_XMIT_Q_TYPE_ERROR",
+	2092: "MQRC_XMIT_Q_USAGE_ERROR",
+	2110: "MQRC_FORMAT_ERROR",
+	2111: "MQRC_SOURCE_CCSID_ERROR",
+	2115: "MQRC_TARGET_CCSID_ERROR",
+	2119: "MQRC_NOT_CONVERTED",
+	2120: "MQRC_CONVERTED_MSG_TOO_BIG",
+	2189: "MQRC_CLUSTER_RESOLUTION_ERROR",
+	2192: "MQRC_PAGESET_FULL",
+	2196: "MQRC_UNKNOWN_XMIT_Q",
+	2197: "MQRC_UNKNOWN_DEF_XMIT_Q",
+	2198: "MQRC_DEF_XMIT_Q_TYPE_ERROR",
+	2199: "MQRC_DEF_XMIT_Q_USAGE_ERROR",
+	2268: "MQRC_CLUSTER_PUT_INHIBITED",
+}
+
+// ReasonName returns the MQFB or MQRC constant name for a dead-letter
+// reason code, or "unknown" when the code is not in the table.
+func ReasonName(reason int) string {
+	if name, known := reasonNames[reason]; known {
+		return name
+	}
+	return "unknown"
+}
@@ -164,6 +164,13 @@ func TestValuesMatch_CaseInsensitive(t *testing.T) {
 		{"5000", "10000", false},
 		{5000, "5000", true},
 		{"yes", "YES", true},
+		{"A,B", []string{"A", "B"}, true},
+		{"a b", []string{"A", "B"}, true},
+		{[]string{"A", "B"}, []string{"A", "B"}, true},
+		{[]any{"A", "B"}, []string{"A", "B"}, true},
+		{"A", []string{"A", "B"}, false},
+		{"B,A", []string{"A", "B"}, false},
+		{"1500 2300", []int64{1500, 2300}, true},
 	}
 
 	for _, test := range tests {
@@ -175,6 +182,51 @@ func TestValuesMatch_CaseInsensitive(t *testing.T) {
 	}
 }
 
+func TestEnsureNamelist_ConvertValuesUnchanged(t *testing.T) {
+	transport := newMockTransport()
+	transport.addSuccessResponse(map[string]any{
+		"NAMELIST": "CLUSTERS",
+		"NAMES":    []any{"CLUS1", "CLUS2"},
+	})
+	session := newConvertingTestSession(transport)
+
+	result, err := session.EnsureNamelist(context.Background(), "CLUSTERS",
+		map[string]any{"names": "CLUS1,CLUS2"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if result.Action != EnsureUnchanged {
+		t.Errorf("Action = %v, want EnsureUnchanged (Changed = %v)", result.Action, result.Changed)
+	}
+	if transport.callCount() != 1 {
+		t.Errorf("expected 1 transport call, got %d", transport.callCount())
+	}
+}
+
+func TestEnsureNamelist_ConvertValuesUpdated(t *testing.T) {
+	transport := newMockTransport()
+	transport.addSuccessResponse(map[string]any{
+		"NAMELIST": "CLUSTERS",
+		"NAMES":    []any{"CLUS1", "CLUS2"},
+	})
+	transport.addSuccessResponse()
+	session := newConvertingTestSession(transport)
+
+	result, err := session.EnsureNamelist(context.Background(), "CLUSTERS",
+		map[string]any{"names": "CLUS1,CLUS3"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if result.Action != EnsureUpdated {
+		t.Errorf("Action = %v, want EnsureUpdated", result.Action)
+	}
+	if len(result.Changed) != 1 || result.Changed[0] != "names" {
+		t.Errorf("Changed = %v, want [names]", result.Changed)
+	}
+}
+
 func TestEnsureQlocal_DisplayNonCommandError(t *testing.T) {
 	transport := newMockTransport()
 	// DISPLAY returns a transport error (not a CommandError)
@@ -1,7 +1,9 @@
 package mqrestadmin
 
 import (
+	"errors"
 	"fmt"
+	"slices"
 	"strings"
 )
 
@@ -53,6 +55,130 @@ func (e *CommandError) Error() string {
 	return fmt.Sprintf("mqrestadmin command error (HTTP %d): %v", e.StatusCode, e.Payload)
 }
 
+// Reason codes that commands report when nothing matches, for use with
+// HasReasonCode.
+const (
+	// ReasonUnknownObjectName is MQRC_UNKNOWN_OBJECT_NAME: no object
+	// matches the name given.
+	ReasonUnknownObjectName = 2085
+	// ReasonNoSubscription is MQRC_NO_SUBSCRIPTION: no subscription
+	// matches the name or topic given.
+	ReasonNoSubscription = 2428
+	// ReasonChannelStatusNotFound is MQRCCF_CHL_STATUS_NOT_FOUND: no
+	// matching channel has status.
+	ReasonChannelStatusNotFound = 3065
+	// ReasonChlauthNotFound is MQRCCF_CHLAUTH_NOT_FOUND: no channel
+	// authentication record matches.
+	ReasonChlauthNotFound = 3346
+)
+
+// HasReasonCode reports whether err is a CommandError whose failing
+// commandResponse items all carry one of reasonCodes. A response without
+// failing items is judged by its overall reason code instead.
+func HasReasonCode(err error, reasonCodes ...int) bool {
+	var cmdErr *CommandError
+	if !errors.As(err, &cmdErr) {
+		return false
+	}
+	items, _ := cmdErr.Payload["commandResponse"].([]any)
+	failing := 0
+	for _, item := range items {
+		itemMap, _ := item.(map[string]any)
+		if !hasErrorCodes(itemMap["completionCode"], itemMap["reasonCode"]) {
+			continue
+		}
+		failing++
+		if !slices.Contains(reasonCodes, intValue(itemMap["reasonCode"])) {
+			return false
+		}
+	}
+	return failing > 0 || slices.Contains(reasonCodes, intValue(cmdErr.Payload["overallReasonCode"]))
+}
+
+// QsgCommandError indicates a command failed on one or more queue sharing
+// group members. Members holds every member's outcome, so the members the
+// command succeeded on can be told apart from those it failed on. It
+// unwraps to a CommandError for the whole response.
+type QsgCommandError struct {
+	Payload    map[string]any
+	StatusCode int
+	Members    []QsgMemberResult
+}
+
+func (e *QsgCommandError) Error() string {
+	failed := make([]string, 0, len(e.Members))
+	for _, member := range e.Failed() {
+		failed = append(failed, fmt.Sprintf("%s (CC=%d RC=%d)", member.QmgrName, member.CompletionCode, member.ReasonCode))
+	}
+	return fmt.Sprintf("mqrestadmin command error (HTTP %d): failed on %d of %d queue managers: %s",
+		e.StatusCode, len(failed), len(e.Members), strings.Join(failed, ", "))
+}
+
+func (e *QsgCommandError) Unwrap() error {
+	return &CommandError{Payload: e.Payload, StatusCode: e.StatusCode}
+}
+
+// Succeeded returns the members the command completed on.
+func (e *QsgCommandError) Succeeded() []QsgMemberResult {
+	return slices.DeleteFunc(slices.Clone(e.Members), func(member QsgMemberResult) bool { return !member.Succeeded() })
+}
+
+// Failed returns the members the command failed on.
+func (e *QsgCommandError) Failed() []QsgMemberResult {
+	return slices.DeleteFunc(slices.Clone(e.Members), QsgMemberResult.Succeeded)
+}
+
+// MessagingError indicates the messaging REST API rejected a put, get,
+// browse, or publish request. Queue is set for queue requests and Topic,
+// the topic string, for publishes. CompletionCode, ReasonCode and Message
+// are taken from the response's error body when it has one.
+type MessagingError struct {
+	Queue          string
+	Topic          string
+	StatusCode     int
+	CompletionCode int
+	ReasonCode     int
+	Message        string
+	ResponseText   string
+}
+
+func (e *MessagingError) Error() string {
+	destination := "queue " + e.Queue
+	if e.Topic != "" {
+		destination = "topic " + e.Topic
+	}
+	if e.Message == "" {
+		return fmt.Sprintf("mqrestadmin messaging error (HTTP %d) for %s: %s", e.StatusCode, destination, e.ResponseText)
+	}
+	return fmt.Sprintf("mqrestadmin messaging error (HTTP %d, RC=%d) for %s: %s",
+		e.StatusCode, e.ReasonCode, destination, e.Message)
+}
+
+// UnsupportedError indicates a command, or one of its attributes, that the
+// session's queue manager platform does not support. It is returned before
+// the command is sent, once Session.Capabilities has detected the platform.
+type UnsupportedError struct {
+	// Command is the MQSC command and qualifier, such as "DISPLAY USAGE".
+	Command string
+	// Attribute is the unsupported MQSC attribute, or empty when the
+	// command itself is unsupported.
+	Attribute string
+	// Platform is the queue manager's MQSC PLATFORM value.
+	Platform string
+	// Requires describes the platform or command level the command or
+	// attribute needs.
+	Requires string
+}
+
+func (e *UnsupportedError) Error() string {
+	subject := e.Command
+	if e.Attribute != "" {
+		subject = e.Attribute + " on " + e.Command
+	}
+	return fmt.Sprintf("mqrestadmin unsupported: %s requires %s (queue manager platform %s)",
+		subject, e.Requires, e.Platform)
+}
+
 // TimeoutError indicates a synchronous polling operation exceeded its
 // configured timeout.
 type TimeoutError struct {
@@ -65,6 +191,17 @@ func (e *TimeoutError) Error() string {
 	return fmt.Sprintf("mqrestadmin timeout: %s %s after %.1fs", e.Operation, e.Name, e.ElapsedSeconds)
 }
 
+// MQSCSyntaxError indicates an MQSC script command that could not be parsed.
+type MQSCSyntaxError struct {
+	Line   int
+	Text   string
+	Reason string
+}
+
+func (e *MQSCSyntaxError) Error() string {
+	return fmt.Sprintf("mqrestadmin MQSC syntax error at line %d: %s: %s", e.Line, e.Reason, e.Text)
+}
+
 // MappingError indicates one or more attribute translation failures in strict
 // mode.
 type MappingError struct {
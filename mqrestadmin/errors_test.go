@@ -70,6 +70,42 @@ func TestCommandError_Error(t *testing.T) {
 	}
 }
 
+func TestHasReasonCode(t *testing.T) {
+	item := func(reasonCode int) map[string]any {
+		return map[string]any{"completionCode": float64(2), "reasonCode": float64(reasonCode)}
+	}
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"matching item", &CommandError{Payload: map[string]any{
+			"overallReasonCode": float64(3008), "commandResponse": []any{item(2085)},
+		}}, true},
+		{"other item", &CommandError{Payload: map[string]any{
+			"overallReasonCode": float64(3008), "commandResponse": []any{item(2035)},
+		}}, false},
+		{"one of several items", &CommandError{Payload: map[string]any{
+			"commandResponse": []any{item(2085), item(2035)},
+		}}, false},
+		{"succeeded items skipped", &CommandError{Payload: map[string]any{
+			"commandResponse": []any{map[string]any{"completionCode": float64(0), "reasonCode": float64(0)}, item(2085)},
+		}}, true},
+		{"overall code", &CommandError{Payload: map[string]any{"overallReasonCode": float64(2085)}}, true},
+		{"other overall code", &CommandError{Payload: map[string]any{"overallReasonCode": float64(3008)}}, false},
+		{"qsg member", &QsgCommandError{Payload: map[string]any{
+			"commandResponse": []any{map[string]any{"qmgrName": "QM2", "completionCode": float64(2), "reasonCode": float64(2085)}},
+		}}, true},
+		{"not a command error", errors.New("connection refused"), false},
+		{"nil", nil, false},
+	}
+	for _, tt := range tests {
+		if got := HasReasonCode(tt.err, ReasonUnknownObjectName); got != tt.want {
+			t.Errorf("%s: HasReasonCode() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
 func TestTimeoutError_Error(t *testing.T) {
 	err := &TimeoutError{
 		Name:           "TO.REMOTE",
@@ -86,6 +122,18 @@ func TestTimeoutError_Error(t *testing.T) {
 	}
 }
 
+func TestMQSCSyntaxError_Error(t *testing.T) {
+	err := &MQSCSyntaxError{Line: 12, Text: "DEFINE QLOCAL(", Reason: "unbalanced parentheses or quotes in QLOCAL"}
+
+	msg := err.Error()
+	if !strings.Contains(msg, "line 12") {
+		t.Errorf("error message should contain line number: %s", msg)
+	}
+	if !strings.Contains(msg, "DEFINE QLOCAL(") {
+		t.Errorf("error message should contain command text: %s", msg)
+	}
+}
+
 func TestMappingError_Error(t *testing.T) {
 	err := &MappingError{
 		Issues: []MappingIssue{
@@ -209,6 +257,12 @@ func TestSyncOperation_String(t *testing.T) {
 	if SyncRestarted.String() != "restarted" {
 		t.Errorf("SyncRestarted.String() = %q", SyncRestarted.String())
 	}
+	if SyncPublished.String() != "published" {
+		t.Errorf("SyncPublished.String() = %q", SyncPublished.String())
+	}
+	if SyncTransferred.String() != "transferred" {
+		t.Errorf("SyncTransferred.String() = %q", SyncTransferred.String())
+	}
 	if SyncOperation(99).String() != "unknown" {
 		t.Errorf("SyncOperation(99).String() = %q, want unknown", SyncOperation(99).String())
 	}
@@ -0,0 +1,431 @@
+package mqrestadmin
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// ExportOptions configures ExportConfig.
+type ExportOptions struct {
+	// IncludeSystemObjects includes objects, channel authentication records,
+	// and authority records whose names start with SYSTEM.
+	IncludeSystemObjects bool
+	// OmitDefaults drops attributes whose values match the queue manager's
+	// default object of the same type, such as SYSTEM.DEFAULT.LOCAL.QUEUE
+	// for local queues or SYSTEM.DEF.SVRCONN for server-connection channels.
+	OmitDefaults bool
+}
+
+// exportObjectType describes how one DISPLAY qualifier is exported as
+// DEFINE commands.
+type exportObjectType struct {
+	qualifier string
+	// kindKey is the attribute that selects the DEFINE keyword or the
+	// default object, e.g. TYPE for queues.
+	kindKey string
+	// defineKind uses the kindKey value as the DEFINE keyword (QLOCAL,
+	// QREMOTE, ...) instead of the qualifier.
+	defineKind bool
+	// leadingKey is emitted first because DEFINE requires it, e.g. CHLTYPE.
+	leadingKey string
+	// kinds lists the exported kindKey values and their default objects. A
+	// nil map exports every object against defaultObject.
+	kinds         map[string]string
+	defaultObject string
+	readOnly      []string
+}
+
+// exportAlwaysOmitted lists display-only attributes common to all object
+// types.
+var exportAlwaysOmitted = []string{"ALTDATE", "ALTTIME", "CRDATE", "CRTIME"}
+
+// exportObjectTypes lists the exported object types in output order.
+var exportObjectTypes = []exportObjectType{
+	{
+		qualifier: "AUTHINFO",
+		kindKey:   "AUTHTYPE", leadingKey: "AUTHTYPE",
+		kinds: map[string]string{
+			"CRLLDAP":  "SYSTEM.DEFAULT.AUTHINFO.CRLLDAP",
+			"IDPWLDAP": "SYSTEM.DEFAULT.AUTHINFO.IDPWLDAP",
+			"IDPWOS":   "SYSTEM.DEFAULT.AUTHINFO.IDPWOS",
+			"OCSP":     "SYSTEM.DEFAULT.AUTHINFO.OCSP",
+		},
+	},
+	{
+		qualifier: "QUEUE",
+		kindKey:   "TYPE", defineKind: true,
+		kinds: map[string]string{
+			"QALIAS":  "SYSTEM.DEFAULT.ALIAS.QUEUE",
+			"QLOCAL":  "SYSTEM.DEFAULT.LOCAL.QUEUE",
+			"QMODEL":  "SYSTEM.DEFAULT.MODEL.QUEUE",
+			"QREMOTE": "SYSTEM.DEFAULT.REMOTE.QUEUE",
+		},
+		readOnly: []string{
+			"CLUSDATE", "CLUSQMGR", "CLUSQT", "CLUSTIME", "CURDEPTH",
+			"IPPROCS", "OPPROCS", "QMID",
+		},
+	},
+	{qualifier: "NAMELIST", defaultObject: "SYSTEM.DEFAULT.NAMELIST", readOnly: []string{"NAMCOUNT"}},
+	{qualifier: "PROCESS", defaultObject: "SYSTEM.DEFAULT.PROCESS"},
+	{
+		qualifier: "CHANNEL",
+		kindKey:   "CHLTYPE", leadingKey: "CHLTYPE",
+		kinds: map[string]string{
+			"AMQP":     "SYSTEM.DEF.AMQP",
+			"CLNTCONN": "SYSTEM.DEF.CLNTCONN",
+			"CLUSRCVR": "SYSTEM.DEF.CLUSRCVR",
+			"CLUSSDR":  "SYSTEM.DEF.CLUSSDR",
+			"RCVR":     "SYSTEM.DEF.RECEIVER",
+			"RQSTR":    "SYSTEM.DEF.REQUESTER",
+			"SDR":      "SYSTEM.DEF.SENDER",
+			"SVR":      "SYSTEM.DEF.SERVER",
+			"SVRCONN":  "SYSTEM.DEF.SVRCONN",
+		},
+	},
+	{
+		qualifier: "TOPIC",
+		kindKey:   "TYPE",
+		kinds:     map[string]string{"LOCAL": "SYSTEM.DEFAULT.TOPIC"},
+		readOnly:  []string{"CLUSDATE", "CLUSQMGR", "CLUSTIME", "QMID"},
+	},
+	{
+		qualifier: "SUB",
+		kindKey:   "SUBTYPE",
+		kinds:     map[string]string{"ADMIN": "SYSTEM.DEFAULT.SUB"},
+		readOnly:  []string{"SUBID"},
+	},
+	{
+		qualifier: "LISTENER",
+		kindKey:   "TRPTYPE", leadingKey: "TRPTYPE",
+		kinds: map[string]string{
+			"LU62":    "SYSTEM.DEFAULT.LISTENER.LU62",
+			"NETBIOS": "SYSTEM.DEFAULT.LISTENER.NETBIOS",
+			"SPX":     "SYSTEM.DEFAULT.LISTENER.SPX",
+			"TCP":     "SYSTEM.DEFAULT.LISTENER.TCP",
+		},
+	},
+	{qualifier: "SERVICE", defaultObject: "SYSTEM.DEFAULT.SERVICE"},
+	{qualifier: "COMMINFO", defaultObject: "SYSTEM.DEFAULT.COMMINFO.MULTICAST"},
+}
+
+// exportQmgrOmitted lists the display-only DISPLAY QMGR attributes, which
+// ALTER QMGR rejects.
+var exportQmgrOmitted = []string{
+	"ADVCAP", "AMQPCAP", "CMDLEVEL", "COMMANDQ", "CPILEVEL", "DISTL",
+	"MAXPRTY", "PLATFORM", "QMID", "QMNAME", "QSGNAME", "SPLCAP", "SYNCPT",
+	"VERSION",
+}
+
+// ExportConfig reads the queue manager's configuration and renders it as a
+// re-runnable MQSC script, similar to dmpmqcfg: ALTER QMGR, then DEFINE ...
+// REPLACE commands for authentication information objects, queues,
+// namelists, processes, channels, topics, administrative subscriptions,
+// listeners, services, and communication information objects, then SET
+// CHLAUTH and SET AUTHREC commands.
+//
+// Output is deterministic: sections appear in a fixed order, commands are
+// sorted within each section, and attributes are sorted by name after any
+// attribute that DEFINE requires first (such as CHLTYPE). Cluster queues and
+// topics, and non-administrative subscriptions, are not exported because
+// they are not defined on this queue manager. The result can be read back
+// with ParseMQSCScript or ApplyMQSCScript.
+func ExportConfig(ctx context.Context, session *Session, opts ExportOptions) (string, error) {
+	var sections [][]string
+
+	qmgrRows, err := session.displayUnmapped(ctx, "QMGR", nil)
+	if err != nil {
+		return "", err
+	}
+	var qmgrCommands []string
+	for _, row := range qmgrRows {
+		omitted := append(slices.Clone(exportAlwaysOmitted), exportQmgrOmitted...)
+		qmgrCommands = append(qmgrCommands, renderMQSCCommand("ALTER QMGR", exportAttributes(row, omitted, "", nil), ""))
+	}
+	sections = append(sections, qmgrCommands)
+
+	for _, objectType := range exportObjectTypes {
+		commands, err := exportObjects(ctx, session, objectType, opts)
+		if err != nil {
+			return "", err
+		}
+		sections = append(sections, commands)
+	}
+
+	chlauthCommands, err := exportChannelAuthRecords(ctx, session, opts)
+	if err != nil {
+		return "", err
+	}
+	sections = append(sections, chlauthCommands)
+
+	authrecCommands, err := exportAuthorityRecords(ctx, session, opts)
+	if err != nil {
+		return "", err
+	}
+	sections = append(sections, authrecCommands)
+
+	var builder strings.Builder
+	builder.WriteString("* MQSC configuration exported by mqrestadmin from queue manager " + session.qmgrName + "\n")
+	for _, commands := range sections {
+		for _, command := range commands {
+			builder.WriteString("\n" + command + "\n")
+		}
+	}
+	return builder.String(), nil
+}
+
+func exportObjects(ctx context.Context, session *Session, objectType exportObjectType,
+	opts ExportOptions,
+) ([]string, error) {
+	wildcard := "*"
+	rows, err := session.displayUnmapped(ctx, objectType.qualifier, &wildcard)
+	if err != nil {
+		return nil, err
+	}
+
+	nameKey := objectType.qualifier
+	byName := make(map[string]map[string]any, len(rows))
+	for _, row := range rows {
+		byName[stringAttribute(row, nameKey)] = row
+	}
+
+	omitted := append(slices.Clone(exportAlwaysOmitted), objectType.readOnly...)
+	omitted = append(omitted, nameKey)
+	if objectType.kindKey != "" && objectType.kindKey != objectType.leadingKey {
+		omitted = append(omitted, objectType.kindKey)
+	}
+
+	var commands []string
+	for _, row := range rows {
+		name := stringAttribute(row, nameKey)
+		if !opts.IncludeSystemObjects && strings.HasPrefix(name, "SYSTEM.") {
+			continue
+		}
+
+		kind := stringAttribute(row, objectType.kindKey)
+		defaultObject := objectType.defaultObject
+		if objectType.kinds != nil {
+			var exported bool
+			defaultObject, exported = objectType.kinds[kind]
+			if !exported {
+				continue
+			}
+		}
+
+		keyword := objectType.qualifier
+		if objectType.defineKind {
+			keyword = kind
+		}
+
+		rowOmitted := omitted
+		if keyword != "QMODEL" {
+			// DEFTYPE is only settable on model queues.
+			rowOmitted = append(slices.Clone(omitted), "DEFTYPE")
+		}
+
+		var defaults map[string]any
+		if opts.OmitDefaults && name != defaultObject {
+			defaults = byName[defaultObject]
+		}
+
+		header := "DEFINE " + keyword + "(" + quoteMQSCString(name) + ")"
+		attributes := exportAttributes(row, rowOmitted, objectType.leadingKey, defaults)
+		commands = append(commands, renderMQSCCommand(header, attributes, "REPLACE"))
+	}
+	slices.Sort(commands)
+	return commands, nil
+}
+
+func exportChannelAuthRecords(ctx context.Context, session *Session, opts ExportOptions) ([]string, error) {
+	wildcard := "*"
+	rows, err := session.displayUnmapped(ctx, "CHLAUTH", &wildcard)
+	if err != nil {
+		// A queue manager without any records reports an error rather than
+		// an empty result.
+		if HasReasonCode(err, ReasonChlauthNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	omitted := append(slices.Clone(exportAlwaysOmitted), "CHLAUTH")
+	var commands []string
+	for _, row := range rows {
+		name := stringAttribute(row, "CHLAUTH")
+		if !opts.IncludeSystemObjects && strings.HasPrefix(name, "SYSTEM.") {
+			continue
+		}
+		header := "SET CHLAUTH(" + quoteMQSCString(name) + ")"
+		commands = append(commands, renderMQSCCommand(header, exportAttributes(row, omitted, "TYPE", nil), "ACTION(REPLACE)"))
+	}
+	slices.Sort(commands)
+	return commands, nil
+}
+
+func exportAuthorityRecords(ctx context.Context, session *Session, opts ExportOptions) ([]string, error) {
+	rows, err := session.displayUnmapped(ctx, "AUTHREC", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var commands []string
+	for _, row := range rows {
+		profile := stringAttribute(row, "PROFILE")
+		if !opts.IncludeSystemObjects && strings.HasPrefix(profile, "SYSTEM.") {
+			continue
+		}
+		authorities := listAttribute(row["AUTHLIST"])
+		if len(authorities) == 0 || slices.Contains(authorities, "NONE") {
+			continue
+		}
+
+		objectType := stringAttribute(row, "OBJTYPE")
+		header := "SET AUTHREC"
+		var attributes []string
+		if objectType != "QMGR" {
+			attributes = append(attributes, "PROFILE("+quoteMQSCString(profile)+")")
+		}
+		attributes = append(attributes, "OBJTYPE("+objectType+")")
+
+		entityKeyword := "PRINCIPAL"
+		if stringAttribute(row, "ENTTYPE") == "GROUP" {
+			entityKeyword = "GROUP"
+		}
+		attributes = append(attributes, entityKeyword+"("+quoteMQSCString(stringAttribute(row, "ENTITY"))+")")
+		attributes = append(attributes, "AUTHADD("+strings.Join(authorities, ",")+")")
+
+		commands = append(commands, renderMQSCCommand(header, attributes, ""))
+	}
+	slices.Sort(commands)
+	return commands, nil
+}
+
+// displayUnmapped runs a DISPLAY command for all attributes without
+// attribute mapping or value conversion, returning rows keyed by upper-case
+// MQSC names.
+func (session *Session) displayUnmapped(ctx context.Context, qualifier string, name *string) ([]map[string]any, error) {
+	payload := session.buildCommandPayload("DISPLAY", qualifier, name, nil, []string{"all"})
+	session.LastCommandPayload = payload
+
+	objects, _, err := session.executeAndParseResponse(ctx, payload, "")
+	if err != nil {
+		return nil, err
+	}
+
+	rows := make([]map[string]any, len(objects))
+	for index, object := range objects {
+		row := make(map[string]any, len(object))
+		for key, value := range object {
+			row[strings.ToUpper(key)] = value
+		}
+		rows[index] = row
+	}
+	return rows, nil
+}
+
+// exportAttributes renders a row's attributes as KEY(VALUE) strings, leading
+// key first and the rest sorted by name. Attributes in omitted, attributes
+// whose value matches defaults, and empty lists are dropped.
+func exportAttributes(row map[string]any, omitted []string, leadingKey string,
+	defaults map[string]any,
+) []string {
+	var attributes []string
+	if value, exists := row[leadingKey]; exists && leadingKey != "" {
+		attributes = append(attributes, leadingKey+"("+formatMQSCValue(value)+")")
+	}
+
+	for _, key := range slices.Sorted(maps.Keys(row)) {
+		if key == leadingKey || slices.Contains(omitted, key) {
+			continue
+		}
+		formatted := formatMQSCValue(row[key])
+		if formatted == "" {
+			continue
+		}
+		if defaultValue, exists := defaults[key]; exists && formatMQSCValue(defaultValue) == formatted {
+			continue
+		}
+		attributes = append(attributes, key+"("+formatted+")")
+	}
+	return attributes
+}
+
+// renderMQSCCommand lays out a command with one attribute per continuation
+// line, as dmpmqcfg does.
+func renderMQSCCommand(header string, attributes []string, trailer string) string {
+	lines := []string{header}
+	for _, attribute := range attributes {
+		lines = append(lines, "   "+attribute)
+	}
+	if trailer != "" {
+		lines = append(lines, "   "+trailer)
+	}
+	return strings.Join(lines, " +\n")
+}
+
+// formatMQSCValue renders a response value in MQSC syntax. Numbers and
+// values that runmqsc would read back unchanged without quotes are left
+// bare; other strings are quoted. Lists are comma-separated, and an empty
+// list renders as an empty string.
+func formatMQSCValue(value any) string {
+	switch typed := value.(type) {
+	case string:
+		if isBareMQSCToken(typed) {
+			return typed
+		}
+		return quoteMQSCString(typed)
+	case []any:
+		items := make([]string, 0, len(typed))
+		for _, item := range typed {
+			items = append(items, formatMQSCValue(item))
+		}
+		return strings.Join(items, ",")
+	default:
+		if text, isNumber := FormatNumber(value); isNumber {
+			return text
+		}
+		return quoteMQSCString(fmt.Sprint(value))
+	}
+}
+
+// isBareMQSCToken reports whether text can be written without quotes: a
+// non-empty run of upper-case letters, digits, and underscores.
+func isBareMQSCToken(text string) bool {
+	if text == "" {
+		return false
+	}
+	for index := range len(text) {
+		character := text[index]
+		if !(character >= 'A' && character <= 'Z') && !(character >= '0' && character <= '9') && character != '_' {
+			return false
+		}
+	}
+	return true
+}
+
+// quoteMQSCString quotes text for MQSC, doubling embedded quotes. A blank
+// value is written as a single quoted space, as dmpmqcfg does.
+func quoteMQSCString(text string) string {
+	if text == "" {
+		text = " "
+	}
+	return "'" + strings.ReplaceAll(text, "'", "''") + "'"
+}
+
+func stringAttribute(row map[string]any, key string) string {
+	text, _ := row[key].(string)
+	return strings.TrimSpace(text)
+}
+
+func listAttribute(value any) []string {
+	items, _ := value.([]any)
+	var values []string
+	for _, item := range items {
+		if text, isString := item.(string); isString {
+			values = append(values, strings.TrimSpace(text))
+		}
+	}
+	return values
+}
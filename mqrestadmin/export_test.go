@@ -0,0 +1,327 @@
+package mqrestadmin
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// addExportResponses queues one DISPLAY response per ExportConfig call, in
+// the order ExportConfig issues them: QMGR, the object types, CHLAUTH, then
+// AUTHREC.
+func addExportResponses(transport *mockTransport, rows map[string][]map[string]any) {
+	qualifiers := []string{"QMGR"}
+	for _, objectType := range exportObjectTypes {
+		qualifiers = append(qualifiers, objectType.qualifier)
+	}
+	qualifiers = append(qualifiers, "CHLAUTH", "AUTHREC")
+	for _, qualifier := range qualifiers {
+		transport.addSuccessResponse(rows[qualifier]...)
+	}
+}
+
+func exportTestRows() map[string][]map[string]any {
+	return map[string][]map[string]any{
+		"QMGR": {{
+			"qmname": "QM1", "qmid": "QM1_2026", "cmdlevel": float64(940), "version": "09040000",
+			"advcap": "ENABLED", "amqpcap": "NO", "splcap": "DISABLED",
+			"descr": "Test qmgr", "maxmsgl": float64(4194304), "chlauth": "ENABLED",
+		}},
+		"QUEUE": {
+			{
+				"queue": "APP.Q", "type": "QLOCAL", "descr": "It's orders", "maxdepth": float64(5000),
+				"curdepth": float64(12), "defpsist": "YES", "deftype": "PREDEFINED",
+				"altdate": "2026-01-01", "alttime": "10.00.00", "custom": "",
+			},
+			{"queue": "APP.ALIAS", "type": "QALIAS", "target": "APP.Q", "descr": ""},
+			{"queue": "APP.MODEL", "type": "QMODEL", "deftype": "PERMDYN", "maxdepth": float64(5000)},
+			{"queue": "CLUS.Q", "type": "QCLUSTER", "clusqmgr": "QM2"},
+			{"queue": "SYSTEM.DEFAULT.LOCAL.QUEUE", "type": "QLOCAL", "maxdepth": float64(5000), "defpsist": "NO"},
+		},
+		"CHANNEL": {
+			{"channel": "APP.SVRCONN", "chltype": "SVRCONN", "maxmsgl": float64(4194304), "sslciph": ""},
+			{"channel": "SYSTEM.DEF.SVRCONN", "chltype": "SVRCONN", "maxmsgl": float64(4194304)},
+		},
+		"TOPIC": {
+			{"topic": "APP.TOPIC", "type": "LOCAL", "topicstr": "app/events", "clroute": []any{}},
+			{"topic": "CLUS.TOPIC", "type": "CLUSTER", "topicstr": "clus"},
+		},
+		"SUB": {
+			{"sub": "APP.SUB", "subtype": "ADMIN", "subid": "414D51", "topicstr": "app/events", "dest": "APP.Q"},
+			{"sub": "API.SUB", "subtype": "API", "subid": "414D52"},
+		},
+		"NAMELIST": {
+			{"namelist": "APP.NL", "names": []any{"CLUS1", "CLUS2"}, "namcount": float64(2)},
+		},
+		"CHLAUTH": {
+			{"chlauth": "APP.SVRCONN", "type": "ADDRESSMAP", "address": "*", "usersrc": "CHANNEL"},
+			{"chlauth": "SYSTEM.*", "type": "ADDRESSMAP", "address": "*", "usersrc": "NOACCESS"},
+		},
+		"AUTHREC": {
+			{"profile": "APP.Q", "objtype": "QUEUE", "entity": "app", "enttype": "PRINCIPAL", "authlist": []any{"GET", "PUT"}},
+			{"profile": "self", "objtype": "QMGR", "entity": "apps", "enttype": "GROUP", "authlist": []any{"CONNECT", "INQ"}},
+			{"profile": "APP.NONE", "objtype": "QUEUE", "entity": "none", "enttype": "PRINCIPAL", "authlist": []any{"NONE"}},
+			{"profile": "SYSTEM.ADMIN.COMMAND.QUEUE", "objtype": "QUEUE", "entity": "mqm", "enttype": "GROUP", "authlist": []any{"PUT"}},
+		},
+	}
+}
+
+func TestExportConfig(t *testing.T) {
+	transport := newMockTransport()
+	addExportResponses(transport, exportTestRows())
+	session := newTestSessionWithMapping(transport)
+
+	script, err := ExportConfig(context.Background(), session, ExportOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := `* MQSC configuration exported by mqrestadmin from queue manager QM1
+
+ALTER QMGR +
+   CHLAUTH(ENABLED) +
+   DESCR('Test qmgr') +
+   MAXMSGL(4194304)
+
+DEFINE QALIAS('APP.ALIAS') +
+   DESCR(' ') +
+   TARGET('APP.Q') +
+   REPLACE
+
+DEFINE QLOCAL('APP.Q') +
+   CUSTOM(' ') +
+   DEFPSIST(YES) +
+   DESCR('It''s orders') +
+   MAXDEPTH(5000) +
+   REPLACE
+
+DEFINE QMODEL('APP.MODEL') +
+   DEFTYPE(PERMDYN) +
+   MAXDEPTH(5000) +
+   REPLACE
+
+DEFINE NAMELIST('APP.NL') +
+   NAMES(CLUS1,CLUS2) +
+   REPLACE
+
+DEFINE CHANNEL('APP.SVRCONN') +
+   CHLTYPE(SVRCONN) +
+   MAXMSGL(4194304) +
+   SSLCIPH(' ') +
+   REPLACE
+
+DEFINE TOPIC('APP.TOPIC') +
+   TOPICSTR('app/events') +
+   REPLACE
+
+DEFINE SUB('APP.SUB') +
+   DEST('APP.Q') +
+   TOPICSTR('app/events') +
+   REPLACE
+
+SET CHLAUTH('APP.SVRCONN') +
+   TYPE(ADDRESSMAP) +
+   ADDRESS('*') +
+   USERSRC(CHANNEL) +
+   ACTION(REPLACE)
+
+SET AUTHREC +
+   OBJTYPE(QMGR) +
+   GROUP('apps') +
+   AUTHADD(CONNECT,INQ)
+
+SET AUTHREC +
+   PROFILE('APP.Q') +
+   OBJTYPE(QUEUE) +
+   PRINCIPAL('app') +
+   AUTHADD(GET,PUT)
+`
+	if script != want {
+		t.Errorf("script =\n%s\nwant\n%s", script, want)
+	}
+
+	if transport.callCount() != 13 {
+		t.Fatalf("callCount = %d, want 13", transport.callCount())
+	}
+	first := transport.calls[0].Payload
+	if first["qualifier"] != "QMGR" || !reflect.DeepEqual(first["responseParameters"], []string{"all"}) {
+		t.Errorf("first payload = %v, want DISPLAY QMGR ALL", first)
+	}
+	if _, hasName := transport.calls[12].Payload["name"]; hasName {
+		t.Errorf("AUTHREC payload = %v, want no name", transport.calls[12].Payload)
+	}
+	if transport.calls[2].Payload["name"] != "*" {
+		t.Errorf("QUEUE payload = %v, want generic name", transport.calls[2].Payload)
+	}
+}
+
+func TestExportConfig_RoundTripsThroughParser(t *testing.T) {
+	transport := newMockTransport()
+	addExportResponses(transport, exportTestRows())
+	session := newTestSession(transport)
+
+	script, err := ExportConfig(context.Background(), session, ExportOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	commands, err := ParseMQSCScript(strings.NewReader(script))
+	if err != nil {
+		t.Fatalf("ParseMQSCScript error: %v", err)
+	}
+	if len(commands) != 11 {
+		t.Fatalf("len(commands) = %d, want 11", len(commands))
+	}
+
+	local := commands[2]
+	if local.Verb != "DEFINE" || local.Qualifier != "QLOCAL" || local.Name != "APP.Q" {
+		t.Errorf("command = %+v, want DEFINE QLOCAL(APP.Q)", local)
+	}
+	wantParams := map[string]any{
+		"CUSTOM":   " ",
+		"DEFPSIST": "YES",
+		"DESCR":    "It's orders",
+		"MAXDEPTH": "5000",
+		"REPLACE":  "YES",
+	}
+	if !reflect.DeepEqual(local.Parameters, wantParams) {
+		t.Errorf("Parameters = %#v, want %#v", local.Parameters, wantParams)
+	}
+
+	namelist := commands[4]
+	if names := namelist.Parameters["NAMES"]; !reflect.DeepEqual(names, []string{"CLUS1", "CLUS2"}) {
+		t.Errorf("NAMES = %#v, want two-item list", names)
+	}
+
+	authrec := commands[10]
+	if authrec.Verb != "SET" || authrec.Qualifier != "AUTHREC" || authrec.Parameters["PRINCIPAL"] != "app" {
+		t.Errorf("command = %+v, want SET AUTHREC for principal app", authrec)
+	}
+}
+
+func TestExportConfig_IncludeSystemObjectsAndOmitDefaults(t *testing.T) {
+	transport := newMockTransport()
+	addExportResponses(transport, exportTestRows())
+	session := newTestSession(transport)
+
+	script, err := ExportConfig(context.Background(), session,
+		ExportOptions{IncludeSystemObjects: true, OmitDefaults: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	wantFragments := []string{
+		"DEFINE QLOCAL('APP.Q') +\n   CUSTOM(' ') +\n   DEFPSIST(YES) +\n   DESCR('It''s orders') +\n   REPLACE",
+		"DEFINE QLOCAL('SYSTEM.DEFAULT.LOCAL.QUEUE') +\n   DEFPSIST(NO) +\n   MAXDEPTH(5000) +\n   REPLACE",
+		"DEFINE CHANNEL('APP.SVRCONN') +\n   CHLTYPE(SVRCONN) +\n   SSLCIPH(' ') +\n   REPLACE",
+		"SET CHLAUTH('SYSTEM.*')",
+		"PROFILE('SYSTEM.ADMIN.COMMAND.QUEUE')",
+	}
+	for _, fragment := range wantFragments {
+		if !strings.Contains(script, fragment) {
+			t.Errorf("script missing %q:\n%s", fragment, script)
+		}
+	}
+}
+
+func TestExportConfig_ChlauthNotFoundIsEmpty(t *testing.T) {
+	transport := newMockTransport()
+	rows := exportTestRows()
+	transport.addSuccessResponse(rows["QMGR"]...)
+	for _, objectType := range exportObjectTypes {
+		transport.addSuccessResponse(rows[objectType.qualifier]...)
+	}
+	transport.addItemErrorResponse(3346) // MQRCCF_CHLAUTH_NOT_FOUND
+	transport.addSuccessResponse()
+	session := newTestSession(transport)
+
+	script, err := ExportConfig(context.Background(), session, ExportOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if strings.Contains(script, "SET CHLAUTH") || strings.Contains(script, "SET AUTHREC") {
+		t.Errorf("script = %s, want no CHLAUTH or AUTHREC commands", script)
+	}
+}
+
+func TestExportConfig_ChlauthCommandError(t *testing.T) {
+	transport := newMockTransport()
+	for range len(exportObjectTypes) + 1 {
+		transport.addSuccessResponse()
+	}
+	transport.addItemErrorResponse(3347) // MQRCCF_WRONG_CHLAUTH_ACTION
+	session := newTestSession(transport)
+
+	script, err := ExportConfig(context.Background(), session, ExportOptions{})
+	var cmdErr *CommandError
+	if !errors.As(err, &cmdErr) || script != "" {
+		t.Errorf("ExportConfig = %q, %v; want the CHLAUTH CommandError", script, err)
+	}
+}
+
+func TestExportConfig_Errors(t *testing.T) {
+	transportErr := errors.New("connection refused")
+	objectTypeCount := len(exportObjectTypes)
+
+	tests := []struct {
+		name          string
+		failingCall   int
+		commandError  bool
+		wantCallCount int
+	}{
+		{"qmgr", 0, false, 1},
+		{"object type", 1, true, 2},
+		{"chlauth", objectTypeCount + 1, false, objectTypeCount + 2},
+		{"authrec", objectTypeCount + 2, true, objectTypeCount + 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			transport := newMockTransport()
+			for range tt.failingCall {
+				transport.addSuccessResponse()
+			}
+			if tt.commandError {
+				transport.addCommandErrorResponse(2, 2035)
+			} else {
+				transport.addErrorResponse(transportErr)
+			}
+			session := newTestSession(transport)
+
+			script, err := ExportConfig(context.Background(), session, ExportOptions{})
+			if err == nil || script != "" {
+				t.Fatalf("ExportConfig = %q, %v; want error", script, err)
+			}
+			if transport.callCount() != tt.wantCallCount {
+				t.Errorf("callCount = %d, want %d", transport.callCount(), tt.wantCallCount)
+			}
+		})
+	}
+}
+
+func TestFormatMQSCValue(t *testing.T) {
+	tests := []struct {
+		value any
+		want  string
+	}{
+		{"YES", "YES"},
+		{"APP.Q", "'APP.Q'"},
+		{"lower", "'lower'"},
+		{"", "' '"},
+		{"O'Brien", "'O''Brien'"},
+		{float64(5000), "5000"},
+		{int64(-1), "-1"},
+		{7, "7"},
+		{json.Number("9007199254740993"), "9007199254740993"},
+		{[]any{"A", float64(1)}, "A,1"},
+		{[]any{}, ""},
+		{true, "'true'"},
+	}
+	for _, tt := range tests {
+		if got := formatMQSCValue(tt.value); got != tt.want {
+			t.Errorf("formatMQSCValue(%#v) = %q, want %q", tt.value, got, tt.want)
+		}
+	}
+}
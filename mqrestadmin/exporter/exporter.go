@@ -0,0 +1,485 @@
+// Package exporter exposes IBM MQ queue, channel, queue manager, listener
+// and service status as Prometheus metrics.
+//
+// An Exporter is a prometheus.Collector that polls every queue manager in
+// a mqrestadmin.Fleet with DISPLAY QMSTATUS, DISPLAY QSTATUS, DISPLAY
+// QUEUE, DISPLAY CHSTATUS, DISPLAY LSSTATUS and DISPLAY SVSTATUS:
+//
+//	registry := prometheus.NewRegistry()
+//	registry.MustRegister(exporter.New(fleet))
+//	http.Handle("/metrics", promhttp.HandlerFor(registry, promhttp.HandlerOpts{}))
+//
+// By default the queue managers are polled each time the exporter is
+// scraped. Run polls them in the background instead, and scrapes are then
+// served from the latest poll, so scrape latency does not depend on the
+// queue managers.
+//
+// Every metric is labelled with the queue manager name in qmgr, and object
+// metrics with the object name in queue, channel, listener or service.
+// Status metrics carry the MQ status, such as RUNNING, in a status label.
+// The exporter reads both snake_case and MQSC attribute names, so it works
+// whether or not the sessions map attributes. Status responses include
+// attributes the mapping data does not cover, such as the listener name
+// in DISPLAY LSSTATUS, so sessions that map attributes should use
+// mqrestadmin.WithMappingStrict(false).
+//
+// The package is a separate module, with its own go.mod, so the core
+// mqrestadmin module does not depend on the Prometheus client.
+package exporter
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/wphillipmoore/mq-rest-admin-go/mqrestadmin"
+)
+
+var (
+	upDesc = prometheus.NewDesc("mq_up",
+		"Whether the last poll of the queue manager succeeded.", []string{"qmgr"}, nil)
+	pollDurationDesc = prometheus.NewDesc("mq_poll_duration_seconds",
+		"Duration of the last poll of the queue manager.", []string{"qmgr"}, nil)
+
+	qmgrStatusDesc = prometheus.NewDesc("mq_qmgr_status",
+		"Queue manager status; 1 for the current status.", []string{"qmgr", "status"}, nil)
+	qmgrConnectionsDesc = prometheus.NewDesc("mq_qmgr_connections",
+		"Connections to the queue manager.", []string{"qmgr"}, nil)
+
+	queueDepthDesc = prometheus.NewDesc("mq_queue_depth",
+		"Messages on the queue.", []string{"qmgr", "queue"}, nil)
+	queueMaxDepthDesc = prometheus.NewDesc("mq_queue_max_depth",
+		"Maximum messages allowed on the queue.", []string{"qmgr", "queue"}, nil)
+	queueOpenInputDesc = prometheus.NewDesc("mq_queue_open_input_handles",
+		"Handles open for input on the queue.", []string{"qmgr", "queue"}, nil)
+	queueOpenOutputDesc = prometheus.NewDesc("mq_queue_open_output_handles",
+		"Handles open for output on the queue.", []string{"qmgr", "queue"}, nil)
+	queueOldestMessageAgeDesc = prometheus.NewDesc("mq_queue_oldest_message_age_seconds",
+		"Age of the oldest message on the queue. Requires queue monitoring.", []string{"qmgr", "queue"}, nil)
+	queueUncommittedDesc = prometheus.NewDesc("mq_queue_uncommitted_messages",
+		"Uncommitted changes pending on the queue.", []string{"qmgr", "queue"}, nil)
+
+	channelStatusDesc = prometheus.NewDesc("mq_channel_status",
+		"Channel instances by status.", []string{"qmgr", "channel", "status"}, nil)
+	channelMessagesDesc = prometheus.NewDesc("mq_channel_messages",
+		"Messages sent or received by the channel's current instances.", []string{"qmgr", "channel"}, nil)
+	channelBytesSentDesc = prometheus.NewDesc("mq_channel_bytes_sent",
+		"Bytes sent by the channel's current instances.", []string{"qmgr", "channel"}, nil)
+	channelBytesReceivedDesc = prometheus.NewDesc("mq_channel_bytes_received",
+		"Bytes received by the channel's current instances.", []string{"qmgr", "channel"}, nil)
+	channelBuffersSentDesc = prometheus.NewDesc("mq_channel_buffers_sent",
+		"Buffers sent by the channel's current instances.", []string{"qmgr", "channel"}, nil)
+	channelBuffersReceivedDesc = prometheus.NewDesc("mq_channel_buffers_received",
+		"Buffers received by the channel's current instances.", []string{"qmgr", "channel"}, nil)
+
+	listenerStatusDesc = prometheus.NewDesc("mq_listener_status",
+		"Listener status; 1 for the current status.", []string{"qmgr", "listener", "status"}, nil)
+	serviceStatusDesc = prometheus.NewDesc("mq_service_status",
+		"Service status; 1 for the current status.", []string{"qmgr", "service", "status"}, nil)
+)
+
+// gauge reads a numeric attribute, by snake_case or MQSC name, into a
+// metric.
+type gauge struct {
+	desc *prometheus.Desc
+	keys []string
+}
+
+var (
+	queueStatusGauges = []gauge{
+		{queueDepthDesc, []string{"current_queue_depth", "CURDEPTH"}},
+		{queueOpenInputDesc, []string{"open_input_count", "IPPROCS"}},
+		{queueOpenOutputDesc, []string{"open_output_count", "OPPROCS"}},
+		{queueOldestMessageAgeDesc, []string{"oldest_message_age", "MSGAGE"}},
+		{queueUncommittedDesc, []string{"uncommitted_messages", "UNCOM"}},
+	}
+	channelGauges = []gauge{
+		{channelMessagesDesc, []string{"messages", "MSGS"}},
+		{channelBytesSentDesc, []string{"bytes_sent", "BYTSSENT"}},
+		{channelBytesReceivedDesc, []string{"bytes_received", "BYTSRCVD"}},
+		{channelBuffersSentDesc, []string{"buffers_sent", "BUFSSENT"}},
+		{channelBuffersReceivedDesc, []string{"buffers_received", "BUFSRCVD"}},
+	}
+)
+
+// Option configures an Exporter.
+type Option func(*Exporter)
+
+// WithQueues sets the queue name pattern to poll. Defaults to "*".
+func WithQueues(pattern string) Option {
+	return func(exporter *Exporter) {
+		exporter.queues = pattern
+	}
+}
+
+// WithChannels sets the channel name pattern to poll. Defaults to "*".
+func WithChannels(pattern string) Option {
+	return func(exporter *Exporter) {
+		exporter.channels = pattern
+	}
+}
+
+// WithPollTimeout limits how long a poll of all queue managers may take.
+// A queue manager whose poll is cut short reports mq_up 0. By default a
+// poll is limited only by the sessions' timeouts.
+func WithPollTimeout(timeout time.Duration) Option {
+	return func(exporter *Exporter) {
+		exporter.pollTimeout = timeout
+	}
+}
+
+// WithLogger sets a logger for failed polls, which are logged at warning
+// level. By default failures are reported only through mq_up.
+func WithLogger(logger *slog.Logger) Option {
+	return func(exporter *Exporter) {
+		exporter.logger = logger
+	}
+}
+
+// Exporter collects queue manager metrics for Prometheus.
+type Exporter struct {
+	fleet       *mqrestadmin.Fleet
+	queues      string
+	channels    string
+	pollTimeout time.Duration
+	logger      *slog.Logger
+
+	// pollMutex serializes polls, so concurrent scrapes do not share a
+	// session.
+	pollMutex sync.Mutex
+
+	cacheMutex sync.Mutex
+	cached     bool
+	metrics    []prometheus.Metric
+}
+
+// New creates an exporter for the queue managers in fleet.
+func New(fleet *mqrestadmin.Fleet, opts ...Option) *Exporter {
+	exporter := &Exporter{fleet: fleet, queues: "*", channels: "*"}
+	for _, opt := range opts {
+		opt(exporter)
+	}
+	return exporter
+}
+
+// Describe sends the descriptors of every metric the exporter collects.
+func (exporter *Exporter) Describe(descs chan<- *prometheus.Desc) {
+	for _, desc := range []*prometheus.Desc{
+		upDesc, pollDurationDesc, qmgrStatusDesc, qmgrConnectionsDesc, queueMaxDepthDesc,
+		channelStatusDesc, listenerStatusDesc, serviceStatusDesc,
+	} {
+		descs <- desc
+	}
+	for _, gauge := range queueStatusGauges {
+		descs <- gauge.desc
+	}
+	for _, gauge := range channelGauges {
+		descs <- gauge.desc
+	}
+}
+
+// Collect sends the metrics of the latest poll while Run is polling, and
+// otherwise polls the queue managers first.
+func (exporter *Exporter) Collect(metrics chan<- prometheus.Metric) {
+	exporter.cacheMutex.Lock()
+	cached, latest := exporter.cached, exporter.metrics
+	exporter.cacheMutex.Unlock()
+	if !cached {
+		latest = exporter.poll(context.Background())
+	}
+	for _, metric := range latest {
+		metrics <- metric
+	}
+}
+
+// Run polls the queue managers immediately and then every interval until
+// ctx is done, and returns ctx's error. While Run is polling, scrapes are
+// served from the latest poll; before the first poll completes they
+// return no metrics.
+func (exporter *Exporter) Run(ctx context.Context, interval time.Duration) error {
+	exporter.cacheMutex.Lock()
+	exporter.cached = true
+	exporter.cacheMutex.Unlock()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		metrics := exporter.poll(ctx)
+		exporter.cacheMutex.Lock()
+		exporter.metrics = metrics
+		exporter.cacheMutex.Unlock()
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// poll polls every queue manager in the fleet and returns their metrics.
+// A queue manager that fails reports only mq_up and
+// mq_poll_duration_seconds.
+func (exporter *Exporter) poll(ctx context.Context) []prometheus.Metric {
+	exporter.pollMutex.Lock()
+	defer exporter.pollMutex.Unlock()
+	if exporter.pollTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, exporter.pollTimeout)
+		defer cancel()
+	}
+
+	results := mqrestadmin.RunFleet(ctx, exporter.fleet, func(ctx context.Context,
+		session *mqrestadmin.Session,
+	) (qmgrPoll, error) {
+		start := time.Now()
+		metrics, err := exporter.pollQmgr(ctx, session)
+		return qmgrPoll{metrics: metrics, duration: time.Since(start)}, err
+	})
+
+	var metrics []prometheus.Metric
+	for _, qmgrName := range exporter.fleet.QmgrNames() {
+		result := results[qmgrName]
+		up := 1.0
+		if result.Err != nil {
+			up = 0
+			if exporter.logger != nil {
+				exporter.logger.Warn("mqrestadmin exporter poll failed", "qmgr", qmgrName, "error", result.Err)
+			}
+		} else {
+			metrics = append(metrics, result.Value.metrics...)
+		}
+		metrics = append(metrics,
+			prometheus.MustNewConstMetric(upDesc, prometheus.GaugeValue, up, qmgrName),
+			prometheus.MustNewConstMetric(pollDurationDesc, prometheus.GaugeValue,
+				result.Value.duration.Seconds(), qmgrName))
+	}
+	return metrics
+}
+
+// qmgrPoll is the outcome of polling one queue manager.
+type qmgrPoll struct {
+	metrics  []prometheus.Metric
+	duration time.Duration
+}
+
+// pollQmgr runs the DISPLAY commands against one queue manager. A command
+// that matches no objects, such as DISPLAY CHSTATUS finding no running
+// channels, yields no rows; any other error, including other command
+// errors such as MQRC_NOT_AUTHORIZED, fails the poll.
+func (exporter *Exporter) pollQmgr(ctx context.Context, session *mqrestadmin.Session) ([]prometheus.Metric, error) {
+	metrics := &qmgrMetrics{qmgrName: session.QmgrName()}
+	for _, collect := range []func(context.Context, *mqrestadmin.Session, *qmgrMetrics) error{
+		collectQmgrStatus, exporter.collectQueueStatus, exporter.collectQueues, exporter.collectChannels,
+		collectListeners, collectServices,
+	} {
+		if err := collect(ctx, session, metrics); err != nil {
+			return nil, err
+		}
+	}
+	return metrics.metrics, nil
+}
+
+// qmgrMetrics accumulates the metrics of one queue manager.
+type qmgrMetrics struct {
+	qmgrName string
+	metrics  []prometheus.Metric
+}
+
+// add adds a gauge labelled with the queue manager name and labels.
+func (metrics *qmgrMetrics) add(desc *prometheus.Desc, value float64, labels ...string) {
+	metrics.metrics = append(metrics.metrics, prometheus.MustNewConstMetric(desc, prometheus.GaugeValue, value,
+		append([]string{metrics.qmgrName}, labels...)...))
+}
+
+// collectQmgrStatus adds the queue manager status and connection count.
+func collectQmgrStatus(ctx context.Context, session *mqrestadmin.Session, metrics *qmgrMetrics) error {
+	status, err := session.DisplayQmstatus(ctx)
+	if err = ignoreNotFound(err); err != nil {
+		return err
+	}
+	if qmgrStatus := text(status, "ha_status", "STATUS"); qmgrStatus != "" {
+		metrics.add(qmgrStatusDesc, 1, qmgrStatus)
+	}
+	if connections, exists := number(status, "connections", "CONNS"); exists {
+		metrics.add(qmgrConnectionsDesc, connections)
+	}
+	return nil
+}
+
+// collectQueueStatus adds the depth, handle, message age and uncommitted
+// message gauges of each queue.
+func (exporter *Exporter) collectQueueStatus(ctx context.Context, session *mqrestadmin.Session,
+	metrics *qmgrMetrics,
+) error {
+	rows, err := session.DisplayQstatus(ctx, exporter.queues)
+	if err = ignoreNotFound(err); err != nil {
+		return err
+	}
+	for _, row := range rows {
+		queue := text(row, "queue_name", "QUEUE")
+		for _, gauge := range queueStatusGauges {
+			if value, exists := number(row, gauge.keys...); exists {
+				metrics.add(gauge.desc, value, queue)
+			}
+		}
+	}
+	return nil
+}
+
+// collectQueues adds the maximum depth of local queues.
+func (exporter *Exporter) collectQueues(ctx context.Context, session *mqrestadmin.Session,
+	metrics *qmgrMetrics,
+) error {
+	rows, err := session.DisplayQueue(ctx, exporter.queues)
+	if err = ignoreNotFound(err); err != nil {
+		return err
+	}
+	for _, row := range rows {
+		if queueType := text(row, "type", "TYPE"); queueType != "QLOCAL" && queueType != "LOCAL" {
+			continue
+		}
+		if maxDepth, exists := number(row, "max_queue_depth", "MAXDEPTH"); exists {
+			metrics.add(queueMaxDepthDesc, maxDepth, text(row, "queue_name", "QUEUE"))
+		}
+	}
+	return nil
+}
+
+// collectChannels adds the status and traffic of each channel, summed
+// across its instances.
+func (exporter *Exporter) collectChannels(ctx context.Context, session *mqrestadmin.Session,
+	metrics *qmgrMetrics,
+) error {
+	rows, err := session.DisplayChstatus(ctx, exporter.channels)
+	if err = ignoreNotFound(err); err != nil {
+		return err
+	}
+	for _, channel := range channelTotals(rows) {
+		for status, count := range channel.statuses {
+			metrics.add(channelStatusDesc, count, channel.name, status)
+		}
+		for index, gauge := range channelGauges {
+			if channel.hasValues[index] {
+				metrics.add(gauge.desc, channel.values[index], channel.name)
+			}
+		}
+	}
+	return nil
+}
+
+// collectListeners adds the status of each listener.
+func collectListeners(ctx context.Context, session *mqrestadmin.Session, metrics *qmgrMetrics) error {
+	rows, err := session.DisplayLsstatus(ctx, "*")
+	return collectStatuses(rows, err, metrics, listenerStatusDesc, "listener_name", "LISTENER")
+}
+
+// collectServices adds the status of each service.
+func collectServices(ctx context.Context, session *mqrestadmin.Session, metrics *qmgrMetrics) error {
+	rows, err := session.DisplaySvstatus(ctx, "*")
+	return collectStatuses(rows, err, metrics, serviceStatusDesc, "service_name", "SERVICE")
+}
+
+// collectStatuses adds a status metric for each object in rows, named by
+// the first of nameKeys.
+func collectStatuses(rows []map[string]any, err error, metrics *qmgrMetrics, desc *prometheus.Desc,
+	nameKeys ...string,
+) error {
+	if err = ignoreNotFound(err); err != nil {
+		return err
+	}
+	for _, row := range rows {
+		if status := text(row, "status", "STATUS"); status != "" {
+			metrics.add(desc, 1, text(row, nameKeys...), status)
+		}
+	}
+	return nil
+}
+
+// channelTotal sums the status rows of a channel's instances.
+type channelTotal struct {
+	name      string
+	statuses  map[string]float64
+	values    [5]float64
+	hasValues [5]bool
+}
+
+// channelTotals groups channel status rows by channel name, counting
+// instances by status and summing their message, byte and buffer counts,
+// in the order the channels first appear.
+func channelTotals(rows []map[string]any) []*channelTotal {
+	var totals []*channelTotal
+	byName := map[string]*channelTotal{}
+	for _, row := range rows {
+		name := text(row, "channel_name", "CHANNEL")
+		total, exists := byName[name]
+		if !exists {
+			total = &channelTotal{name: name, statuses: map[string]float64{}}
+			byName[name] = total
+			totals = append(totals, total)
+		}
+		if status := text(row, "channel_status", "STATUS"); status != "" {
+			total.statuses[status]++
+		}
+		for index, gauge := range channelGauges {
+			if value, exists := number(row, gauge.keys...); exists {
+				total.values[index] += value
+				total.hasValues[index] = true
+			}
+		}
+	}
+	return totals
+}
+
+// notFoundReasonCodes are the reason codes the DISPLAY commands report
+// when no objects match: MQRC_UNKNOWN_OBJECT_NAME, and
+// MQRCCF_CHL_STATUS_NOT_FOUND when no matching channel is running.
+var notFoundReasonCodes = []int{mqrestadmin.ReasonUnknownObjectName, mqrestadmin.ReasonChannelStatusNotFound}
+
+// ignoreNotFound returns nil for a command error reporting only that no
+// objects matched, and err otherwise.
+func ignoreNotFound(err error) error {
+	if mqrestadmin.HasReasonCode(err, notFoundReasonCodes...) {
+		return nil
+	}
+	return err
+}
+
+// lookup returns the values of keys in row, trying each key as given and
+// in lower case, since unmapped MQSC names may be returned in either.
+func lookup(row map[string]any, keys []string) []any {
+	var values []any
+	for _, key := range keys {
+		for _, candidate := range []string{key, strings.ToLower(key)} {
+			if value, exists := row[candidate]; exists && value != nil {
+				values = append(values, value)
+			}
+		}
+	}
+	return values
+}
+
+// text returns the first of keys present in row as trimmed, upper-case
+// text, or empty when none is present.
+func text(row map[string]any, keys ...string) string {
+	if values := lookup(row, keys); len(values) > 0 {
+		return strings.ToUpper(strings.TrimSpace(fmt.Sprint(values[0])))
+	}
+	return ""
+}
+
+// number returns the first of keys in row holding a number, whichever
+// NumberMode decoded it or as MQSC text, such as "42".
+func number(row map[string]any, keys ...string) (float64, bool) {
+	for _, value := range lookup(row, keys) {
+		if parsed, isNumber := mqrestadmin.Float64Value(value); isNumber {
+			return parsed, true
+		}
+	}
+	return 0, false
+}
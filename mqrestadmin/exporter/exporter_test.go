@@ -0,0 +1,383 @@
+package exporter
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"github.com/wphillipmoore/mq-rest-admin-go/mqrestadmin"
+)
+
+// fakeTransport answers DISPLAY commands with canned rows keyed by queue
+// manager and qualifier, such as "QM1 QSTATUS", and records each command's
+// queue manager, qualifier and name. Qualifiers without rows answer with
+// the command error for no matching objects, or with the reason code given
+// in reasonCodes, and queue managers or qualifiers in failing, such as
+// "QM4" or "QM1 QUEUE", with a transport error.
+type fakeTransport struct {
+	mutex       sync.Mutex
+	rows        map[string][]map[string]any
+	reasonCodes map[string]int
+	failing     map[string]bool
+	commands    []string
+	requests    int
+}
+
+func (transport *fakeTransport) PostJSON(_ context.Context, url string, payload map[string]any,
+	_ map[string]string, _ time.Duration, _ bool,
+) (*mqrestadmin.TransportResponse, error) {
+	transport.mutex.Lock()
+	defer transport.mutex.Unlock()
+	transport.requests++
+
+	_, rest, _ := strings.Cut(url, "/qmgr/")
+	qmgr, _, _ := strings.Cut(rest, "/")
+	command := fmt.Sprint(qmgr, " ", payload["qualifier"])
+	if name, exists := payload["name"]; exists {
+		command += fmt.Sprint(" ", name)
+	}
+	transport.commands = append(transport.commands, command)
+	if transport.failing[qmgr] || transport.failing[fmt.Sprint(qmgr, " ", payload["qualifier"])] {
+		return nil, errors.New("connection refused")
+	}
+	rows, exists := transport.rows[fmt.Sprint(qmgr, " ", payload["qualifier"])]
+	if !exists {
+		reasonCode := mqrestadmin.ReasonUnknownObjectName
+		if payload["qualifier"] == "CHSTATUS" {
+			reasonCode = mqrestadmin.ReasonChannelStatusNotFound
+		}
+		if code, exists := transport.reasonCodes[fmt.Sprint(qmgr, " ", payload["qualifier"])]; exists {
+			reasonCode = code
+		}
+		return &mqrestadmin.TransportResponse{StatusCode: 200, Body: fmt.Sprintf(`{"commandResponse":[`+
+			`{"completionCode":2,"reasonCode":%d}],"overallCompletionCode":2,"overallReasonCode":3008}`,
+			reasonCode)}, nil
+	}
+	items := make([]map[string]any, len(rows))
+	for index, row := range rows {
+		items[index] = map[string]any{"completionCode": 0, "reasonCode": 0, "parameters": row}
+	}
+	body, _ := json.Marshal(map[string]any{
+		"commandResponse": items, "overallCompletionCode": 0, "overallReasonCode": 0,
+	})
+	return &mqrestadmin.TransportResponse{StatusCode: 200, Body: string(body)}, nil
+}
+
+func (transport *fakeTransport) requestCount() int {
+	transport.mutex.Lock()
+	defer transport.mutex.Unlock()
+	return transport.requests
+}
+
+func newFakeTransport() *fakeTransport {
+	return &fakeTransport{
+		rows: map[string][]map[string]any{
+			"QM1 QMSTATUS": {{"STATUS": "RUNNING", "CONNS": "23"}},
+			"QM1 QSTATUS": {
+				{"QUEUE": "APP.IN", "CURDEPTH": 5, "IPPROCS": 1, "OPPROCS": 2, "MSGAGE": 42, "UNCOM": "3"},
+				{"QUEUE": "APP.OUT", "CURDEPTH": 0, "IPPROCS": 0, "OPPROCS": 1, "MSGAGE": " ", "UNCOM": "NO"},
+			},
+			"QM1 QUEUE": {
+				{"QUEUE": "APP.IN", "TYPE": "QLOCAL", "MAXDEPTH": 5000},
+				{"QUEUE": "APP.OUT", "TYPE": "QLOCAL", "MAXDEPTH": "10000"},
+				{"QUEUE": "APP.ALIAS", "TYPE": "QALIAS"},
+			},
+			"QM1 CHSTATUS": {
+				{"CHANNEL": "APP.SVRCONN", "STATUS": "RUNNING", "MSGS": 10, "BYTSSENT": 1000, "BYTSRCVD": 2000,
+					"BUFSSENT": 11, "BUFSRCVD": 12},
+				{"CHANNEL": "APP.SVRCONN", "STATUS": "RUNNING", "MSGS": 5, "BYTSSENT": 500, "BYTSRCVD": 700,
+					"BUFSSENT": 6, "BUFSRCVD": 7},
+				{"CHANNEL": "TO.QM2", "STATUS": "RETRYING"},
+				{"CHANNEL": "TO.QM3"},
+			},
+			"QM1 LSSTATUS": {{"LISTENER": "LISTENER.TCP", "STATUS": "RUNNING"}},
+
+			"QM2 QMSTATUS": {{"status": "running"}},
+			"QM2 QUEUE":    {{"queue": "Q2", "type": "QLOCAL", "maxdepth": 100}},
+			"QM2 SVSTATUS": {{"service": "SVC1", "status": "STOPPED"}},
+
+			"QM3 QMSTATUS": {{"STATUS": "RUNNING", "CONNS": 4}},
+		},
+		reasonCodes: map[string]int{},
+		failing:     map[string]bool{"QM4": true},
+	}
+}
+
+// newFakeFleet returns a fleet of QM1, mapping attributes; QM2, not
+// mapping attributes and surfacing json.Number values; QM3, surfacing
+// int64 values; and QM4, which fails.
+func newFakeFleet(t *testing.T, transport *fakeTransport) *mqrestadmin.Fleet {
+	t.Helper()
+	var sessions []*mqrestadmin.Session
+	for qmgr, options := range map[string][]mqrestadmin.Option{
+		"QM1": nil,
+		"QM2": {mqrestadmin.WithMapAttributes(false), mqrestadmin.WithNumberMode(mqrestadmin.NumberJSON)},
+		"QM3": {mqrestadmin.WithNumberMode(mqrestadmin.NumberInt64)},
+		"QM4": nil,
+	} {
+		session, err := mqrestadmin.NewSession("https://localhost:9443/ibmmq/rest/v2", qmgr,
+			mqrestadmin.BasicAuth{Username: "admin", Password: "admin"},
+			append([]mqrestadmin.Option{mqrestadmin.WithTransport(transport), mqrestadmin.WithMappingStrict(false)},
+				options...)...)
+		if err != nil {
+			t.Fatalf("NewSession: %v", err)
+		}
+		sessions = append(sessions, session)
+	}
+	fleet, err := mqrestadmin.NewFleet(sessions)
+	if err != nil {
+		t.Fatalf("NewFleet: %v", err)
+	}
+	return fleet
+}
+
+const expectedMetrics = `
+# HELP mq_channel_buffers_received Buffers received by the channel's current instances.
+# TYPE mq_channel_buffers_received gauge
+mq_channel_buffers_received{channel="APP.SVRCONN",qmgr="QM1"} 19
+# HELP mq_channel_buffers_sent Buffers sent by the channel's current instances.
+# TYPE mq_channel_buffers_sent gauge
+mq_channel_buffers_sent{channel="APP.SVRCONN",qmgr="QM1"} 17
+# HELP mq_channel_bytes_received Bytes received by the channel's current instances.
+# TYPE mq_channel_bytes_received gauge
+mq_channel_bytes_received{channel="APP.SVRCONN",qmgr="QM1"} 2700
+# HELP mq_channel_bytes_sent Bytes sent by the channel's current instances.
+# TYPE mq_channel_bytes_sent gauge
+mq_channel_bytes_sent{channel="APP.SVRCONN",qmgr="QM1"} 1500
+# HELP mq_channel_messages Messages sent or received by the channel's current instances.
+# TYPE mq_channel_messages gauge
+mq_channel_messages{channel="APP.SVRCONN",qmgr="QM1"} 15
+# HELP mq_channel_status Channel instances by status.
+# TYPE mq_channel_status gauge
+mq_channel_status{channel="APP.SVRCONN",qmgr="QM1",status="RUNNING"} 2
+mq_channel_status{channel="TO.QM2",qmgr="QM1",status="RETRYING"} 1
+# HELP mq_listener_status Listener status; 1 for the current status.
+# TYPE mq_listener_status gauge
+mq_listener_status{listener="LISTENER.TCP",qmgr="QM1",status="RUNNING"} 1
+# HELP mq_qmgr_connections Connections to the queue manager.
+# TYPE mq_qmgr_connections gauge
+mq_qmgr_connections{qmgr="QM1"} 23
+mq_qmgr_connections{qmgr="QM3"} 4
+# HELP mq_qmgr_status Queue manager status; 1 for the current status.
+# TYPE mq_qmgr_status gauge
+mq_qmgr_status{qmgr="QM1",status="RUNNING"} 1
+mq_qmgr_status{qmgr="QM2",status="RUNNING"} 1
+mq_qmgr_status{qmgr="QM3",status="RUNNING"} 1
+# HELP mq_queue_depth Messages on the queue.
+# TYPE mq_queue_depth gauge
+mq_queue_depth{qmgr="QM1",queue="APP.IN"} 5
+mq_queue_depth{qmgr="QM1",queue="APP.OUT"} 0
+# HELP mq_queue_max_depth Maximum messages allowed on the queue.
+# TYPE mq_queue_max_depth gauge
+mq_queue_max_depth{qmgr="QM1",queue="APP.IN"} 5000
+mq_queue_max_depth{qmgr="QM1",queue="APP.OUT"} 10000
+mq_queue_max_depth{qmgr="QM2",queue="Q2"} 100
+# HELP mq_queue_oldest_message_age_seconds Age of the oldest message on the queue. Requires queue monitoring.
+# TYPE mq_queue_oldest_message_age_seconds gauge
+mq_queue_oldest_message_age_seconds{qmgr="QM1",queue="APP.IN"} 42
+# HELP mq_queue_open_input_handles Handles open for input on the queue.
+# TYPE mq_queue_open_input_handles gauge
+mq_queue_open_input_handles{qmgr="QM1",queue="APP.IN"} 1
+mq_queue_open_input_handles{qmgr="QM1",queue="APP.OUT"} 0
+# HELP mq_queue_open_output_handles Handles open for output on the queue.
+# TYPE mq_queue_open_output_handles gauge
+mq_queue_open_output_handles{qmgr="QM1",queue="APP.IN"} 2
+mq_queue_open_output_handles{qmgr="QM1",queue="APP.OUT"} 1
+# HELP mq_queue_uncommitted_messages Uncommitted changes pending on the queue.
+# TYPE mq_queue_uncommitted_messages gauge
+mq_queue_uncommitted_messages{qmgr="QM1",queue="APP.IN"} 3
+# HELP mq_service_status Service status; 1 for the current status.
+# TYPE mq_service_status gauge
+mq_service_status{qmgr="QM2",service="SVC1",status="STOPPED"} 1
+# HELP mq_up Whether the last poll of the queue manager succeeded.
+# TYPE mq_up gauge
+mq_up{qmgr="QM1"} 1
+mq_up{qmgr="QM2"} 1
+mq_up{qmgr="QM3"} 1
+mq_up{qmgr="QM4"} 0
+`
+
+// metricNames lists every metric except mq_poll_duration_seconds, whose
+// value varies.
+var metricNames = []string{
+	"mq_up", "mq_qmgr_status", "mq_qmgr_connections",
+	"mq_queue_depth", "mq_queue_max_depth", "mq_queue_open_input_handles", "mq_queue_open_output_handles",
+	"mq_queue_oldest_message_age_seconds", "mq_queue_uncommitted_messages",
+	"mq_channel_status", "mq_channel_messages", "mq_channel_bytes_sent", "mq_channel_bytes_received",
+	"mq_channel_buffers_sent", "mq_channel_buffers_received",
+	"mq_listener_status", "mq_service_status",
+}
+
+func TestExporter_ScrapeTime(t *testing.T) {
+	transport := newFakeTransport()
+	var output bytes.Buffer
+	exporter := New(newFakeFleet(t, transport), WithLogger(slog.New(slog.NewTextHandler(&output, nil))))
+
+	if err := testutil.CollectAndCompare(exporter, strings.NewReader(expectedMetrics), metricNames...); err != nil {
+		t.Fatal(err)
+	}
+	if count := testutil.CollectAndCount(exporter, "mq_poll_duration_seconds"); count != 4 {
+		t.Errorf("collected %d poll durations, want 4", count)
+	}
+	if !strings.Contains(output.String(), `msg="mqrestadmin exporter poll failed" qmgr=QM4`) {
+		t.Errorf("log output = %s", output.String())
+	}
+
+	delete(transport.rows, "QM1 LSSTATUS")
+	if count := testutil.CollectAndCount(exporter, "mq_listener_status"); count != 0 {
+		t.Errorf("collected %d listener statuses after the listener stopped, want 0", count)
+	}
+}
+
+func TestExporter_Patterns(t *testing.T) {
+	transport := newFakeTransport()
+	exporter := New(newFakeFleet(t, transport), WithQueues("APP.*"), WithChannels("TO.*"),
+		WithPollTimeout(time.Minute))
+	testutil.CollectAndCount(exporter)
+
+	var commands []string
+	for _, command := range transport.commands {
+		if strings.HasPrefix(command, "QM1 ") {
+			commands = append(commands, command)
+		}
+	}
+	want := "QM1 QMSTATUS,QM1 QSTATUS APP.*,QM1 QUEUE APP.*,QM1 CHSTATUS TO.*,QM1 LSSTATUS *,QM1 SVSTATUS *"
+	if got := strings.Join(commands, ","); got != want {
+		t.Errorf("commands = %s, want %s", got, want)
+	}
+}
+
+func TestExporter_CommandFailures(t *testing.T) {
+	for _, qualifier := range []string{"QMSTATUS", "QSTATUS", "QUEUE", "CHSTATUS", "LSSTATUS", "SVSTATUS"} {
+		transport := newFakeTransport()
+		transport.failing["QM1 "+qualifier] = true
+		exporter := New(newFakeFleet(t, transport))
+
+		expected := `
+# HELP mq_up Whether the last poll of the queue manager succeeded.
+# TYPE mq_up gauge
+mq_up{qmgr="QM1"} 0
+mq_up{qmgr="QM2"} 1
+mq_up{qmgr="QM3"} 1
+mq_up{qmgr="QM4"} 0
+`
+		if err := testutil.CollectAndCompare(exporter, strings.NewReader(expected), "mq_up"); err != nil {
+			t.Errorf("%s failing: %v", qualifier, err)
+		}
+		if count := testutil.CollectAndCount(exporter, "mq_queue_depth"); count != 0 {
+			t.Errorf("%s failing: collected %d queue depths, want 0", qualifier, count)
+		}
+	}
+}
+
+func TestExporter_CommandErrors(t *testing.T) {
+	for _, qualifier := range []string{"QMSTATUS", "QSTATUS", "QUEUE", "CHSTATUS", "LSSTATUS", "SVSTATUS"} {
+		transport := newFakeTransport()
+		delete(transport.rows, "QM1 "+qualifier)
+		transport.reasonCodes["QM1 "+qualifier] = 2035
+		var output bytes.Buffer
+		exporter := New(newFakeFleet(t, transport), WithLogger(slog.New(slog.NewTextHandler(&output, nil))))
+
+		expected := `
+# HELP mq_up Whether the last poll of the queue manager succeeded.
+# TYPE mq_up gauge
+mq_up{qmgr="QM1"} 0
+mq_up{qmgr="QM2"} 1
+mq_up{qmgr="QM3"} 1
+mq_up{qmgr="QM4"} 0
+`
+		if err := testutil.CollectAndCompare(exporter, strings.NewReader(expected), "mq_up"); err != nil {
+			t.Errorf("%s not authorized: %v", qualifier, err)
+		}
+		if !strings.Contains(output.String(), "qmgr=QM1") {
+			t.Errorf("%s not authorized: log = %s", qualifier, output.String())
+		}
+	}
+}
+
+func TestExporter_PollTimeout(t *testing.T) {
+	exporter := New(newFakeFleet(t, newFakeTransport()), WithPollTimeout(time.Nanosecond))
+	expected := `
+# HELP mq_up Whether the last poll of the queue manager succeeded.
+# TYPE mq_up gauge
+mq_up{qmgr="QM1"} 0
+mq_up{qmgr="QM2"} 0
+mq_up{qmgr="QM3"} 0
+mq_up{qmgr="QM4"} 0
+`
+	if err := testutil.CollectAndCompare(exporter, strings.NewReader(expected), "mq_up"); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestExporter_Run(t *testing.T) {
+	transport := newFakeTransport()
+	exporter := New(newFakeFleet(t, transport))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- exporter.Run(ctx, time.Hour) }()
+	waitFor(t, func() bool { return testutil.CollectAndCount(exporter, "mq_up") == 4 })
+
+	requests := transport.requestCount()
+	if err := testutil.CollectAndCompare(exporter, strings.NewReader(expectedMetrics), metricNames...); err != nil {
+		t.Error(err)
+	}
+	if transport.requestCount() != requests {
+		t.Errorf("scrape sent %d requests while Run was polling", transport.requestCount()-requests)
+	}
+	cancel()
+	if err := <-done; !errors.Is(err, context.Canceled) {
+		t.Errorf("Run returned %v, want context.Canceled", err)
+	}
+
+	ctx, cancel = context.WithCancel(context.Background())
+	go func() { done <- exporter.Run(ctx, time.Millisecond) }()
+	waitFor(t, func() bool { return transport.requestCount() >= 3*requests })
+	cancel()
+	<-done
+}
+
+func TestNumber(t *testing.T) {
+	for _, test := range []struct {
+		value any
+		want  float64
+		found bool
+	}{
+		{float64(1.5), 1.5, true},
+		{int64(2), 2, true},
+		{3, 3, true},
+		{json.Number("4"), 4, true},
+		{json.Number("x"), 0, false},
+		{" 5 ", 5, true},
+		{"YES", 0, false},
+		{true, 0, false},
+	} {
+		got, found := number(map[string]any{"value": test.value}, "value")
+		if got != test.want || found != test.found {
+			t.Errorf("number(%v) = %v, %v, want %v, %v", test.value, got, found, test.want, test.found)
+		}
+	}
+}
+
+// waitFor polls condition until it holds, failing the test after a few
+// seconds.
+func waitFor(t *testing.T, condition func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for !condition() {
+		if time.Now().After(deadline) {
+			t.Fatal("condition not met")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
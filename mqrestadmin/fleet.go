@@ -0,0 +1,201 @@
+package mqrestadmin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"sync"
+)
+
+const defaultFleetConcurrency = 8
+
+// Fleet runs the same operation against many queue managers with bounded
+// concurrency. Each queue manager is reached through its own Session, so
+// a fleet can mix direct sessions and sessions routed through a gateway
+// queue manager with WithGatewayQmgr. A session is never used by two
+// fleet operations' goroutines at once, so each session only sees one
+// command at a time.
+type Fleet struct {
+	sessions    []*Session
+	byName      map[string]*Session
+	concurrency int
+}
+
+// FleetOption configures a Fleet during construction.
+type FleetOption func(*Fleet)
+
+// WithConcurrency limits how many queue managers a fleet operation runs
+// against at once. Values below 1 are treated as 1. Defaults to 8.
+func WithConcurrency(limit int) FleetOption {
+	return func(fleet *Fleet) {
+		fleet.concurrency = max(limit, 1)
+	}
+}
+
+// NewFleet creates a fleet from sessions, keyed by their queue manager
+// names. Operations visit the queue managers in the order given.
+func NewFleet(sessions []*Session, opts ...FleetOption) (*Fleet, error) {
+	fleet := &Fleet{byName: map[string]*Session{}, concurrency: defaultFleetConcurrency}
+	for _, session := range sessions {
+		if session == nil {
+			return nil, errors.New("fleet session must not be nil")
+		}
+		if _, exists := fleet.byName[session.qmgrName]; exists {
+			return nil, fmt.Errorf("fleet has more than one session for queue manager %s", session.qmgrName)
+		}
+		fleet.byName[session.qmgrName] = session
+		fleet.sessions = append(fleet.sessions, session)
+	}
+	for _, opt := range opts {
+		opt(fleet)
+	}
+	return fleet, nil
+}
+
+// GatewaySessions creates one session per queue manager, all routed
+// through gatewayQmgr at restBaseURL, for use with NewFleet. A single
+// gateway session is created with the options and credentials, and each
+// queue manager's session is derived from it with ForQmgr, so LTPA
+// credentials log in once for the whole fleet.
+func GatewaySessions(restBaseURL, gatewayQmgr string, qmgrNames []string, credentials Credentials,
+	opts ...Option,
+) ([]*Session, error) {
+	gateway, err := NewSession(restBaseURL, gatewayQmgr, credentials,
+		append(append([]Option{}, opts...), WithGatewayQmgr(gatewayQmgr))...)
+	if err != nil {
+		return nil, fmt.Errorf("gateway queue manager %s: %w", gatewayQmgr, err)
+	}
+	sessions := make([]*Session, len(qmgrNames))
+	for index, qmgrName := range qmgrNames {
+		sessions[index] = gateway.ForQmgr(qmgrName)
+	}
+	return sessions, nil
+}
+
+// QmgrNames returns the fleet's queue manager names in fleet order.
+func (fleet *Fleet) QmgrNames() []string {
+	names := make([]string, len(fleet.sessions))
+	for index, session := range fleet.sessions {
+		names[index] = session.qmgrName
+	}
+	return names
+}
+
+// Session returns the session for a queue manager.
+func (fleet *Fleet) Session(qmgrName string) (*Session, bool) {
+	session, exists := fleet.byName[qmgrName]
+	return session, exists
+}
+
+// Select returns a fleet of the named queue managers, in the order given,
+// with the same concurrency limit.
+func (fleet *Fleet) Select(qmgrNames ...string) (*Fleet, error) {
+	selected := make([]*Session, 0, len(qmgrNames))
+	for _, qmgrName := range qmgrNames {
+		session, exists := fleet.byName[qmgrName]
+		if !exists {
+			return nil, fmt.Errorf("queue manager %s is not in the fleet", qmgrName)
+		}
+		selected = append(selected, session)
+	}
+	return NewFleet(selected, WithConcurrency(fleet.concurrency))
+}
+
+// FleetResult is the outcome of a fleet operation on one queue manager.
+type FleetResult[T any] struct {
+	QmgrName string
+	Value    T
+	// Err is the operation's error, or nil on success.
+	Err error
+}
+
+// FleetResults holds a fleet operation's results keyed by queue manager
+// name, with one entry for every queue manager the operation ran against.
+type FleetResults[T any] map[string]FleetResult[T]
+
+// Err joins the errors of every failed queue manager, each annotated with
+// the queue manager name, or returns nil if all succeeded.
+func (results FleetResults[T]) Err() error {
+	var failures []error
+	for _, qmgrName := range slices.Sorted(maps.Keys(results)) {
+		if err := results[qmgrName].Err; err != nil {
+			failures = append(failures, fmt.Errorf("queue manager %s: %w", qmgrName, err))
+		}
+	}
+	return errors.Join(failures...)
+}
+
+// RunFleet runs operation against every queue manager in the fleet, at
+// most the fleet's concurrency limit at a time, and waits for all of them.
+// A failure on one queue manager does not stop the others; cancel ctx to
+// stop early. Queue managers not yet started when ctx is done report the
+// context error.
+func RunFleet[T any](ctx context.Context, fleet *Fleet,
+	operation func(ctx context.Context, session *Session) (T, error),
+) FleetResults[T] {
+	values := make([]FleetResult[T], len(fleet.sessions))
+	slots := make(chan struct{}, fleet.concurrency)
+	var group sync.WaitGroup
+	for index, session := range fleet.sessions {
+		values[index].QmgrName = session.qmgrName
+		select {
+		case slots <- struct{}{}:
+		case <-ctx.Done():
+		}
+		if err := ctx.Err(); err != nil {
+			values[index].Err = err
+			continue
+		}
+		group.Go(func() {
+			defer func() { <-slots }()
+			values[index].Value, values[index].Err = operation(ctx, session)
+		})
+	}
+	group.Wait()
+
+	results := make(FleetResults[T], len(values))
+	for _, result := range values {
+		results[result.QmgrName] = result
+	}
+	return results
+}
+
+// Rows runs a row-returning operation against every queue manager and
+// merges the rows in fleet order, tagging each with QmgrNameKey. Rows
+// already tagged, such as those from queue sharing group members, keep
+// their own queue manager name. The returned error joins the
+// per-queue-manager failures (see FleetResults.Err); rows from the queue
+// managers that succeeded are returned either way.
+func (fleet *Fleet) Rows(ctx context.Context,
+	operation func(ctx context.Context, session *Session) ([]map[string]any, error),
+) ([]map[string]any, error) {
+	results := RunFleet(ctx, fleet, operation)
+	var merged []map[string]any
+	for _, session := range fleet.sessions {
+		for _, row := range results[session.qmgrName].Value {
+			if _, tagged := row[QmgrNameKey]; !tagged {
+				row[QmgrNameKey] = session.qmgrName
+			}
+			merged = append(merged, row)
+		}
+	}
+	return merged, results.Err()
+}
+
+// DisplayQueue runs DisplayQueue against every queue manager and returns
+// the merged rows, each tagged with QmgrNameKey.
+func (fleet *Fleet) DisplayQueue(ctx context.Context, name string, opts ...CommandOption) ([]map[string]any, error) {
+	return fleet.Rows(ctx, func(ctx context.Context, session *Session) ([]map[string]any, error) {
+		return session.DisplayQueue(ctx, name, opts...)
+	})
+}
+
+// DisplayChannel runs DisplayChannel against every queue manager and
+// returns the merged rows, each tagged with QmgrNameKey.
+func (fleet *Fleet) DisplayChannel(ctx context.Context, name string, opts ...CommandOption) ([]map[string]any, error) {
+	return fleet.Rows(ctx, func(ctx context.Context, session *Session) ([]map[string]any, error) {
+		return session.DisplayChannel(ctx, name, opts...)
+	})
+}
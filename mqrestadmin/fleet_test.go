@@ -0,0 +1,235 @@
+package mqrestadmin
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func newFleetTestSession(qmgrName string) (*Session, *mockTransport) {
+	transport := newMockTransport()
+	session := newTestSession(transport)
+	session.qmgrName = qmgrName
+	return session, transport
+}
+
+func TestNewFleet(t *testing.T) {
+	qm1, _ := newFleetTestSession("QM1")
+	qm2, _ := newFleetTestSession("QM2")
+
+	fleet, err := NewFleet([]*Session{qm2, qm1})
+	if err != nil {
+		t.Fatalf("NewFleet() error: %v", err)
+	}
+	if got := fleet.QmgrNames(); !reflect.DeepEqual(got, []string{"QM2", "QM1"}) {
+		t.Errorf("QmgrNames() = %v", got)
+	}
+	if fleet.concurrency != defaultFleetConcurrency {
+		t.Errorf("concurrency = %d, want %d", fleet.concurrency, defaultFleetConcurrency)
+	}
+	if session, exists := fleet.Session("QM1"); !exists || session != qm1 {
+		t.Errorf("Session(QM1) = %v, %v", session, exists)
+	}
+	if _, exists := fleet.Session("QM3"); exists {
+		t.Error("Session(QM3) should not exist")
+	}
+}
+
+func TestNewFleet_Errors(t *testing.T) {
+	qm1, _ := newFleetTestSession("QM1")
+	if _, err := NewFleet([]*Session{qm1, nil}); err == nil || !strings.Contains(err.Error(), "must not be nil") {
+		t.Errorf("nil session error = %v", err)
+	}
+	duplicate, _ := newFleetTestSession("QM1")
+	if _, err := NewFleet([]*Session{qm1, duplicate}); err == nil ||
+		!strings.Contains(err.Error(), "more than one session for queue manager QM1") {
+		t.Errorf("duplicate session error = %v", err)
+	}
+}
+
+func TestWithConcurrency(t *testing.T) {
+	for limit, want := range map[int]int{-1: 1, 0: 1, 1: 1, 20: 20} {
+		fleet, _ := NewFleet(nil, WithConcurrency(limit))
+		if fleet.concurrency != want {
+			t.Errorf("WithConcurrency(%d) = %d, want %d", limit, fleet.concurrency, want)
+		}
+	}
+}
+
+func TestFleetSelect(t *testing.T) {
+	qm1, _ := newFleetTestSession("QM1")
+	qm2, _ := newFleetTestSession("QM2")
+	qm3, _ := newFleetTestSession("QM3")
+	fleet, _ := NewFleet([]*Session{qm1, qm2, qm3}, WithConcurrency(2))
+
+	selected, err := fleet.Select("QM3", "QM1")
+	if err != nil {
+		t.Fatalf("Select() error: %v", err)
+	}
+	if got := selected.QmgrNames(); !reflect.DeepEqual(got, []string{"QM3", "QM1"}) {
+		t.Errorf("QmgrNames() = %v", got)
+	}
+	if selected.concurrency != 2 {
+		t.Errorf("concurrency = %d, want 2", selected.concurrency)
+	}
+	if _, err := fleet.Select("QM9"); err == nil || !strings.Contains(err.Error(), "QM9 is not in the fleet") {
+		t.Errorf("unknown qmgr error = %v", err)
+	}
+}
+
+func TestGatewaySessions(t *testing.T) {
+	transport := newMockTransport()
+	transport.addResponse(200, map[string]any{}, map[string]string{"Set-Cookie": "LtpaToken2=gateway-token; Path=/"})
+	sessions, err := GatewaySessions("https://gateway:9443/ibmmq/rest/v2", "GW1", []string{"QM1", "QM2"},
+		LTPAAuth{Username: "admin", Password: "admin"},
+		WithTransport(transport), WithGatewayQmgr("IGNORED"), WithTimeout(time.Second))
+	if err != nil {
+		t.Fatalf("GatewaySessions() error: %v", err)
+	}
+	if transport.callCount() != 1 {
+		t.Errorf("LTPA logins = %d, want 1", transport.callCount())
+	}
+	if len(sessions) != 2 || sessions[0].qmgrName != "QM1" || sessions[1].qmgrName != "QM2" {
+		t.Fatalf("sessions = %v", sessions)
+	}
+	for _, session := range sessions {
+		if session.gatewayQmgr != "GW1" || session.timeout != time.Second || session.ltpaToken != "gateway-token" {
+			t.Errorf("%s gateway = %q, timeout = %v, token = %q",
+				session.qmgrName, session.gatewayQmgr, session.timeout, session.ltpaToken)
+		}
+	}
+
+	_, err = GatewaySessions("https://gateway:9443", "GW1", []string{"QM1"},
+		CertificateAuth{CertPath: "/nonexistent/cert.pem"})
+	if err == nil || !strings.Contains(err.Error(), "gateway queue manager GW1: load client certificate") {
+		t.Errorf("certificate error = %v", err)
+	}
+}
+
+func TestRunFleet_ResultsAndErrors(t *testing.T) {
+	qm1, _ := newFleetTestSession("QM1")
+	qm2, _ := newFleetTestSession("QM2")
+	qm3, _ := newFleetTestSession("QM3")
+	fleet, _ := NewFleet([]*Session{qm1, qm2, qm3})
+	failure := errors.New("unreachable")
+
+	results := RunFleet(context.Background(), fleet, func(_ context.Context, session *Session) (string, error) {
+		if session.qmgrName == "QM2" {
+			return "", failure
+		}
+		return strings.ToLower(session.qmgrName), nil
+	})
+
+	if len(results) != 3 || results["QM1"].Value != "qm1" || results["QM3"].Value != "qm3" {
+		t.Errorf("results = %+v", results)
+	}
+	if results["QM2"].QmgrName != "QM2" || !errors.Is(results["QM2"].Err, failure) {
+		t.Errorf("QM2 result = %+v", results["QM2"])
+	}
+	err := results.Err()
+	if !errors.Is(err, failure) || err.Error() != "queue manager QM2: unreachable" {
+		t.Errorf("Err() = %v", err)
+	}
+
+	delete(results, "QM2")
+	if err := results.Err(); err != nil {
+		t.Errorf("Err() without failures = %v", err)
+	}
+}
+
+func TestRunFleet_BoundedConcurrency(t *testing.T) {
+	var sessions []*Session
+	for _, name := range []string{"QM1", "QM2", "QM3", "QM4", "QM5", "QM6"} {
+		session, _ := newFleetTestSession(name)
+		sessions = append(sessions, session)
+	}
+	fleet, _ := NewFleet(sessions, WithConcurrency(2))
+
+	var running, peak atomic.Int32
+	results := RunFleet(context.Background(), fleet, func(context.Context, *Session) (bool, error) {
+		current := running.Add(1)
+		for {
+			previous := peak.Load()
+			if current <= previous || peak.CompareAndSwap(previous, current) {
+				break
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+		running.Add(-1)
+		return true, nil
+	})
+
+	if len(results) != 6 || results.Err() != nil {
+		t.Errorf("results = %+v", results)
+	}
+	if got := peak.Load(); got < 1 || got > 2 {
+		t.Errorf("peak concurrency = %d, want at most 2", got)
+	}
+}
+
+func TestRunFleet_CanceledContext(t *testing.T) {
+	qm1, _ := newFleetTestSession("QM1")
+	qm2, _ := newFleetTestSession("QM2")
+	fleet, _ := NewFleet([]*Session{qm1, qm2}, WithConcurrency(1))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	results := RunFleet(ctx, fleet, func(context.Context, *Session) (int, error) {
+		cancel()
+		return 1, nil
+	})
+
+	if results["QM1"].Value != 1 || results["QM1"].Err != nil {
+		t.Errorf("QM1 result = %+v", results["QM1"])
+	}
+	if !errors.Is(results["QM2"].Err, context.Canceled) {
+		t.Errorf("QM2 result = %+v, want context.Canceled", results["QM2"])
+	}
+}
+
+func TestFleetDisplayQueue_MergesRows(t *testing.T) {
+	qm1, transport1 := newFleetTestSession("QM1")
+	qm2, transport2 := newFleetTestSession("QM2")
+	qm3, transport3 := newFleetTestSession("QM3")
+	transport1.addSuccessResponse(map[string]any{"queue": "APP.A"}, map[string]any{"queue": "APP.B"})
+	transport2.addCommandErrorResponse(2, 2058)
+	transport3.addSuccessResponse(map[string]any{"queue": "APP.A"})
+	fleet, _ := NewFleet([]*Session{qm1, qm2, qm3})
+
+	rows, err := fleet.DisplayQueue(context.Background(), "APP.*")
+	want := []map[string]any{
+		{"queue": "APP.A", QmgrNameKey: "QM1"},
+		{"queue": "APP.B", QmgrNameKey: "QM1"},
+		{"queue": "APP.A", QmgrNameKey: "QM3"},
+	}
+	if !reflect.DeepEqual(rows, want) {
+		t.Errorf("rows = %v, want %v", rows, want)
+	}
+	var commandErr *CommandError
+	if !errors.As(err, &commandErr) || !strings.HasPrefix(err.Error(), "queue manager QM2: ") {
+		t.Errorf("error = %v, want QM2 CommandError", err)
+	}
+	if transport1.lastCall().Payload["name"] != "APP.*" {
+		t.Errorf("payload = %v", transport1.lastCall().Payload)
+	}
+}
+
+func TestFleetDisplayChannel(t *testing.T) {
+	qm1, transport1 := newFleetTestSession("QM1")
+	transport1.addSuccessResponse(map[string]any{"channel": "TO.QM2"})
+	fleet, _ := NewFleet([]*Session{qm1})
+
+	rows, err := fleet.DisplayChannel(context.Background(), "", WithResponseParameters([]string{"CHLTYPE"}))
+	if err != nil {
+		t.Fatalf("DisplayChannel() error: %v", err)
+	}
+	if !reflect.DeepEqual(rows, []map[string]any{{"channel": "TO.QM2", QmgrNameKey: "QM1"}}) {
+		t.Errorf("rows = %v", rows)
+	}
+	if transport1.lastCall().Payload["qualifier"] != "CHANNEL" {
+		t.Errorf("payload = %v", transport1.lastCall().Payload)
+	}
+}
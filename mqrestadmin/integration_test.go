@@ -142,6 +142,8 @@ const (
 
 	testEnsureQlocal  = "DEV.ENSURE.QLOCAL"
 	testEnsureChannel = "DEV.ENSURE.CHL"
+
+	testMessagingQlocal = "DEV.MESSAGING.QLOCAL"
 )
 
 // ---------------------------------------------------------------------------
@@ -1005,3 +1007,45 @@ func TestLTPAAuthDisplayQmgr(t *testing.T) {
 		t.Errorf("LTPA DisplayQmgr does not contain %q", cfg.qmgrName)
 	}
 }
+
+func TestMessagingPutBrowseGet(t *testing.T) {
+	cfg := loadIntegrationConfig()
+	session := buildSession(t, cfg)
+	ctx := context.Background()
+
+	silentDelete(func() error { return session.DeleteQlocal(ctx, testMessagingQlocal) })
+	if err := session.DefineQlocal(ctx, testMessagingQlocal); err != nil {
+		t.Fatalf("DefineQlocal: %v", err)
+	}
+	defer silentDelete(func() error { return session.DeleteQlocal(ctx, testMessagingQlocal) })
+
+	messaging := session.Messaging()
+	message := mqrestadmin.TextMessage("integration test message")
+	message.CorrelationID = strings.Repeat("0A", 24)
+	messageID, err := messaging.Put(ctx, testMessagingQlocal, message)
+	if err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+	if messageID == "" {
+		t.Error("Put: expected a message ID")
+	}
+
+	browsed, err := messaging.Browse(ctx, testMessagingQlocal)
+	if err != nil || browsed == nil || browsed.Text() != "integration test message" {
+		t.Fatalf("Browse: message = %v, err = %v", browsed, err)
+	}
+
+	got, err := messaging.Get(ctx, testMessagingQlocal,
+		mqrestadmin.WithCorrelationID(message.CorrelationID), mqrestadmin.WithWait(time.Second))
+	if err != nil || got == nil {
+		t.Fatalf("Get: message = %v, err = %v", got, err)
+	}
+	if got.Text() != "integration test message" || !strings.EqualFold(got.MessageID, messageID) {
+		t.Errorf("Get: message = %+v, want the put message %s", got, messageID)
+	}
+
+	empty, err := messaging.Get(ctx, testMessagingQlocal)
+	if err != nil || empty != nil {
+		t.Errorf("Get (empty queue): message = %v, err = %v; want nil, nil", empty, err)
+	}
+}
@@ -0,0 +1,153 @@
+// Command genvalues generates typed Go enum constants for the closed value
+// vocabularies defined in mapping-data.json.
+//
+// Every entry in a qualifier's request_value_map and response_value_map
+// becomes a named string type with one constant per caller-facing value.
+// Run it from the mqrestadmin package directory via go generate.
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go/format"
+	"log"
+	"os"
+	"sort"
+	"strings"
+)
+
+const (
+	inputPath  = "mapping-data.json"
+	outputPath = "attribute_values.go"
+)
+
+type qualifierMapping struct {
+	RequestValueMap  map[string]map[string]string `json:"request_value_map"`
+	ResponseKeyMap   map[string]string            `json:"response_key_map"`
+	ResponseValueMap map[string]map[string]string `json:"response_value_map"`
+}
+
+type mappingData struct {
+	Qualifiers map[string]qualifierMapping `json:"qualifiers"`
+}
+
+type enumType struct {
+	Qualifier string
+	Attribute string
+	Values    []string
+}
+
+func main() {
+	raw, err := os.ReadFile(inputPath)
+	if err != nil {
+		log.Fatalf("read %s: %v", inputPath, err)
+	}
+
+	var data mappingData
+	if err := json.Unmarshal(raw, &data); err != nil {
+		log.Fatalf("parse %s: %v", inputPath, err)
+	}
+
+	source, err := format.Source(render(collectEnums(data)))
+	if err != nil {
+		log.Fatalf("format generated source: %v", err)
+	}
+
+	if err := os.WriteFile(outputPath, source, 0o644); err != nil {
+		log.Fatalf("write %s: %v", outputPath, err)
+	}
+}
+
+// collectEnums merges the caller-facing values of both value maps for each
+// qualifier attribute. Request value maps are keyed by snake_case attribute
+// and value; response value maps are keyed by MQSC name and map to the
+// snake_case value.
+func collectEnums(data mappingData) []enumType {
+	var enums []enumType
+
+	for _, qualifier := range sortedKeys(data.Qualifiers) {
+		mapping := data.Qualifiers[qualifier]
+		values := make(map[string]map[string]bool)
+
+		for attribute, entries := range mapping.RequestValueMap {
+			for value := range entries {
+				addValue(values, attribute, value)
+			}
+		}
+		for mqscName, entries := range mapping.ResponseValueMap {
+			attribute, exists := mapping.ResponseKeyMap[mqscName]
+			if !exists {
+				continue
+			}
+			for _, value := range entries {
+				addValue(values, attribute, value)
+			}
+		}
+
+		for _, attribute := range sortedKeys(values) {
+			enums = append(enums, enumType{
+				Qualifier: qualifier,
+				Attribute: attribute,
+				Values:    sortedKeys(values[attribute]),
+			})
+		}
+	}
+
+	return enums
+}
+
+func addValue(values map[string]map[string]bool, attribute, value string) {
+	if values[attribute] == nil {
+		values[attribute] = make(map[string]bool)
+	}
+	values[attribute][value] = true
+}
+
+func render(enums []enumType) []byte {
+	var buffer bytes.Buffer
+
+	buffer.WriteString("// Code generated by genvalues from mapping-data.json; DO NOT EDIT.\n\n")
+	buffer.WriteString("package mqrestadmin\n")
+
+	for _, enum := range enums {
+		typeName := enumTypeName(enum.Qualifier, enum.Attribute)
+		fmt.Fprintf(&buffer, "\n// %s enumerates the mapped values of the %s %s attribute.\n",
+			typeName, enum.Qualifier, enum.Attribute)
+		fmt.Fprintf(&buffer, "type %s string\n\n", typeName)
+		fmt.Fprintf(&buffer, "// %s values.\n", typeName)
+		buffer.WriteString("const (\n")
+		for _, value := range enum.Values {
+			fmt.Fprintf(&buffer, "\t%s%s %s = %q\n", typeName, camelCase(value), typeName, value)
+		}
+		buffer.WriteString(")\n")
+	}
+
+	return buffer.Bytes()
+}
+
+// enumTypeName joins the qualifier and attribute names, dropping the
+// qualifier prefix when the attribute already repeats it (channel +
+// channel_type becomes ChannelType, not ChannelChannelType).
+func enumTypeName(qualifier, attribute string) string {
+	attribute = strings.TrimPrefix(attribute, qualifier+"_")
+	return camelCase(qualifier) + camelCase(attribute)
+}
+
+func camelCase(name string) string {
+	var builder strings.Builder
+	for _, part := range strings.FieldsFunc(name, func(r rune) bool { return r == '_' || r == '-' || r == ' ' }) {
+		builder.WriteString(strings.ToUpper(part[:1]))
+		builder.WriteString(strings.ToLower(part[1:]))
+	}
+	return builder.String()
+}
+
+func sortedKeys[V any](source map[string]V) []string {
+	keys := make([]string, 0, len(source))
+	for key := range source {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
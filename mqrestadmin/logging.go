@@ -0,0 +1,153 @@
+package mqrestadmin
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+)
+
+// WithLogger sets a logger for debug output. At debug level the session
+// logs each command with its parameters before and after attribute
+// mapping, HTTP status, duration, and completion and reason codes, along
+// with mapping issues that permissive mapping ignores, LTPA logins, and
+// other REST API requests. Authorization headers, LTPA cookies, login
+// passwords, and the values of sensitive attributes are redacted; see
+// WithSensitiveAttributes. By default nothing is logged.
+func WithLogger(logger *slog.Logger) Option {
+	return func(config *sessionConfig) {
+		config.logger = logger
+	}
+}
+
+// WithSensitiveAttributes adds attributes whose values are redacted in
+// log output, by snake_case or MQSC name, such as "ssl_cipher_spec". A
+// snake_case name also redacts the MQSC attributes it maps to. Passwords
+// and key repository secrets, such as PASSWORD, LDAPPWD and SSLKEYP, are
+// always redacted.
+func WithSensitiveAttributes(names ...string) Option {
+	return func(config *sessionConfig) {
+		config.sensitiveAttributes = append(config.sensitiveAttributes, names...)
+	}
+}
+
+// debugEnabled reports whether the session logs at debug level.
+func (session *Session) debugEnabled(ctx context.Context) bool {
+	return session.logger != nil && session.logger.Enabled(ctx, slog.LevelDebug)
+}
+
+// logCommand logs an admin command sent to url, with its response status,
+// duration and codes, or its error.
+func (session *Session) logCommand(ctx context.Context, url string, headers map[string]string,
+	payload map[string]any, start time.Time, statusCode int, responsePayload map[string]any, err error,
+) {
+	if !session.debugEnabled(ctx) {
+		return
+	}
+	command, qualifier, name := describePayload(payload)
+	attributes := []slog.Attr{
+		slog.String("command", command),
+		slog.String("qualifier", qualifier),
+	}
+	if name != "" {
+		attributes = append(attributes, slog.String("name", name))
+	}
+	if parameters, isMap := payload["parameters"].(map[string]any); isMap && payload["type"] == "runCommand" {
+		text, _ := parameters["command"].(string)
+		attributes = append(attributes, slog.String("mqsc", session.redactor.MQSC(text)))
+	} else if isMap {
+		attributes = append(attributes, slog.Any("mqsc_parameters", session.redactor.Parameters(parameters)))
+	}
+	attributes = append(attributes,
+		slog.String("url", url),
+		slog.Any("headers", session.redactor.Headers(headers)))
+
+	var commandErr *CommandError
+	if responsePayload == nil && errors.As(err, &commandErr) {
+		responsePayload = commandErr.Payload
+	}
+	if responsePayload != nil {
+		attributes = append(attributes,
+			slog.Int("completion_code", intValue(responsePayload["overallCompletionCode"])),
+			slog.Int("reason_code", intValue(responsePayload["overallReasonCode"])))
+	}
+	session.logExchange(ctx, "mqrestadmin command", attributes, start, statusCode, err)
+}
+
+// logRequest logs a REST API request outside runCommandJSON. Request and
+// response bodies are not logged.
+func (session *Session) logRequest(ctx context.Context, method, url string, headers map[string]string,
+	start time.Time, statusCode int, err error,
+) {
+	if !session.debugEnabled(ctx) {
+		return
+	}
+	session.logExchange(ctx, "mqrestadmin request", []slog.Attr{
+		slog.String("method", method),
+		slog.String("url", url),
+		slog.Any("headers", session.redactor.Headers(headers)),
+	}, start, statusCode, err)
+}
+
+// logExchange adds the status, duration and error to attributes and logs
+// them at debug level.
+func (session *Session) logExchange(ctx context.Context, message string, attributes []slog.Attr,
+	start time.Time, statusCode int, err error,
+) {
+	if statusCode != 0 {
+		attributes = append(attributes, slog.Int("status", statusCode))
+	}
+	attributes = append(attributes, slog.Duration("duration", session.clock.now().Sub(start)))
+	if err != nil {
+		attributes = append(attributes, slog.String("error", err.Error()))
+	}
+	session.logger.LogAttrs(ctx, slog.LevelDebug, message, attributes...)
+}
+
+// logLogin logs an LTPA login request with its password redacted.
+func (session *Session) logLogin(ctx context.Context, url string, headers map[string]string,
+	payload map[string]any, start time.Time, response *TransportResponse, err error,
+) {
+	if !session.debugEnabled(ctx) {
+		return
+	}
+	attributes := []slog.Attr{
+		slog.String("url", url),
+		slog.Any("headers", session.redactor.Headers(headers)),
+		slog.Any("payload", session.redactor.Parameters(payload)),
+	}
+	var statusCode int
+	if response != nil {
+		statusCode = response.StatusCode
+		attributes = append(attributes, slog.Any("response_headers", session.redactor.Headers(response.Headers)))
+	}
+	session.logExchange(ctx, "mqrestadmin login", attributes, start, statusCode, err)
+}
+
+// logRequestMapping logs request parameters before and after attribute
+// mapping.
+func (session *Session) logRequestMapping(ctx context.Context, mappingQualifier string,
+	parameters, mappedParameters map[string]any,
+) {
+	if !session.debugEnabled(ctx) {
+		return
+	}
+	session.logger.LogAttrs(ctx, slog.LevelDebug, "mqrestadmin request mapping",
+		slog.String("mapping_qualifier", mappingQualifier),
+		slog.Any("parameters", session.redactor.Parameters(parameters)),
+		slog.Any("mqsc_parameters", session.redactor.Parameters(mappedParameters)))
+}
+
+// logMappingIssues logs the mapping issues permissive mapping ignored.
+func (session *Session) logMappingIssues(ctx context.Context, mappingQualifier string, issues []MappingIssue) {
+	if len(issues) == 0 || !session.debugEnabled(ctx) {
+		return
+	}
+	descriptions := make([]string, len(issues))
+	for index, issue := range issues {
+		descriptions[index] = issue.String()
+	}
+	session.logger.LogAttrs(ctx, slog.LevelDebug, "mqrestadmin mapping issues",
+		slog.String("mapping_qualifier", mappingQualifier),
+		slog.Any("issues", descriptions))
+}
@@ -0,0 +1,204 @@
+package mqrestadmin
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+// newLoggingTestSession returns a session logging at debug level to the
+// returned buffer.
+func newLoggingTestSession(t *testing.T, transport Transport, credentials Credentials,
+	opts ...Option,
+) (*Session, *bytes.Buffer) {
+	t.Helper()
+	var output bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&output, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	session, err := NewSession("https://localhost:9443/ibmmq/rest/v2", "QM1", credentials,
+		append([]Option{WithTransport(transport), WithLogger(logger)}, opts...)...)
+	if err != nil {
+		t.Fatalf("NewSession: %v", err)
+	}
+	return session, &output
+}
+
+func logLines(output *bytes.Buffer) []string {
+	return strings.Split(strings.TrimSpace(output.String()), "\n")
+}
+
+func TestWithLogger_Command(t *testing.T) {
+	transport := newMockTransport()
+	transport.addSuccessResponse()
+	session, output := newLoggingTestSession(t, transport, BasicAuth{Username: "admin", Password: "s3cret"},
+		WithSensitiveAttributes("ssl_cipher_spec"))
+
+	err := session.AlterChannel(context.Background(), "TO.QM2", WithRequestParameters(map[string]any{
+		"password":        "channel-secret",
+		"ssl_cipher_spec": "TLS_AES_256_GCM_SHA384",
+		"description":     "to QM2",
+	}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	lines := logLines(output)
+	if len(lines) != 2 {
+		t.Fatalf("logged %d lines, want 2:\n%s", len(lines), output.String())
+	}
+	mapping, command := lines[0], lines[1]
+	for _, want := range []string{
+		`msg="mqrestadmin request mapping"`, "mapping_qualifier=channel",
+		"password:[REDACTED]", "ssl_cipher_spec:[REDACTED]", "description:to QM2",
+		"PASSWORD:[REDACTED]", "SSLCIPH:[REDACTED]", "DESCR:to QM2",
+	} {
+		if !strings.Contains(mapping, want) {
+			t.Errorf("mapping line missing %q: %s", want, mapping)
+		}
+	}
+	for _, want := range []string{
+		`msg="mqrestadmin command"`, "level=DEBUG", "command=ALTER", "qualifier=CHANNEL", "name=TO.QM2",
+		"PASSWORD:[REDACTED]", "Authorization:[REDACTED]", "status=200", "completion_code=0", "reason_code=0",
+		"duration=",
+	} {
+		if !strings.Contains(command, want) {
+			t.Errorf("command line missing %q: %s", want, command)
+		}
+	}
+	for _, secret := range []string{"channel-secret", "TLS_AES", "Basic "} {
+		if strings.Contains(output.String(), secret) {
+			t.Errorf("log output contains %q", secret)
+		}
+	}
+}
+
+func TestWithLogger_CommandErrors(t *testing.T) {
+	transport := newMockTransport()
+	transport.addCommandErrorResponse(2, 2085)
+	transport.addErrorResponse(errors.New("connection refused"))
+	session, output := newLoggingTestSession(t, transport, BasicAuth{Username: "admin", Password: "admin"},
+		WithMapAttributes(false))
+
+	_, _ = session.DisplayQueue(context.Background(), "MISSING")
+	_, _ = session.DisplayQmgr(context.Background())
+
+	lines := logLines(output)
+	if len(lines) != 2 {
+		t.Fatalf("logged %d lines, want 2:\n%s", len(lines), output.String())
+	}
+	if !strings.Contains(lines[0], "completion_code=2") || !strings.Contains(lines[0], "reason_code=2085") ||
+		!strings.Contains(lines[0], "error=") {
+		t.Errorf("command error line = %s", lines[0])
+	}
+	if strings.Contains(lines[1], "status=") || !strings.Contains(lines[1], `error="connection refused"`) {
+		t.Errorf("transport error line = %s", lines[1])
+	}
+}
+
+func TestWithLogger_RunMQSCRedaction(t *testing.T) {
+	transport := newMockTransport()
+	transport.addSuccessResponse()
+	session, output := newLoggingTestSession(t, transport, BasicAuth{Username: "admin", Password: "admin"})
+
+	_, err := session.RunMQSC(context.Background(),
+		"DEFINE AUTHINFO(LDAP.AUTH) AUTHTYPE(IDPWLDAP) ldappwd('p(a)ss''word') LDAPUSER('cn=mq')")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(output.String(), "ldappwd([REDACTED]) LDAPUSER('cn=mq')") ||
+		strings.Contains(output.String(), "p(a)ss") {
+		t.Errorf("log output = %s", output.String())
+	}
+}
+
+func TestWithLogger_MappingIssues(t *testing.T) {
+	transport := newMockTransport()
+	transport.addSuccessResponse(map[string]any{"queue": "Q1", "UNKNOWN": "x"})
+	session, output := newLoggingTestSession(t, transport, BasicAuth{Username: "admin", Password: "admin"},
+		WithMappingStrict(false))
+
+	if _, err := session.DisplayQueue(context.Background(), "Q1",
+		WithRequestParameters(map[string]any{"no_such_attribute": "x"})); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var issues []string
+	for _, line := range logLines(output) {
+		if strings.Contains(line, `msg="mqrestadmin mapping issues"`) {
+			issues = append(issues, line)
+		}
+	}
+	if len(issues) != 2 || !strings.Contains(issues[0], "no_such_attribute") || !strings.Contains(issues[1], "UNKNOWN") {
+		t.Errorf("mapping issue lines = %v", issues)
+	}
+
+	transport.addSuccessResponse(map[string]any{"queue": "Q1", "UNKNOWN": "x"})
+	output.Reset()
+	if _, err := collectSeq(t, session.DisplayQueueSeq(context.Background(), "Q1")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(output.String(), `issues="[response unknown_key: UNKNOWN]"`) ||
+		!strings.Contains(output.String(), "status=200") {
+		t.Errorf("stream log output = %s", output.String())
+	}
+}
+
+func TestWithLogger_LTPALoginAndRequests(t *testing.T) {
+	transport := &mockRequestTransport{mockTransport: newMockTransport()}
+	transport.addResponse(200, map[string]any{}, map[string]string{"Set-Cookie": "LtpaToken2=token-value; Path=/; HttpOnly"})
+	transport.addMessageResponse(200, `{"installation":[]}`, nil)
+	transport.addErrorResponse(errors.New("connection refused"))
+	session, output := newLoggingTestSession(t, transport, LTPAAuth{Username: "admin", Password: "login-secret"})
+
+	if _, err := session.Installations(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	_, _ = session.ListQmgrs(context.Background())
+
+	lines := logLines(output)
+	if len(lines) != 3 {
+		t.Fatalf("logged %d lines, want 3:\n%s", len(lines), output.String())
+	}
+	for _, want := range []string{
+		`msg="mqrestadmin login"`, "username:admin", "password:[REDACTED]",
+		"Set-Cookie:LtpaToken2=[REDACTED]; Path=[REDACTED]; HttpOnly", "status=200",
+	} {
+		if !strings.Contains(lines[0], want) {
+			t.Errorf("login line missing %q: %s", want, lines[0])
+		}
+	}
+	if !strings.Contains(lines[1], `msg="mqrestadmin request"`) || !strings.Contains(lines[1], "method=GET") ||
+		!strings.Contains(lines[1], "Cookie:LtpaToken2=[REDACTED]") || !strings.Contains(lines[1], "status=200") {
+		t.Errorf("request line = %s", lines[1])
+	}
+	if !strings.Contains(lines[2], `error="connection refused"`) {
+		t.Errorf("failed request line = %s", lines[2])
+	}
+	for _, secret := range []string{"login-secret", "token-value"} {
+		if strings.Contains(output.String(), secret) {
+			t.Errorf("log output contains %q", secret)
+		}
+	}
+}
+
+func TestWithLogger_Disabled(t *testing.T) {
+	var output bytes.Buffer
+	transport := newMockTransport()
+	transport.addSuccessResponse()
+	session, err := NewSession("https://localhost:9443/ibmmq/rest/v2", "QM1",
+		BasicAuth{Username: "admin", Password: "admin"}, WithTransport(transport),
+		WithLogger(slog.New(slog.NewTextHandler(&output, &slog.HandlerOptions{Level: slog.LevelInfo}))))
+	if err != nil {
+		t.Fatalf("NewSession: %v", err)
+	}
+
+	if _, err := session.ForQmgr("QM2").DisplayQueue(context.Background(), "*",
+		WithRequestParameters(map[string]any{"description": "x"})); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if output.Len() != 0 {
+		t.Errorf("logged below the handler level: %s", output.String())
+	}
+}
@@ -3,6 +3,9 @@ package mqrestadmin
 import (
 	"encoding/json"
 	"fmt"
+	"maps"
+	"reflect"
+	"slices"
 	"strings"
 )
 
@@ -36,6 +39,7 @@ type qualifierMapping struct {
 	RequestValueMap    map[string]map[string]string `json:"request_value_map"`
 	RequestKeyValueMap map[string]map[string]keyValueEntry `json:"request_key_value_map"`
 	ResponseKeyMap     map[string]string            `json:"response_key_map"`
+	ResponseTypeMap    map[string]string            `json:"response_type_map"`
 	ResponseValueMap   map[string]map[string]string `json:"response_value_map"`
 }
 
@@ -101,6 +105,7 @@ func newAttributeMapperWithOverrides(overrides map[string]any, mode MappingOverr
 				}
 			}
 			mergeStringMap(existing.ResponseKeyMap, override.ResponseKeyMap)
+			mergeStringMap(existing.ResponseTypeMap, override.ResponseTypeMap)
 			mergeNestedStringMap(existing.ResponseValueMap, override.ResponseValueMap)
 			mapper.data.Qualifiers[qualifier] = existing
 		}
@@ -149,6 +154,81 @@ func (mapper *attributeMapper) mapRequestAttributes(qualifier string,
 	return mapper.mapAttributes(qualifier, attributes, strict, MappingRequest, -1)
 }
 
+// reverseRequestAttributes translates request attributes from MQSC parameter
+// names back to snake_case where the mapping data allows it, so MQSC input
+// can be fed through the request mapping pipeline. Attributes without a
+// reverse mapping are returned unchanged.
+func (mapper *attributeMapper) reverseRequestAttributes(qualifier string,
+	attributes map[string]any,
+) map[string]any {
+	qualifierData, exists := mapper.data.Qualifiers[qualifier]
+	if !exists {
+		return copyMap(attributes)
+	}
+
+	// Invert in sorted order so duplicate MQSC names resolve the same way on
+	// every run.
+	reverseKeys := make(map[string]string, len(qualifierData.RequestKeyMap))
+	for _, snakeKey := range slices.Sorted(maps.Keys(qualifierData.RequestKeyMap)) {
+		mqscKey := qualifierData.RequestKeyMap[snakeKey]
+		if _, exists := reverseKeys[mqscKey]; !exists {
+			reverseKeys[mqscKey] = snakeKey
+		}
+	}
+
+	result := make(map[string]any, len(attributes))
+	for key, value := range attributes {
+		if snakeKey, snakeValue, found := reverseKeyValue(qualifierData.RequestKeyValueMap, key, value); found {
+			result[snakeKey] = snakeValue
+			continue
+		}
+
+		snakeKey, exists := reverseKeys[key]
+		if !exists {
+			result[key] = value
+			continue
+		}
+		result[snakeKey] = reverseValue(qualifierData.RequestValueMap[snakeKey], value)
+	}
+	return result
+}
+
+// reverseKeyValue finds the snake_case key and value that the key-value map
+// translates to the given MQSC key and value.
+func reverseKeyValue(keyValueMap map[string]map[string]keyValueEntry,
+	mqscKey string, value any,
+) (snakeKey string, snakeValue string, found bool) {
+	text, isString := value.(string)
+	if !isString {
+		return "", "", false
+	}
+	for _, candidateKey := range slices.Sorted(maps.Keys(keyValueMap)) {
+		entries := keyValueMap[candidateKey]
+		for _, candidateValue := range slices.Sorted(maps.Keys(entries)) {
+			entry := entries[candidateValue]
+			if entry.Key == mqscKey && strings.EqualFold(entry.Value, text) {
+				return candidateKey, candidateValue, true
+			}
+		}
+	}
+	return "", "", false
+}
+
+// reverseValue translates an MQSC value back to its snake_case value, or
+// returns it unchanged when the value map has no match.
+func reverseValue(valueMap map[string]string, value any) any {
+	text, isString := value.(string)
+	if !isString {
+		return value
+	}
+	for _, snakeValue := range slices.Sorted(maps.Keys(valueMap)) {
+		if strings.EqualFold(valueMap[snakeValue], text) {
+			return snakeValue
+		}
+	}
+	return value
+}
+
 // mapResponseAttributes translates response attributes from MQSC parameter
 // names to snake_case using the key map and value map layers.
 func (mapper *attributeMapper) mapResponseAttributes(qualifier string,
@@ -213,6 +293,8 @@ func (mapper *attributeMapper) mapAttributes(qualifier string,
 	var issues []MappingIssue
 
 	for key, value := range attributes {
+		value = plainString(value)
+
 		// The MQ REST API returns MQSC parameter names in lowercase, but the
 		// mapping data uses uppercase. Normalize response keys to uppercase
 		// for all lookups.
@@ -363,6 +445,20 @@ func mergeNestedStringMap(target, source map[string]map[string]string) {
 	}
 }
 
+// plainString converts values of named string types, such as the attribute
+// enums, to plain strings so the value map lookups match them. json.Number
+// is left alone so numeric values stay numeric.
+func plainString(value any) any {
+	switch value.(type) {
+	case string, json.Number:
+		return value
+	}
+	if reflected := reflect.ValueOf(value); reflected.Kind() == reflect.String {
+		return reflected.String()
+	}
+	return value
+}
+
 func copyMap(source map[string]any) map[string]any {
 	result := make(map[string]any, len(source))
 	for key, value := range source {
@@ -2,5 +2,7 @@ package mqrestadmin
 
 import _ "embed"
 
+//go:generate go run ./internal/genvalues
+
 //go:embed mapping-data.json
 var mappingDataJSON []byte
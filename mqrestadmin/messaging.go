@@ -0,0 +1,326 @@
+package mqrestadmin
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	messagingEndpoint    = "/messaging/qmgr/%s/queue/%s/message"
+	messageListEndpoint  = "/messaging/qmgr/%s/queue/%s/messagelist"
+	topicMessageEndpoint = "/messaging/qmgr/%s/topic/%s/message"
+
+	// messageDescriptorPrefix prefixes the headers that carry message
+	// descriptor fields on messaging requests and responses.
+	messageDescriptorPrefix = "ibm-mq-md-"
+
+	messageIDHeader     = "ibm-mq-md-messageId"
+	correlationIDHeader = "ibm-mq-md-correlationId"
+
+	// TextContentType is the content type of a text message body.
+	TextContentType = "text/plain;charset=utf-8"
+	// BinaryContentType is the content type of a binary message body.
+	BinaryContentType = "application/octet-stream"
+)
+
+// Messaging puts, gets, browses, and lists messages and publishes to topics
+// through the IBM MQ messaging REST API. It shares its Session's transport,
+// credentials, CSRF token and LTPA token, and addresses the session's queue
+// manager directly: the messaging API does not route through a gateway
+// queue manager.
+//
+// The session's transport must implement RequestTransport. HTTPTransport
+// does.
+type Messaging struct {
+	session *Session
+}
+
+// Messaging returns a messaging client for the session's queue manager.
+func (session *Session) Messaging() *Messaging {
+	return &Messaging{session: session}
+}
+
+// Message is a message put to or read from a queue.
+type Message struct {
+	Body []byte
+	// ContentType is TextContentType for text bodies and BinaryContentType
+	// for binary ones. Put defaults it to BinaryContentType.
+	ContentType string
+	// MessageID and CorrelationID hold the 48-character hexadecimal
+	// identifiers. MessageID is assigned by the queue manager on Put.
+	MessageID     string
+	CorrelationID string
+	// Properties holds the other message descriptor fields, keyed by their
+	// header names without the ibm-mq-md- prefix (for example
+	// "persistence", "expiry" or "replyTo").
+	Properties map[string]string
+}
+
+// TextMessage returns a message with a UTF-8 text body.
+func TextMessage(text string) Message {
+	return Message{Body: []byte(text), ContentType: TextContentType}
+}
+
+// IsText reports whether the message body is text.
+func (message Message) IsText() bool {
+	return strings.HasPrefix(message.ContentType, "text/")
+}
+
+// Text returns the message body as a string.
+func (message Message) Text() string {
+	return string(message.Body)
+}
+
+// MessageOption selects the message a Get or Browse reads.
+type MessageOption func(*messageConfig)
+
+type messageConfig struct {
+	messageID     string
+	correlationID string
+	wait          time.Duration
+}
+
+// WithMessageID reads only the message with this hexadecimal message ID.
+func WithMessageID(messageID string) MessageOption {
+	return func(config *messageConfig) {
+		config.messageID = messageID
+	}
+}
+
+// WithCorrelationID reads only a message with this hexadecimal correlation
+// ID.
+func WithCorrelationID(correlationID string) MessageOption {
+	return func(config *messageConfig) {
+		config.correlationID = correlationID
+	}
+}
+
+// WithWait waits up to interval for a message to arrive. Without it, Get
+// and Browse return immediately when no message is available. The interval
+// is sent in whole milliseconds.
+func WithWait(interval time.Duration) MessageOption {
+	return func(config *messageConfig) {
+		config.wait = interval
+	}
+}
+
+// Put puts a message on a queue and returns the message ID the queue
+// manager assigned.
+func (messaging *Messaging) Put(ctx context.Context, queue string, message Message) (string, error) {
+	response, err := messaging.send(ctx, http.MethodPost, queue, nil, message.Body, putHeaders(message))
+	if err != nil {
+		return "", err
+	}
+	return headerValue(response.Headers, messageIDHeader), nil
+}
+
+// Publish publishes a message on a topic string. Message descriptor
+// properties are sent as for Put.
+func (messaging *Messaging) Publish(ctx context.Context, topicString string, message Message) error {
+	_, err := messaging.sendTo(ctx, topicMessageEndpoint, http.MethodPost, topicString, nil, message.Body,
+		putHeaders(message))
+	return err
+}
+
+// putHeaders returns the request headers that carry a message's content
+// type and descriptor fields on a put or publish.
+func putHeaders(message Message) map[string]string {
+	headers := map[string]string{"Content-Type": message.ContentType}
+	if message.ContentType == "" {
+		headers["Content-Type"] = BinaryContentType
+	}
+	for name, value := range message.Properties {
+		headers[messageDescriptorPrefix+name] = value
+	}
+	if message.CorrelationID != "" {
+		headers[correlationIDHeader] = message.CorrelationID
+	}
+	return headers
+}
+
+// Get destructively gets a message from a queue. It returns nil when no
+// matching message is available within the wait interval.
+func (messaging *Messaging) Get(ctx context.Context, queue string, opts ...MessageOption) (*Message, error) {
+	return messaging.read(ctx, http.MethodDelete, queue, opts)
+}
+
+// Browse reads a message from a queue without removing it. It returns nil
+// when no matching message is available within the wait interval.
+func (messaging *Messaging) Browse(ctx context.Context, queue string, opts ...MessageOption) (*Message, error) {
+	return messaging.read(ctx, http.MethodGet, queue, opts)
+}
+
+// List browses the messages on a queue and returns their message
+// descriptors, without bodies, in queue order. The REST API may cap the
+// number of messages it lists.
+func (messaging *Messaging) List(ctx context.Context, queue string) ([]Message, error) {
+	response, err := messaging.sendTo(ctx, messageListEndpoint, http.MethodGet, queue, nil, nil,
+		map[string]string{"Accept": "application/json"})
+	if err != nil {
+		return nil, err
+	}
+
+	var list struct {
+		Messages []map[string]any `json:"messages"`
+	}
+	if err := json.Unmarshal([]byte(response.Body), &list); err != nil {
+		return nil, &ResponseError{ResponseText: response.Body, StatusCode: response.StatusCode}
+	}
+
+	messages := make([]Message, len(list.Messages))
+	for index, fields := range list.Messages {
+		message := Message{Properties: map[string]string{}}
+		for name, value := range fields {
+			switch name {
+			case "messageId":
+				message.MessageID = fmt.Sprint(value)
+			case "correlationId":
+				message.CorrelationID = fmt.Sprint(value)
+			default:
+				message.Properties[name] = fmt.Sprint(value)
+			}
+		}
+		messages[index] = message
+	}
+	return messages, nil
+}
+
+// read is the shared implementation of Get and Browse.
+func (messaging *Messaging) read(ctx context.Context, method, queue string, opts []MessageOption) (*Message, error) {
+	var config messageConfig
+	for _, opt := range opts {
+		opt(&config)
+	}
+
+	query := url.Values{}
+	if config.messageID != "" {
+		query.Set("messageId", config.messageID)
+	}
+	if config.correlationID != "" {
+		query.Set("correlationId", config.correlationID)
+	}
+	if config.wait > 0 {
+		query.Set("wait", strconv.FormatInt(config.wait.Milliseconds(), 10))
+	}
+
+	response, err := messaging.send(ctx, method, queue, query, nil, map[string]string{})
+	if err != nil {
+		return nil, err
+	}
+	if response.StatusCode == http.StatusNoContent {
+		return nil, nil
+	}
+	return messageFromResponse(response), nil
+}
+
+// send issues a message request with the session's authentication
+// headers and checks the response status.
+func (messaging *Messaging) send(ctx context.Context, method, queue string, query url.Values,
+	body []byte, headers map[string]string,
+) (*TransportResponse, error) {
+	return messaging.sendTo(ctx, messagingEndpoint, method, queue, query, body, headers)
+}
+
+// sendTo issues a request to a messaging endpoint for a queue, or for a
+// topic string when endpoint is topicMessageEndpoint.
+func (messaging *Messaging) sendTo(ctx context.Context, endpoint, method, destination string, query url.Values,
+	body []byte, headers map[string]string,
+) (*TransportResponse, error) {
+	session := messaging.session
+	requestURL := session.restBaseURL +
+		fmt.Sprintf(endpoint, url.PathEscape(session.qmgrName), url.PathEscape(destination))
+	if len(query) > 0 {
+		requestURL += "?" + query.Encode()
+	}
+
+	response, err := session.sendRequest(ctx, method, requestURL, body, headers)
+	if err != nil {
+		return nil, err
+	}
+	if response.StatusCode >= http.StatusBadRequest {
+		messagingErr := newMessagingError(response)
+		if endpoint == topicMessageEndpoint {
+			messagingErr.Topic = destination
+		} else {
+			messagingErr.Queue = destination
+		}
+		return nil, messagingErr
+	}
+	return response, nil
+}
+
+// messageFromResponse builds a Message from a get or browse response.
+func messageFromResponse(response *TransportResponse) *Message {
+	message := &Message{Body: []byte(response.Body), Properties: map[string]string{}}
+	for name, value := range response.Headers {
+		switch {
+		case strings.EqualFold(name, "Content-Type"):
+			message.ContentType = value
+		case strings.EqualFold(name, messageIDHeader):
+			message.MessageID = value
+		case strings.EqualFold(name, correlationIDHeader):
+			message.CorrelationID = value
+		case len(name) > len(messageDescriptorPrefix) &&
+			strings.EqualFold(name[:len(messageDescriptorPrefix)], messageDescriptorPrefix):
+			message.Properties[responsePropertyName(name[len(messageDescriptorPrefix):])] = value
+		}
+	}
+	return message
+}
+
+// responsePropertyName restores the camel case of a message descriptor
+// field whose header name was canonicalized by net/http, such as
+// "Persistence" or "Replyto".
+func responsePropertyName(name string) string {
+	for _, known := range messageDescriptorFields {
+		if strings.EqualFold(name, known) {
+			return known
+		}
+	}
+	return name
+}
+
+// messageDescriptorFields lists the message descriptor fields the
+// messaging REST API reports as ibm-mq-md- headers.
+var messageDescriptorFields = []string{
+	"encoding", "expiry", "persistence",
+	"priority", "putDate", "putTime", "replyTo", "replyToQmgr",
+	"format", "characterSet", "backoutCount", "messageType", "userIdentifier",
+}
+
+// headerValue returns the value of a response header, matching the name
+// case-insensitively.
+func headerValue(headers map[string]string, name string) string {
+	for key, value := range headers {
+		if strings.EqualFold(key, name) {
+			return value
+		}
+	}
+	return ""
+}
+
+// newMessagingError builds a MessagingError from an error response, taking
+// the completion code, reason code and message from its JSON error body
+// when present.
+func newMessagingError(response *TransportResponse) *MessagingError {
+	messagingErr := &MessagingError{StatusCode: response.StatusCode, ResponseText: response.Body}
+	var body struct {
+		Error []struct {
+			CompletionCode int    `json:"completionCode"`
+			ReasonCode     int    `json:"reasonCode"`
+			Message        string `json:"message"`
+		} `json:"error"`
+	}
+	if json.Unmarshal([]byte(response.Body), &body) == nil && len(body.Error) > 0 {
+		messagingErr.CompletionCode = body.Error[0].CompletionCode
+		messagingErr.ReasonCode = body.Error[0].ReasonCode
+		messagingErr.Message = body.Error[0].Message
+	}
+	return messagingErr
+}
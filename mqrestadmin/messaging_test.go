@@ -0,0 +1,229 @@
+package mqrestadmin
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// mockRequestTransport adds RequestTransport to mockTransport, recording
+// each request's method and body.
+type mockRequestTransport struct {
+	*mockTransport
+	methods []string
+	bodies  []string
+}
+
+func (transport *mockRequestTransport) SendRequest(ctx context.Context, method, url string, body []byte,
+	headers map[string]string, timeout time.Duration, verifyTLS bool,
+) (*TransportResponse, error) {
+	transport.methods = append(transport.methods, method)
+	transport.bodies = append(transport.bodies, string(body))
+	return transport.PostJSON(ctx, url, nil, headers, timeout, verifyTLS)
+}
+
+// addMessageResponse queues a messaging response with a raw body.
+func (transport *mockRequestTransport) addMessageResponse(statusCode int, body string, headers map[string]string) {
+	transport.responses = append(transport.responses, mockResponse{
+		Response: &TransportResponse{StatusCode: statusCode, Body: body, Headers: headers},
+	})
+}
+
+func newMessagingTestSession() (*Session, *mockRequestTransport) {
+	transport := &mockRequestTransport{mockTransport: newMockTransport()}
+	session := newTestSession(transport.mockTransport)
+	session.transport = transport
+	return session, transport
+}
+
+func TestMessaging_PutText(t *testing.T) {
+	session, transport := newMessagingTestSession()
+	session.gatewayQmgr = "GW1"
+	transport.addMessageResponse(201, "", map[string]string{"Ibm-Mq-Md-Messageid": "414D5120514D31"})
+
+	message := TextMessage("hello")
+	message.CorrelationID = "0102"
+	message.Properties = map[string]string{"persistence": "persistent", "expiry": "unlimited"}
+	messageID, err := session.Messaging().Put(context.Background(), "APP.Q", message)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if messageID != "414D5120514D31" {
+		t.Errorf("messageID = %q", messageID)
+	}
+
+	call := transport.lastCall()
+	if call.URL != "https://localhost:9443/ibmmq/rest/v2/messaging/qmgr/QM1/queue/APP.Q/message" {
+		t.Errorf("URL = %q", call.URL)
+	}
+	if transport.methods[0] != http.MethodPost || transport.bodies[0] != "hello" {
+		t.Errorf("request = %s %q, want POST hello", transport.methods[0], transport.bodies[0])
+	}
+	wantHeaders := map[string]string{
+		"Content-Type":            TextContentType,
+		"ibm-mq-md-correlationId": "0102",
+		"ibm-mq-md-persistence":   "persistent",
+		"ibm-mq-md-expiry":        "unlimited",
+		"Authorization":           call.Headers["Authorization"],
+		"ibm-mq-rest-csrf-token":  "local",
+	}
+	if !reflect.DeepEqual(call.Headers, wantHeaders) {
+		t.Errorf("headers = %v, want %v", call.Headers, wantHeaders)
+	}
+	if !strings.HasPrefix(call.Headers["Authorization"], "Basic ") {
+		t.Errorf("Authorization = %q, want basic auth", call.Headers["Authorization"])
+	}
+}
+
+func TestMessaging_PutBinaryDefault(t *testing.T) {
+	session, transport := newMessagingTestSession()
+	transport.addMessageResponse(201, "", nil)
+
+	if _, err := session.Messaging().Put(context.Background(), "APP Q", Message{Body: []byte{0, 1}}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	call := transport.lastCall()
+	if call.Headers["Content-Type"] != BinaryContentType || !strings.HasSuffix(call.URL, "/queue/APP%20Q/message") {
+		t.Errorf("call = %+v", call)
+	}
+}
+
+func TestMessaging_Get(t *testing.T) {
+	session, transport := newMessagingTestSession()
+	transport.addMessageResponse(200, "hello", map[string]string{
+		"Content-Type":            "text/plain;charset=utf-8",
+		"Ibm-Mq-Md-Messageid":     "414D51",
+		"Ibm-Mq-Md-Correlationid": "0102",
+		"Ibm-Mq-Md-Persistence":   "persistent",
+		"Ibm-Mq-Md-Replytoqmgr":   "QM2",
+		"Ibm-Mq-Md-Customfield":   "x",
+		"X-Powered-By":            "Servlet/4.0",
+		"ibm-mq-md-":              "ignored",
+	})
+
+	message, err := session.Messaging().Get(context.Background(), "APP.Q",
+		WithCorrelationID("0102"), WithMessageID("414D51"), WithWait(1500*time.Millisecond))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := &Message{
+		Body:          []byte("hello"),
+		ContentType:   TextContentType,
+		MessageID:     "414D51",
+		CorrelationID: "0102",
+		Properties:    map[string]string{"persistence": "persistent", "replyToQmgr": "QM2", "Customfield": "x"},
+	}
+	if !reflect.DeepEqual(message, want) {
+		t.Errorf("message = %#v, want %#v", message, want)
+	}
+	if !message.IsText() || message.Text() != "hello" {
+		t.Errorf("IsText/Text = %v %q", message.IsText(), message.Text())
+	}
+	call := transport.lastCall()
+	if transport.methods[0] != http.MethodDelete ||
+		!strings.HasSuffix(call.URL, "/message?correlationId=0102&messageId=414D51&wait=1500") {
+		t.Errorf("request = %s %s", transport.methods[0], call.URL)
+	}
+	if _, hasContentType := call.Headers["Content-Type"]; hasContentType {
+		t.Errorf("headers = %v, want no Content-Type on a get", call.Headers)
+	}
+}
+
+func TestMessaging_BrowseNoMessage(t *testing.T) {
+	session, transport := newMessagingTestSession()
+	transport.addMessageResponse(204, "", nil)
+
+	message, err := session.Messaging().Browse(context.Background(), "APP.Q")
+	if err != nil || message != nil {
+		t.Errorf("message = %v, err = %v; want nil, nil", message, err)
+	}
+	if transport.methods[0] != http.MethodGet || strings.Contains(transport.lastCall().URL, "?") {
+		t.Errorf("request = %s %s", transport.methods[0], transport.lastCall().URL)
+	}
+	if (Message{ContentType: BinaryContentType}).IsText() {
+		t.Error("binary message reported as text")
+	}
+}
+
+func TestMessaging_Errors(t *testing.T) {
+	session, transport := newMessagingTestSession()
+	transport.addMessageResponse(404,
+		`{"error":[{"msgId":"MQWB0009E","completionCode":2,"reasonCode":2085,"message":"MQWB0009E: Could not query the queue"}]}`, nil)
+	transport.addMessageResponse(500, "Internal error", nil)
+	transport.addMessageResponse(401, "", nil)
+	transport.addErrorResponse(errors.New("connection refused"))
+	messaging := session.Messaging()
+
+	_, err := messaging.Get(context.Background(), "MISSING")
+	var messagingErr *MessagingError
+	if !errors.As(err, &messagingErr) || messagingErr.ReasonCode != 2085 || messagingErr.CompletionCode != 2 {
+		t.Fatalf("error = %v, want MessagingError with RC 2085", err)
+	}
+	if err.Error() != "mqrestadmin messaging error (HTTP 404, RC=2085) for queue MISSING: MQWB0009E: Could not query the queue" {
+		t.Errorf("Error() = %q", err.Error())
+	}
+
+	_, err = messaging.Put(context.Background(), "APP.Q", TextMessage("x"))
+	if err == nil || err.Error() != "mqrestadmin messaging error (HTTP 500) for queue APP.Q: Internal error" {
+		t.Errorf("error = %v", err)
+	}
+
+	_, err = messaging.Browse(context.Background(), "APP.Q")
+	var authErr *AuthError
+	if !errors.As(err, &authErr) || session.LastHTTPStatus != 401 {
+		t.Errorf("error = %v, want AuthError", err)
+	}
+
+	if _, err = messaging.Browse(context.Background(), "APP.Q"); err == nil || err.Error() != "connection refused" {
+		t.Errorf("error = %v, want transport failure", err)
+	}
+}
+
+func TestMessaging_RequiresRequestTransport(t *testing.T) {
+	session := newTestSession(newMockTransport())
+
+	_, err := session.Messaging().Put(context.Background(), "APP.Q", TextMessage("x"))
+	if err == nil || !strings.Contains(err.Error(), "RequestTransport") {
+		t.Errorf("error = %v, want unsupported transport", err)
+	}
+}
+
+func TestMessaging_List(t *testing.T) {
+	session, transport := newMessagingTestSession()
+	transport.addMessageResponse(200,
+		`{"messages":[{"messageId":"414D51","correlationId":"0102","format":"MQDEAD","priority":4},{"messageId":"414D52"}]}`, nil)
+	transport.addMessageResponse(200, "not json", nil)
+	transport.addMessageResponse(404, "", nil)
+
+	messages, err := session.Messaging().List(context.Background(), "DLQ")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []Message{
+		{MessageID: "414D51", CorrelationID: "0102", Properties: map[string]string{"format": "MQDEAD", "priority": "4"}},
+		{MessageID: "414D52", Properties: map[string]string{}},
+	}
+	if !reflect.DeepEqual(messages, want) {
+		t.Errorf("messages = %#v, want %#v", messages, want)
+	}
+	call := transport.lastCall()
+	if transport.methods[0] != http.MethodGet || !strings.HasSuffix(call.URL, "/queue/DLQ/messagelist") ||
+		call.Headers["Accept"] != "application/json" {
+		t.Errorf("request = %s %s %v", transport.methods[0], call.URL, call.Headers)
+	}
+
+	_, err = session.Messaging().List(context.Background(), "DLQ")
+	var responseErr *ResponseError
+	if !errors.As(err, &responseErr) {
+		t.Errorf("error = %v, want ResponseError", err)
+	}
+
+	var messagingErr *MessagingError
+	if _, err = session.Messaging().List(context.Background(), "MISSING"); !errors.As(err, &messagingErr) {
+		t.Errorf("error = %v, want MessagingError", err)
+	}
+}
@@ -0,0 +1,413 @@
+package mqrestadmin
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"path"
+	"time"
+)
+
+const (
+	mftAgentEndpoint    = "/admin/mft/agent"
+	mftMonitorEndpoint  = "/admin/mft/monitor"
+	mftTransferEndpoint = "/admin/mft/transfer"
+)
+
+// MFT reads and creates IBM MQ Managed File Transfer agents, resource
+// monitors and transfers through the mqweb MFT REST API. It shares its
+// Session's transport, credentials, CSRF token and LTPA token. The MFT
+// resources describe the coordination queue manager mqweb is configured
+// for, not the session's queue manager.
+//
+// The session's transport must implement RequestTransport. HTTPTransport
+// does.
+type MFT struct {
+	session *Session
+}
+
+// MFT returns a Managed File Transfer client for the session's mqweb
+// server.
+func (session *Session) MFT() *MFT {
+	return &MFT{session: session}
+}
+
+// AgentState is an MFT agent's state.
+type AgentState string
+
+// AgentState values.
+const (
+	AgentStateReady       AgentState = "ready"
+	AgentStateActive      AgentState = "active"
+	AgentStateStarting    AgentState = "starting"
+	AgentStateEnding      AgentState = "ending"
+	AgentStateStopped     AgentState = "stopped"
+	AgentStateUnreachable AgentState = "unreachable"
+	AgentStateProblem     AgentState = "problem"
+	AgentStateUnknown     AgentState = "unknown"
+)
+
+// Agent is an MFT agent and its last published status.
+type Agent struct {
+	Name string
+	// Type is the agent type, such as "standard" or "bridge".
+	Type  string
+	State AgentState
+	// QmgrName is the agent's queue manager.
+	QmgrName    string
+	Description string
+}
+
+// Monitor is an MFT resource monitor.
+type Monitor struct {
+	Name      string
+	AgentName string
+	// State is "started" or "stopped".
+	State string
+	// Type is the monitored resource type, "directory" or "queue".
+	Type string
+}
+
+// TransferState is an MFT transfer's state.
+type TransferState string
+
+// TransferState values.
+const (
+	TransferStateStarted                TransferState = "started"
+	TransferStateInProgress             TransferState = "inProgress"
+	TransferStateInProgressWithWarnings TransferState = "inProgressWithWarnings"
+	TransferStateInProgressWithFailures TransferState = "inProgressWithFailures"
+	TransferStateSuccessful             TransferState = "successful"
+	TransferStatePartiallySuccessful    TransferState = "partiallySuccessful"
+	TransferStateFailed                 TransferState = "failed"
+	TransferStateCancelled              TransferState = "cancelled"
+	TransferStateMalformed              TransferState = "malformed"
+	TransferStateNotAuthorized          TransferState = "notAuthorized"
+	TransferStateDeleted                TransferState = "deleted"
+)
+
+// Done reports whether the transfer has finished, successfully or not.
+func (state TransferState) Done() bool {
+	switch state {
+	case TransferStateStarted, TransferStateInProgress,
+		TransferStateInProgressWithWarnings, TransferStateInProgressWithFailures:
+		return false
+	default:
+		return true
+	}
+}
+
+// Succeeded reports whether every file in the transfer was transferred.
+func (state TransferState) Succeeded() bool {
+	return state == TransferStateSuccessful
+}
+
+// AgentRef names an MFT agent and its queue manager.
+type AgentRef struct {
+	Name     string `json:"name"`
+	QmgrName string `json:"qmgrName,omitempty"`
+}
+
+// Transfer is an MFT transfer and its status.
+type Transfer struct {
+	ID    string
+	State TransferState
+	// Description explains the state, typically for failed transfers.
+	Description      string
+	SourceAgent      AgentRef
+	DestinationAgent AgentRef
+	// OriginatorUserID is the user that requested the transfer.
+	OriginatorUserID string
+	// StartTime and EndTime are zero until the transfer starts and ends.
+	StartTime time.Time
+	EndTime   time.Time
+	// Files counts the files in the transfer; FileSuccesses, FileWarnings
+	// and FileFailures count the files transferred so far by outcome.
+	Files         int
+	FileSuccesses int
+	FileWarnings  int
+	FileFailures  int
+}
+
+// TransferItem is one file or directory to transfer.
+type TransferItem struct {
+	// Source and Destination are the file, directory or queue names.
+	Source      string
+	Destination string
+	// SourceType and DestinationType are "file" (the default), "directory"
+	// or "queue".
+	SourceType      string
+	DestinationType string
+	// Text transfers in text mode, converting line endings and code pages.
+	// Files are transferred in binary mode by default.
+	Text bool
+	// Recursive includes subdirectories of a source directory.
+	Recursive bool
+	// Overwrite replaces an existing destination file instead of failing.
+	Overwrite bool
+	// DeleteSource deletes the source file after a successful transfer.
+	DeleteSource bool
+}
+
+// TransferRequest describes a transfer for CreateTransfer.
+type TransferRequest struct {
+	SourceAgent      AgentRef
+	DestinationAgent AgentRef
+	Items            []TransferItem
+	// JobName optionally labels the transfer.
+	JobName string
+}
+
+// ListAgents returns every MFT agent with its state, from
+// GET /admin/mft/agent.
+func (mft *MFT) ListAgents(ctx context.Context) ([]Agent, error) {
+	var body struct {
+		Agent []struct {
+			Name        string     `json:"name"`
+			Type        string     `json:"type"`
+			State       AgentState `json:"state"`
+			QmgrName    string     `json:"qmgrName"`
+			Description string     `json:"description"`
+		} `json:"agent"`
+	}
+	if err := mft.session.getResource(ctx, mftAgentEndpoint, &body); err != nil {
+		return nil, err
+	}
+
+	agents := make([]Agent, len(body.Agent))
+	for index, agent := range body.Agent {
+		agents[index] = Agent(agent)
+	}
+	return agents, nil
+}
+
+// ListMonitors returns every MFT resource monitor, from
+// GET /admin/mft/monitor.
+func (mft *MFT) ListMonitors(ctx context.Context) ([]Monitor, error) {
+	var body struct {
+		Monitor []struct {
+			Name      string `json:"name"`
+			AgentName string `json:"agentName"`
+			State     string `json:"state"`
+			Type      string `json:"type"`
+		} `json:"monitor"`
+	}
+	if err := mft.session.getResource(ctx, mftMonitorEndpoint, &body); err != nil {
+		return nil, err
+	}
+
+	monitors := make([]Monitor, len(body.Monitor))
+	for index, monitor := range body.Monitor {
+		monitors[index] = Monitor(monitor)
+	}
+	return monitors, nil
+}
+
+// ListTransfers returns the transfers mqweb has recorded, from
+// GET /admin/mft/transfer.
+func (mft *MFT) ListTransfers(ctx context.Context) ([]Transfer, error) {
+	return mft.getTransfers(ctx, mftTransferEndpoint)
+}
+
+// Transfer returns one transfer by ID, from GET /admin/mft/transfer/{id}.
+func (mft *MFT) Transfer(ctx context.Context, transferID string) (Transfer, error) {
+	transfers, err := mft.getTransfers(ctx, mftTransferEndpoint+"/"+url.PathEscape(transferID))
+	if err != nil {
+		return Transfer{}, err
+	}
+	if len(transfers) == 0 {
+		return Transfer{}, &ResponseError{ResponseText: "transfer " + transferID + " was not returned", StatusCode: http.StatusOK}
+	}
+	return transfers[0], nil
+}
+
+// CreateTransfer submits a transfer with POST /admin/mft/transfer and
+// returns its ID. The transfer runs asynchronously; use WaitForTransfer to
+// poll it to completion. Transfer creation needs IBM MQ 9.1 or later.
+func (mft *MFT) CreateTransfer(ctx context.Context, request TransferRequest) (string, error) {
+	body, err := json.Marshal(transferRequestBody(request))
+	if err != nil { // coverage-ignore -- the request body only holds strings and booleans
+		return "", err
+	}
+
+	session := mft.session
+	response, err := session.sendRequest(ctx, http.MethodPost, session.restBaseURL+mftTransferEndpoint, body,
+		map[string]string{"Accept": "application/json", "Content-Type": "application/json"})
+	if err != nil {
+		return "", err
+	}
+	if response.StatusCode >= http.StatusBadRequest {
+		return "", &ResponseError{ResponseText: response.Body, StatusCode: response.StatusCode}
+	}
+
+	location := headerValue(response.Headers, "Location")
+	if location == "" {
+		return "", &ResponseError{ResponseText: "transfer created without a Location header", StatusCode: response.StatusCode}
+	}
+	return path.Base(location), nil
+}
+
+// TransferResult describes a transfer polled to completion by
+// WaitForTransfer.
+type TransferResult struct {
+	// Transfer is the transfer's final status. Check Transfer.State to see
+	// whether it succeeded.
+	Transfer Transfer
+	// Polls is the number of status checks performed.
+	Polls int
+	// ElapsedSeconds is the wall-clock time taken.
+	ElapsedSeconds float64
+}
+
+// WaitForTransfer polls a transfer until it finishes, successfully or not,
+// and returns a TimeoutError if it has not finished within config.Timeout.
+// A transfer that mqweb has not yet recorded, as happens just after
+// CreateTransfer, is polled again rather than reported as an error.
+func (mft *MFT) WaitForTransfer(ctx context.Context, transferID string,
+	config SyncConfig,
+) (result TransferResult, err error) {
+	session := mft.session
+	ctx, end := session.observeSync(ctx, SyncTransferred, transferID)
+	defer func() { end(err) }()
+
+	config, err = normalizeSyncConfig(config)
+	if err != nil {
+		return TransferResult{}, err
+	}
+
+	startTime := session.clock.now()
+	for {
+		session.clock.sleep(config.PollInterval)
+
+		transfer, err := mft.Transfer(withSyncPoll(ctx, SyncTransferred, transferID, result.Polls+1), transferID)
+		var responseErr *ResponseError
+		if err != nil && !(errors.As(err, &responseErr) && responseErr.StatusCode == http.StatusNotFound) {
+			return TransferResult{}, err
+		}
+		result.Polls++
+		result.ElapsedSeconds = session.clock.now().Sub(startTime).Seconds()
+
+		if err == nil && transfer.State.Done() {
+			result.Transfer = transfer
+			return result, nil
+		}
+		if result.ElapsedSeconds >= config.Timeout.Seconds() {
+			return result, &TimeoutError{
+				Name:           transferID,
+				Operation:      SyncTransferred,
+				ElapsedSeconds: result.ElapsedSeconds,
+			}
+		}
+	}
+}
+
+// getTransfers reads a transfer resource.
+func (mft *MFT) getTransfers(ctx context.Context, endpoint string) ([]Transfer, error) {
+	var body struct {
+		Transfer []struct {
+			ID     string `json:"id"`
+			Status struct {
+				State       TransferState `json:"state"`
+				Description string        `json:"description"`
+				StartTime   string        `json:"startTime"`
+				EndTime     string        `json:"endTime"`
+			} `json:"status"`
+			SourceAgent      AgentRef `json:"sourceAgent"`
+			DestinationAgent AgentRef `json:"destinationAgent"`
+			Originator       struct {
+				UserID string `json:"userId"`
+			} `json:"originator"`
+			Statistics struct {
+				Files         int `json:"numberOfFiles"`
+				FileSuccesses int `json:"numberOfFileSuccesses"`
+				FileWarnings  int `json:"numberOfFileWarnings"`
+				FileFailures  int `json:"numberOfFileFailures"`
+			} `json:"statistics"`
+		} `json:"transfer"`
+	}
+	if err := mft.session.getResource(ctx, endpoint, &body); err != nil {
+		return nil, err
+	}
+
+	transfers := make([]Transfer, len(body.Transfer))
+	for index, transfer := range body.Transfer {
+		transfers[index] = Transfer{
+			ID:               transfer.ID,
+			State:            transfer.Status.State,
+			Description:      transfer.Status.Description,
+			SourceAgent:      transfer.SourceAgent,
+			DestinationAgent: transfer.DestinationAgent,
+			OriginatorUserID: transfer.Originator.UserID,
+			StartTime:        parseTransferTime(transfer.Status.StartTime),
+			EndTime:          parseTransferTime(transfer.Status.EndTime),
+			Files:            transfer.Statistics.Files,
+			FileSuccesses:    transfer.Statistics.FileSuccesses,
+			FileWarnings:     transfer.Statistics.FileWarnings,
+			FileFailures:     transfer.Statistics.FileFailures,
+		}
+	}
+	return transfers, nil
+}
+
+// parseTransferTime parses an MFT REST API timestamp, returning the zero
+// time when it is empty or not RFC 3339.
+func parseTransferTime(text string) time.Time {
+	parsed, err := time.Parse(time.RFC3339, text)
+	if err != nil {
+		return time.Time{}
+	}
+	return parsed
+}
+
+// transferRequestBody builds the POST /admin/mft/transfer request body.
+func transferRequestBody(request TransferRequest) map[string]any {
+	items := make([]any, len(request.Items))
+	for index, item := range request.Items {
+		mode := "binary"
+		if item.Text {
+			mode = "text"
+		}
+		disposition := "leave"
+		if item.DeleteSource {
+			disposition = "delete"
+		}
+		actionIfExists := "error"
+		if item.Overwrite {
+			actionIfExists = "overwrite"
+		}
+		items[index] = map[string]any{
+			"mode":      mode,
+			"recursive": item.Recursive,
+			"source": map[string]any{
+				"name":        item.Source,
+				"type":        transferItemType(item.SourceType),
+				"disposition": disposition,
+			},
+			"destination": map[string]any{
+				"name":           item.Destination,
+				"type":           transferItemType(item.DestinationType),
+				"actionIfExists": actionIfExists,
+			},
+		}
+	}
+
+	body := map[string]any{
+		"sourceAgent":      request.SourceAgent,
+		"destinationAgent": request.DestinationAgent,
+		"transferSet":      map[string]any{"item": items},
+	}
+	if request.JobName != "" {
+		body["job"] = map[string]any{"name": request.JobName}
+	}
+	return body
+}
+
+// transferItemType defaults an empty transfer item type to "file".
+func transferItemType(itemType string) string {
+	if itemType == "" {
+		return "file"
+	}
+	return itemType
+}
@@ -0,0 +1,232 @@
+package mqrestadmin
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+const transferJSON = `{"transfer":[{"id":"414D5120514D31202020202020202020A1B2C3D4",` +
+	`"status":{"state":"%s","description":"BFGRP0032I","startTime":"2026-10-18T09:30:00.000Z","endTime":"%s"},` +
+	`"sourceAgent":{"name":"AGENT1","qmgrName":"QM1"},"destinationAgent":{"name":"AGENT2","qmgrName":"QM2"},` +
+	`"originator":{"host":"10.0.0.1","userId":"mqadmin"},` +
+	`"statistics":{"numberOfFiles":3,"numberOfFileSuccesses":2,"numberOfFileWarnings":0,"numberOfFileFailures":1}}]}`
+
+func transferBody(state, endTime string) string {
+	return strings.Replace(strings.Replace(transferJSON, "%s", state, 1), "%s", endTime, 1)
+}
+
+func TestMFT_ListAgentsAndMonitors(t *testing.T) {
+	session, transport := newMessagingTestSession()
+	transport.addMessageResponse(200, `{"agent":[{"name":"AGENT1","type":"standard","state":"ready","qmgrName":"QM1"},`+
+		`{"name":"BRIDGE1","type":"bridge","state":"unreachable","qmgrName":"QM2","description":"FTP bridge"}]}`, nil)
+	transport.addMessageResponse(200, `{"monitor":[{"name":"MON1","agentName":"AGENT1","state":"started","type":"directory"}]}`, nil)
+
+	agents, err := session.MFT().ListAgents(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []Agent{
+		{Name: "AGENT1", Type: "standard", State: AgentStateReady, QmgrName: "QM1"},
+		{Name: "BRIDGE1", Type: "bridge", State: AgentStateUnreachable, QmgrName: "QM2", Description: "FTP bridge"},
+	}
+	if !reflect.DeepEqual(agents, want) {
+		t.Errorf("agents = %+v, want %+v", agents, want)
+	}
+	if url := transport.calls[0].URL; url != "https://localhost:9443/ibmmq/rest/v2/admin/mft/agent?attributes=*" {
+		t.Errorf("URL = %q", url)
+	}
+
+	monitors, err := session.MFT().ListMonitors(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(monitors) != 1 || monitors[0] != (Monitor{Name: "MON1", AgentName: "AGENT1", State: "started", Type: "directory"}) {
+		t.Errorf("monitors = %+v", monitors)
+	}
+}
+
+func TestMFT_Transfers(t *testing.T) {
+	session, transport := newMessagingTestSession()
+	transport.addMessageResponse(200, transferBody("partiallySuccessful", "2026-10-18T09:31:15.000Z"), nil)
+	transport.addMessageResponse(200, transferBody("inProgress", ""), nil)
+	transport.addMessageResponse(200, `{"transfer":[]}`, nil)
+
+	transfers, err := session.MFT().ListTransfers(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := Transfer{
+		ID:               "414D5120514D31202020202020202020A1B2C3D4",
+		State:            TransferStatePartiallySuccessful,
+		Description:      "BFGRP0032I",
+		SourceAgent:      AgentRef{Name: "AGENT1", QmgrName: "QM1"},
+		DestinationAgent: AgentRef{Name: "AGENT2", QmgrName: "QM2"},
+		OriginatorUserID: "mqadmin",
+		StartTime:        time.Date(2026, 10, 18, 9, 30, 0, 0, time.UTC),
+		EndTime:          time.Date(2026, 10, 18, 9, 31, 15, 0, time.UTC),
+		Files:            3,
+		FileSuccesses:    2,
+		FileFailures:     1,
+	}
+	if len(transfers) != 1 || !reflect.DeepEqual(transfers[0], want) {
+		t.Errorf("transfers = %+v, want %+v", transfers, want)
+	}
+	if !transfers[0].State.Done() || transfers[0].State.Succeeded() {
+		t.Errorf("state %q misreported", transfers[0].State)
+	}
+
+	transfer, err := session.MFT().Transfer(context.Background(), want.ID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if transfer.State.Done() || !transfer.EndTime.IsZero() {
+		t.Errorf("transfer = %+v, want in progress", transfer)
+	}
+	if url := transport.lastCall().URL; !strings.HasSuffix(url, "/admin/mft/transfer/"+want.ID+"?attributes=*") {
+		t.Errorf("URL = %q", url)
+	}
+
+	var responseErr *ResponseError
+	if _, err := session.MFT().Transfer(context.Background(), want.ID); !errors.As(err, &responseErr) {
+		t.Errorf("error = %v, want ResponseError for a missing transfer", err)
+	}
+}
+
+func TestMFT_CreateTransfer(t *testing.T) {
+	session, transport := newMessagingTestSession()
+	transport.addMessageResponse(202, "", map[string]string{
+		"Location": "https://localhost:9443/ibmmq/rest/v2/admin/mft/transfer/414D5120514D31202020202020202020A1B2C3D4",
+	})
+
+	transferID, err := session.MFT().CreateTransfer(context.Background(), TransferRequest{
+		SourceAgent:      AgentRef{Name: "AGENT1", QmgrName: "QM1"},
+		DestinationAgent: AgentRef{Name: "AGENT2"},
+		Items: []TransferItem{
+			{Source: "/data/out/report.csv", Destination: "/data/in/report.csv", Text: true, Overwrite: true},
+			{Source: "/data/out/archive", Destination: "/data/in", SourceType: "directory",
+				DestinationType: "directory", Recursive: true, DeleteSource: true},
+		},
+		JobName: "nightly-reports",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if transferID != "414D5120514D31202020202020202020A1B2C3D4" {
+		t.Errorf("transferID = %q", transferID)
+	}
+
+	if transport.methods[0] != "POST" || !strings.HasSuffix(transport.lastCall().URL, "/admin/mft/transfer") {
+		t.Errorf("request = %s %s", transport.methods[0], transport.lastCall().URL)
+	}
+	var body map[string]any
+	if err := json.Unmarshal([]byte(transport.bodies[0]), &body); err != nil {
+		t.Fatalf("request body is not JSON: %v", err)
+	}
+	want := map[string]any{
+		"sourceAgent":      map[string]any{"name": "AGENT1", "qmgrName": "QM1"},
+		"destinationAgent": map[string]any{"name": "AGENT2"},
+		"job":              map[string]any{"name": "nightly-reports"},
+		"transferSet": map[string]any{"item": []any{
+			map[string]any{
+				"mode": "text", "recursive": false,
+				"source":      map[string]any{"name": "/data/out/report.csv", "type": "file", "disposition": "leave"},
+				"destination": map[string]any{"name": "/data/in/report.csv", "type": "file", "actionIfExists": "overwrite"},
+			},
+			map[string]any{
+				"mode": "binary", "recursive": true,
+				"source":      map[string]any{"name": "/data/out/archive", "type": "directory", "disposition": "delete"},
+				"destination": map[string]any{"name": "/data/in", "type": "directory", "actionIfExists": "error"},
+			},
+		}},
+	}
+	if !reflect.DeepEqual(body, want) {
+		t.Errorf("body = %v\nwant %v", body, want)
+	}
+}
+
+func TestMFT_CreateTransferErrors(t *testing.T) {
+	session, transport := newMessagingTestSession()
+	transport.addMessageResponse(400, `{"error":[{"msgId":"MQWB0101E"}]}`, nil)
+	transport.addMessageResponse(202, "", nil)
+	transport.addErrorResponse(errors.New("connection refused"))
+	request := TransferRequest{SourceAgent: AgentRef{Name: "AGENT1"}, DestinationAgent: AgentRef{Name: "AGENT2"}}
+
+	var responseErr *ResponseError
+	if _, err := session.MFT().CreateTransfer(context.Background(), request); !errors.As(err, &responseErr) ||
+		responseErr.StatusCode != 400 {
+		t.Errorf("error = %v, want 400 ResponseError", err)
+	}
+	if _, err := session.MFT().CreateTransfer(context.Background(), request); err == nil ||
+		!strings.Contains(err.Error(), "Location") {
+		t.Errorf("error = %v, want missing Location", err)
+	}
+	if _, err := session.MFT().CreateTransfer(context.Background(), request); err == nil {
+		t.Error("expected transport error")
+	}
+}
+
+func TestMFT_WaitForTransfer(t *testing.T) {
+	session, transport := newMessagingTestSession()
+	session.clock = newMockClock()
+	transport.addMessageResponse(404, `{"error":[{"msgId":"MQWB0105E"}]}`, nil)
+	transport.addMessageResponse(200, transferBody("started", ""), nil)
+	transport.addMessageResponse(200, transferBody("successful", "2026-10-18T09:31:15.000Z"), nil)
+
+	result, err := session.MFT().WaitForTransfer(context.Background(), "414D5120514D31202020202020202020A1B2C3D4",
+		SyncConfig{Timeout: 30 * time.Second, PollInterval: 2 * time.Second})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !result.Transfer.State.Succeeded() || result.Polls != 3 || result.ElapsedSeconds != 6 {
+		t.Errorf("result = %+v", result)
+	}
+}
+
+func TestMFT_WaitForTransferTimeoutAndErrors(t *testing.T) {
+	session, transport := newMessagingTestSession()
+	session.clock = newMockClock()
+	transport.addMessageResponse(200, transferBody("inProgress", ""), nil)
+	transport.addMessageResponse(200, transferBody("inProgressWithWarnings", ""), nil)
+
+	result, err := session.MFT().WaitForTransfer(context.Background(), "T1",
+		SyncConfig{Timeout: 2 * time.Second, PollInterval: time.Second})
+	var timeoutErr *TimeoutError
+	if !errors.As(err, &timeoutErr) || timeoutErr.Operation != SyncTransferred || timeoutErr.Name != "T1" {
+		t.Fatalf("error = %v, want transfer TimeoutError", err)
+	}
+	if result.Polls != 2 || err.Error() != "mqrestadmin timeout: transferred T1 after 2.0s" {
+		t.Errorf("result = %+v, error = %q", result, err.Error())
+	}
+
+	if _, err := session.MFT().WaitForTransfer(context.Background(), "T1", SyncConfig{PollInterval: -1}); err == nil {
+		t.Error("expected error for negative poll interval")
+	}
+
+	transport.addMessageResponse(403, "", nil)
+	var authErr *AuthError
+	if _, err := session.MFT().WaitForTransfer(context.Background(), "T1", SyncConfig{}); !errors.As(err, &authErr) {
+		t.Errorf("error = %v, want AuthError", err)
+	}
+}
+
+func TestMFT_ResourceErrors(t *testing.T) {
+	session, transport := newMessagingTestSession()
+	transport.addMessageResponse(500, "error", nil)
+	transport.addMessageResponse(500, "error", nil)
+	transport.addMessageResponse(500, "error", nil)
+
+	if _, err := session.MFT().ListAgents(context.Background()); err == nil {
+		t.Error("expected ListAgents error")
+	}
+	if _, err := session.MFT().ListMonitors(context.Background()); err == nil {
+		t.Error("expected ListMonitors error")
+	}
+	if _, err := session.MFT().ListTransfers(context.Background()); err == nil {
+		t.Error("expected ListTransfers error")
+	}
+}
@@ -0,0 +1,362 @@
+package mqrestadmin
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"io"
+	"log/slog"
+	"maps"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Middleware wraps a Transport to add cross-cutting behaviour such as
+// logging, metrics or fault injection. Middlewares are installed with
+// WithMiddleware; most are built with NewMiddleware, which hands them a
+// TransportRequest envelope instead of the raw Transport arguments.
+type Middleware func(Transport) Transport
+
+// WithMiddleware adds middlewares around the session's transport. The
+// first middleware is outermost: it sees each request first and each
+// response last. Middlewares wrap the transport set with WithTransport or
+// the default HTTPTransport, and apply to every request the session sends,
+// including LTPA login and Messaging requests.
+func WithMiddleware(middlewares ...Middleware) Option {
+	return func(config *sessionConfig) {
+		config.middlewares = append(config.middlewares, middlewares...)
+	}
+}
+
+// applyMiddlewares wraps transport so the first middleware is outermost.
+func applyMiddlewares(transport Transport, middlewares []Middleware) Transport {
+	for index := len(middlewares) - 1; index >= 0; index-- {
+		transport = middlewares[index](transport)
+	}
+	return transport
+}
+
+// TransportRequest is the typed envelope a NewMiddleware round trip
+// receives. Command, Qualifier and Name describe the MQSC command an admin
+// request carries and are empty for other requests.
+type TransportRequest struct {
+	// Method is the HTTP method: POST for admin commands, and the Messaging
+	// or resource method for RequestTransport requests.
+	Method string
+	// URL is the full request URL.
+	URL string
+	// Headers holds the request headers. Middlewares may modify it.
+	Headers map[string]string
+	// Payload is the JSON payload of a PostJSON or PostJSONStream request,
+	// or nil for a RequestTransport request.
+	Payload map[string]any
+	// Body is the raw body of a RequestTransport request, or nil.
+	Body []byte
+	// Timeout is the request timeout.
+	Timeout time.Duration
+	// VerifyTLS reports whether the server certificate is verified.
+	VerifyTLS bool
+	// Command is the MQSC command verb, such as "DISPLAY".
+	Command string
+	// Qualifier is the MQSC object qualifier, such as "QLOCAL".
+	Qualifier string
+	// Name is the MQSC object name, such as "APP.*", or empty.
+	Name string
+}
+
+// RoundTrip sends a TransportRequest and returns its response.
+type RoundTrip func(ctx context.Context, request *TransportRequest) (*TransportResponse, error)
+
+// NewMiddleware builds a Middleware from a function that wraps the next
+// round trip in the chain. The resulting transport implements
+// StreamingTransport and RequestTransport whenever the transport it wraps
+// does. For streamed requests the response passed back through the chain
+// carries the status code and headers but not the body, which the caller
+// still reads as a stream; a middleware that returns its own response
+// without calling next supplies the body in full.
+func NewMiddleware(wrap func(next RoundTrip) RoundTrip) Middleware {
+	return func(transport Transport) Transport {
+		base := &middlewareTransport{next: transport, wrap: wrap}
+		_, streaming := transport.(StreamingTransport)
+		_, requests := transport.(RequestTransport)
+		switch {
+		case streaming && requests:
+			return &fullMiddlewareTransport{base}
+		case streaming:
+			return &streamingMiddlewareTransport{base}
+		case requests:
+			return &requestMiddlewareTransport{base}
+		default:
+			return base
+		}
+	}
+}
+
+type middlewareTransport struct {
+	next Transport
+	wrap func(next RoundTrip) RoundTrip
+}
+
+type streamingMiddlewareTransport struct{ *middlewareTransport }
+
+type requestMiddlewareTransport struct{ *middlewareTransport }
+
+type fullMiddlewareTransport struct{ *middlewareTransport }
+
+// PostJSON sends a JSON POST request through the middleware.
+func (transport *middlewareTransport) PostJSON(ctx context.Context, url string,
+	payload map[string]any, headers map[string]string, timeout time.Duration,
+	verifyTLS bool,
+) (*TransportResponse, error) {
+	request := newTransportRequest(http.MethodPost, url, headers, timeout, verifyTLS)
+	request.Payload = payload
+	request.Command, request.Qualifier, request.Name = describePayload(payload)
+
+	return transport.wrap(func(ctx context.Context, request *TransportRequest) (*TransportResponse, error) {
+		return transport.next.PostJSON(ctx, request.URL, request.Payload, request.Headers, request.Timeout, request.VerifyTLS)
+	})(ctx, request)
+}
+
+func (transport *middlewareTransport) postJSONStream(ctx context.Context, url string,
+	payload map[string]any, headers map[string]string, timeout time.Duration,
+	verifyTLS bool,
+) (*StreamingResponse, error) {
+	request := newTransportRequest(http.MethodPost, url, headers, timeout, verifyTLS)
+	request.Payload = payload
+	request.Command, request.Qualifier, request.Name = describePayload(payload)
+
+	var stream *StreamingResponse
+	response, err := transport.wrap(func(ctx context.Context, request *TransportRequest) (*TransportResponse, error) {
+		var err error
+		stream, err = transport.next.(StreamingTransport).PostJSONStream(ctx, request.URL, request.Payload,
+			request.Headers, request.Timeout, request.VerifyTLS)
+		if err != nil {
+			return nil, err
+		}
+		return &TransportResponse{StatusCode: stream.StatusCode, Headers: stream.Headers}, nil
+	})(ctx, request)
+	if err != nil {
+		if stream != nil {
+			_ = stream.Body.Close()
+		}
+		return nil, err
+	}
+	if stream != nil {
+		return stream, nil
+	}
+	return &StreamingResponse{
+		StatusCode: response.StatusCode,
+		Body:       io.NopCloser(strings.NewReader(response.Body)),
+		Headers:    response.Headers,
+	}, nil
+}
+
+func (transport *middlewareTransport) sendRequest(ctx context.Context, method, url string, body []byte,
+	headers map[string]string, timeout time.Duration, verifyTLS bool,
+) (*TransportResponse, error) {
+	request := newTransportRequest(method, url, headers, timeout, verifyTLS)
+	request.Body = body
+
+	return transport.wrap(func(ctx context.Context, request *TransportRequest) (*TransportResponse, error) {
+		return transport.next.(RequestTransport).SendRequest(ctx, request.Method, request.URL, request.Body,
+			request.Headers, request.Timeout, request.VerifyTLS)
+	})(ctx, request)
+}
+
+// PostJSONStream sends a streamed JSON POST request through the middleware.
+func (transport *streamingMiddlewareTransport) PostJSONStream(ctx context.Context, url string,
+	payload map[string]any, headers map[string]string, timeout time.Duration,
+	verifyTLS bool,
+) (*StreamingResponse, error) {
+	return transport.postJSONStream(ctx, url, payload, headers, timeout, verifyTLS)
+}
+
+// SendRequest sends a request through the middleware.
+func (transport *requestMiddlewareTransport) SendRequest(ctx context.Context, method, url string, body []byte,
+	headers map[string]string, timeout time.Duration, verifyTLS bool,
+) (*TransportResponse, error) {
+	return transport.sendRequest(ctx, method, url, body, headers, timeout, verifyTLS)
+}
+
+// PostJSONStream sends a streamed JSON POST request through the middleware.
+func (transport *fullMiddlewareTransport) PostJSONStream(ctx context.Context, url string,
+	payload map[string]any, headers map[string]string, timeout time.Duration,
+	verifyTLS bool,
+) (*StreamingResponse, error) {
+	return transport.postJSONStream(ctx, url, payload, headers, timeout, verifyTLS)
+}
+
+// SendRequest sends a request through the middleware.
+func (transport *fullMiddlewareTransport) SendRequest(ctx context.Context, method, url string, body []byte,
+	headers map[string]string, timeout time.Duration, verifyTLS bool,
+) (*TransportResponse, error) {
+	return transport.sendRequest(ctx, method, url, body, headers, timeout, verifyTLS)
+}
+
+// newTransportRequest builds an envelope with a copy of headers, so
+// middlewares can modify them without affecting the caller.
+func newTransportRequest(method, url string, headers map[string]string, timeout time.Duration,
+	verifyTLS bool,
+) *TransportRequest {
+	requestHeaders := make(map[string]string, len(headers))
+	maps.Copy(requestHeaders, headers)
+	return &TransportRequest{
+		Method:    method,
+		URL:       url,
+		Headers:   requestHeaders,
+		Timeout:   timeout,
+		VerifyTLS: verifyTLS,
+	}
+}
+
+// describePayload returns the command, qualifier and name of an admin
+// request payload: the runCommandJSON fields, or the leading words of a
+// runCommand MQSC string such as "DISPLAY QLOCAL(APP.*)".
+func describePayload(payload map[string]any) (command, qualifier, name string) {
+	switch payload["type"] {
+	case "runCommandJSON":
+		command, _ = payload["command"].(string)
+		qualifier, _ = payload["qualifier"].(string)
+		name, _ = payload["name"].(string)
+	case "runCommand":
+		parameters, _ := payload["parameters"].(map[string]any)
+		text, _ := parameters["command"].(string)
+		fields := strings.Fields(text)
+		if len(fields) > 0 {
+			command = strings.ToUpper(fields[0])
+		}
+		if len(fields) > 1 {
+			var rest string
+			qualifier, rest, _ = strings.Cut(fields[1], "(")
+			qualifier = strings.ToUpper(qualifier)
+			name, _, _ = strings.Cut(rest, ")")
+		}
+	}
+	return command, qualifier, name
+}
+
+// LoggingMiddleware logs each request's method, URL, command, status and
+// duration to logger: at Debug level on success and Warn level when the
+// request fails or returns an HTTP error status. Headers and bodies are not
+// logged.
+func LoggingMiddleware(logger *slog.Logger) Middleware {
+	return NewMiddleware(func(next RoundTrip) RoundTrip {
+		return func(ctx context.Context, request *TransportRequest) (*TransportResponse, error) {
+			start := time.Now()
+			response, err := next(ctx, request)
+			attributes := []slog.Attr{
+				slog.String("method", request.Method),
+				slog.String("url", request.URL),
+				slog.Duration("duration", time.Since(start)),
+			}
+			if request.Command != "" {
+				attributes = append(attributes, slog.String("command",
+					strings.TrimSpace(request.Command+" "+request.Qualifier+" "+request.Name)))
+			}
+			level := slog.LevelDebug
+			if err != nil {
+				level = slog.LevelWarn
+				attributes = append(attributes, slog.String("error", err.Error()))
+			} else {
+				attributes = append(attributes, slog.Int("status", response.StatusCode))
+				if response.StatusCode >= http.StatusBadRequest {
+					level = slog.LevelWarn
+				}
+			}
+			logger.LogAttrs(ctx, level, "mqrestadmin request", attributes...)
+			return response, err
+		}
+	})
+}
+
+// HeaderMiddleware adds headers to every request, replacing any the
+// session set with the same name.
+func HeaderMiddleware(headers map[string]string) Middleware {
+	headers = maps.Clone(headers)
+	return NewMiddleware(func(next RoundTrip) RoundTrip {
+		return func(ctx context.Context, request *TransportRequest) (*TransportResponse, error) {
+			maps.Copy(request.Headers, headers)
+			return next(ctx, request)
+		}
+	})
+}
+
+// DefaultRequestIDHeader is the header RequestIDMiddleware sets when given
+// an empty header name.
+const DefaultRequestIDHeader = "X-Request-ID"
+
+type requestIDKey struct{}
+
+// WithRequestID returns a context carrying a request ID for
+// RequestIDMiddleware to send with every request made using it.
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, requestID)
+}
+
+// RequestIDFromContext returns the request ID set with WithRequestID, or
+// empty when there is none.
+func RequestIDFromContext(ctx context.Context) string {
+	requestID, _ := ctx.Value(requestIDKey{}).(string)
+	return requestID
+}
+
+// RequestIDMiddleware sends a request ID in the named header, or
+// DefaultRequestIDHeader when header is empty. The ID comes from the
+// context (see WithRequestID); requests without one get a random ID,
+// which is also passed to the inner round trip's context so later
+// middlewares can log it.
+func RequestIDMiddleware(header string) Middleware {
+	if header == "" {
+		header = DefaultRequestIDHeader
+	}
+	return NewMiddleware(func(next RoundTrip) RoundTrip {
+		return func(ctx context.Context, request *TransportRequest) (*TransportResponse, error) {
+			requestID := RequestIDFromContext(ctx)
+			if requestID == "" {
+				requestID = newRequestID()
+				ctx = WithRequestID(ctx, requestID)
+			}
+			request.Headers[header] = requestID
+			return next(ctx, request)
+		}
+	})
+}
+
+// newRequestID returns a random 128-bit hexadecimal ID.
+func newRequestID() string {
+	id := make([]byte, 16)
+	_, _ = rand.Read(id)
+	return hex.EncodeToString(id)
+}
+
+// LatencyMiddleware calls observe after each request with the time it
+// took and its error, if any. For streamed requests the time covers
+// receiving the response headers, not reading the body.
+func LatencyMiddleware(observe func(request *TransportRequest, duration time.Duration, err error)) Middleware {
+	return NewMiddleware(func(next RoundTrip) RoundTrip {
+		return func(ctx context.Context, request *TransportRequest) (*TransportResponse, error) {
+			start := time.Now()
+			response, err := next(ctx, request)
+			observe(request, time.Since(start), err)
+			return response, err
+		}
+	})
+}
+
+// FaultInjectionMiddleware calls inject before each request. When inject
+// returns a response or an error, the request is not sent and the
+// response or error is returned instead, which lets tests exercise error
+// handling against a real transport. When inject returns nil, nil the
+// request is sent normally.
+func FaultInjectionMiddleware(inject func(request *TransportRequest) (*TransportResponse, error)) Middleware {
+	return NewMiddleware(func(next RoundTrip) RoundTrip {
+		return func(ctx context.Context, request *TransportRequest) (*TransportResponse, error) {
+			response, err := inject(request)
+			if response != nil || err != nil {
+				return response, err
+			}
+			return next(ctx, request)
+		}
+	})
+}
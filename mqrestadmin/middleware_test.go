@@ -0,0 +1,338 @@
+package mqrestadmin
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newMiddlewareTestSession(t *testing.T, transport Transport, middlewares ...Middleware) *Session {
+	t.Helper()
+	session, err := NewSession("https://localhost:9443/ibmmq/rest/v2", "QM1",
+		BasicAuth{Username: "admin", Password: "admin"},
+		WithTransport(transport), WithMapAttributes(false), WithMiddleware(middlewares...))
+	if err != nil {
+		t.Fatalf("NewSession: %v", err)
+	}
+	return session
+}
+
+func TestWithMiddleware_Order(t *testing.T) {
+	var order []string
+	tracing := func(name string) Middleware {
+		return NewMiddleware(func(next RoundTrip) RoundTrip {
+			return func(ctx context.Context, request *TransportRequest) (*TransportResponse, error) {
+				order = append(order, name+" request")
+				response, err := next(ctx, request)
+				order = append(order, name+" response")
+				return response, err
+			}
+		})
+	}
+	transport := newMockTransport()
+	transport.addSuccessResponse()
+	session := newMiddlewareTestSession(t, transport, tracing("outer"), tracing("inner"))
+
+	if _, err := session.DisplayQueue(context.Background(), "*"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := "outer request,inner request,inner response,outer response"
+	if got := strings.Join(order, ","); got != want {
+		t.Errorf("order = %s, want %s", got, want)
+	}
+}
+
+func TestNewMiddleware_Envelope(t *testing.T) {
+	var requests []TransportRequest
+	recording := NewMiddleware(func(next RoundTrip) RoundTrip {
+		return func(ctx context.Context, request *TransportRequest) (*TransportResponse, error) {
+			requests = append(requests, *request)
+			return next(ctx, request)
+		}
+	})
+	transport := newMockTransport()
+	transport.addSuccessResponse()
+	transport.addSuccessResponse()
+	session := newMiddlewareTestSession(t, transport, recording)
+
+	if _, err := session.DisplayQueue(context.Background(), "APP.*"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := session.RunMQSC(context.Background(), "display qlocal(APP.IN) curdepth"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(requests) != 2 {
+		t.Fatalf("recorded %d requests, want 2", len(requests))
+	}
+	describe := func(request TransportRequest) string {
+		return strings.Join([]string{request.Method, request.Command, request.Qualifier, request.Name}, " ")
+	}
+	first := requests[0]
+	if got := describe(first); got != "POST DISPLAY QUEUE APP.*" {
+		t.Errorf("request = %s", got)
+	}
+	if !strings.HasSuffix(first.URL, "/admin/action/qmgr/QM1/mqsc") || first.Timeout != defaultTimeout ||
+		!first.VerifyTLS || first.Headers["Authorization"] == "" || first.Payload["type"] != "runCommandJSON" {
+		t.Errorf("request = %+v", first)
+	}
+	if got := describe(requests[1]); got != "POST DISPLAY QLOCAL APP.IN" {
+		t.Errorf("MQSC request = %s", got)
+	}
+}
+
+func TestNewMiddleware_PreservesTransportExtensions(t *testing.T) {
+	identity := NewMiddleware(func(next RoundTrip) RoundTrip { return next })
+	tests := []struct {
+		name      string
+		transport Transport
+		streaming bool
+		requests  bool
+	}{
+		{"plain", newMockTransport(), false, false},
+		{"streaming", &streamingMockTransport{}, true, false},
+		{"requests", &mockRequestTransport{mockTransport: newMockTransport()}, false, true},
+		{"http", &HTTPTransport{}, true, true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			wrapped := identity(test.transport)
+			if _, streaming := wrapped.(StreamingTransport); streaming != test.streaming {
+				t.Errorf("StreamingTransport = %v, want %v", streaming, test.streaming)
+			}
+			if _, requests := wrapped.(RequestTransport); requests != test.requests {
+				t.Errorf("RequestTransport = %v, want %v", requests, test.requests)
+			}
+		})
+	}
+}
+
+func TestNewMiddleware_HTTPTransport(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Tenant") != "payments" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		if r.Method == http.MethodGet {
+			_, _ = io.WriteString(w, `{"installation":[{"name":"Installation1","version":"9.4.0.0"}]}`)
+			return
+		}
+		_, _ = io.WriteString(w, streamBody(streamItem(`{"QUEUE":"Q1"}`)))
+	}))
+	defer server.Close()
+
+	session, err := NewSession(server.URL, "QM1", BasicAuth{Username: "u", Password: "p"},
+		WithVerifyTLS(false), WithMapAttributes(false),
+		WithMiddleware(HeaderMiddleware(map[string]string{"X-Tenant": "payments"})))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	rows, err := collectSeq(t, session.DisplayQueueSeq(context.Background(), "*"))
+	if err != nil || len(rows) != 1 || rows[0]["QUEUE"] != "Q1" {
+		t.Errorf("rows = %v, %v", rows, err)
+	}
+	installations, err := session.Installations(context.Background())
+	if err != nil || len(installations) != 1 {
+		t.Errorf("installations = %v, %v", installations, err)
+	}
+}
+
+func TestNewMiddleware_Streaming(t *testing.T) {
+	var statuses []int
+	observing := NewMiddleware(func(next RoundTrip) RoundTrip {
+		return func(ctx context.Context, request *TransportRequest) (*TransportResponse, error) {
+			response, err := next(ctx, request)
+			if err == nil {
+				statuses = append(statuses, response.StatusCode)
+			}
+			return response, err
+		}
+	})
+	transport := &streamingMockTransport{statusCode: 200, body: streamBody(streamItem(`{"queue":"Q1"}`))}
+	session := newMiddlewareTestSession(t, transport, observing)
+
+	rows, err := collectSeq(t, session.DisplayQueueSeq(context.Background(), "*"))
+	if err != nil || len(rows) != 1 || rows[0]["queue"] != "Q1" {
+		t.Fatalf("rows = %v, %v", rows, err)
+	}
+	if !transport.closed || len(statuses) != 1 || statuses[0] != 200 {
+		t.Errorf("closed = %v, statuses = %v", transport.closed, statuses)
+	}
+
+	failing := newMiddlewareTestSession(t, &failingStreamTransport{}, observing)
+	var transportErr *TransportError
+	if _, err := collectSeq(t, failing.DisplayQueueSeq(context.Background(), "*")); !errors.As(err, &transportErr) {
+		t.Errorf("error = %v, want TransportError", err)
+	}
+}
+
+func TestNewMiddleware_StreamingRejected(t *testing.T) {
+	rejecting := NewMiddleware(func(next RoundTrip) RoundTrip {
+		return func(ctx context.Context, request *TransportRequest) (*TransportResponse, error) {
+			if _, err := next(ctx, request); err != nil {
+				return nil, err
+			}
+			return nil, errors.New("rejected")
+		}
+	})
+	transport := &streamingMockTransport{statusCode: 200, body: streamBody()}
+	session := newMiddlewareTestSession(t, transport, rejecting)
+
+	if _, err := collectSeq(t, session.DisplayQueueSeq(context.Background(), "*")); err == nil {
+		t.Error("expected middleware error")
+	}
+	if !transport.closed {
+		t.Error("stream was not closed after the middleware failed")
+	}
+}
+
+func TestFaultInjectionMiddleware(t *testing.T) {
+	count := 0
+	faults := FaultInjectionMiddleware(func(request *TransportRequest) (*TransportResponse, error) {
+		count++
+		switch count {
+		case 1:
+			return nil, errors.New("injected")
+		case 2:
+			return &TransportResponse{StatusCode: 503, Body: "unavailable"}, nil
+		case 3:
+			return &TransportResponse{StatusCode: 200, Body: streamBody(streamItem(`{"queue":"FAKE"}`))}, nil
+		}
+		return nil, nil
+	})
+	transport := &streamingMockTransport{statusCode: 200, body: streamBody(streamItem(`{"queue":"REAL"}`))}
+	session := newMiddlewareTestSession(t, transport, faults)
+
+	if _, err := session.DisplayQueue(context.Background(), "*"); err == nil || err.Error() != "injected" {
+		t.Errorf("error = %v, want injected error", err)
+	}
+	var responseErr *ResponseError
+	if _, err := session.DisplayQueue(context.Background(), "*"); !errors.As(err, &responseErr) {
+		t.Errorf("error = %v, want ResponseError for injected 503", err)
+	}
+	rows, err := collectSeq(t, session.DisplayQueueSeq(context.Background(), "*"))
+	if err != nil || len(rows) != 1 || rows[0]["queue"] != "FAKE" {
+		t.Errorf("rows = %v, %v, want injected response", rows, err)
+	}
+	rows, err = collectSeq(t, session.DisplayQueueSeq(context.Background(), "*"))
+	if err != nil || len(rows) != 1 || rows[0]["queue"] != "REAL" {
+		t.Errorf("rows = %v, %v, want real response", rows, err)
+	}
+}
+
+func TestHeaderAndRequestIDMiddleware(t *testing.T) {
+	transport := &mockRequestTransport{mockTransport: newMockTransport()}
+	transport.addSuccessResponse()
+	transport.addMessageResponse(200, `{"installation":[]}`, nil)
+	headers := map[string]string{"X-Tenant": "payments", "Authorization": "Bearer token"}
+	session := newMiddlewareTestSession(t, transport, HeaderMiddleware(headers), RequestIDMiddleware(""))
+	headers["X-Tenant"] = "changed"
+
+	ctx := WithRequestID(context.Background(), "req-42")
+	if _, err := session.DisplayQmgr(ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	call := transport.lastCall()
+	if call.Headers["X-Tenant"] != "payments" || call.Headers["Authorization"] != "Bearer token" ||
+		call.Headers[DefaultRequestIDHeader] != "req-42" {
+		t.Errorf("headers = %v", call.Headers)
+	}
+
+	if _, err := session.Installations(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if requestID := transport.lastCall().Headers[DefaultRequestIDHeader]; len(requestID) != 32 {
+		t.Errorf("generated request ID = %q, want 32 hex digits", requestID)
+	}
+	if transport.methods[0] != "GET" {
+		t.Errorf("method = %s, want GET", transport.methods[0])
+	}
+}
+
+func TestRequestIDMiddleware_CustomHeader(t *testing.T) {
+	var seen string
+	transport := newMockTransport()
+	transport.addSuccessResponse()
+	session := newMiddlewareTestSession(t, transport, RequestIDMiddleware("X-Correlation-ID"),
+		NewMiddleware(func(next RoundTrip) RoundTrip {
+			return func(ctx context.Context, request *TransportRequest) (*TransportResponse, error) {
+				seen = RequestIDFromContext(ctx)
+				return next(ctx, request)
+			}
+		}))
+
+	if _, err := session.DisplayQmgr(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if header := transport.lastCall().Headers["X-Correlation-ID"]; header == "" || header != seen {
+		t.Errorf("header = %q, context ID = %q", header, seen)
+	}
+}
+
+func TestLatencyMiddleware(t *testing.T) {
+	var observed []string
+	transport := newMockTransport()
+	transport.addSuccessResponse()
+	transport.addErrorResponse(errors.New("connection refused"))
+	session := newMiddlewareTestSession(t, transport,
+		LatencyMiddleware(func(request *TransportRequest, duration time.Duration, err error) {
+			if duration < 0 {
+				t.Errorf("duration = %v", duration)
+			}
+			observed = append(observed, request.Qualifier+":"+errorText(err))
+		}))
+
+	_, _ = session.DisplayQmgr(context.Background())
+	_, _ = session.DisplayQueue(context.Background(), "*")
+	if got := strings.Join(observed, ","); got != "QMGR:,QUEUE:connection refused" {
+		t.Errorf("observed = %s", got)
+	}
+}
+
+func errorText(err error) string {
+	if err == nil {
+		return ""
+	}
+	return err.Error()
+}
+
+func TestLoggingMiddleware(t *testing.T) {
+	var output bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&output, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	transport := &mockRequestTransport{mockTransport: newMockTransport()}
+	transport.addSuccessResponse()
+	transport.addMessageResponse(500, "error", nil)
+	transport.addErrorResponse(errors.New("connection refused"))
+	session := newMiddlewareTestSession(t, transport, LoggingMiddleware(logger))
+
+	_, _ = session.DisplayQueue(context.Background(), "APP.*")
+	_, _ = session.ListQmgrs(context.Background())
+	_, _ = session.DisplayQmgr(context.Background())
+
+	lines := strings.Split(strings.TrimSpace(output.String()), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("logged %d lines, want 3:\n%s", len(lines), output.String())
+	}
+	if !strings.Contains(lines[0], "level=DEBUG") || !strings.Contains(lines[0], `command="DISPLAY QUEUE APP.*"`) ||
+		!strings.Contains(lines[0], "status=200") {
+		t.Errorf("success line = %s", lines[0])
+	}
+	if !strings.Contains(lines[1], "level=WARN") || !strings.Contains(lines[1], "method=GET") ||
+		!strings.Contains(lines[1], "status=500") || strings.Contains(lines[1], "command=") {
+		t.Errorf("HTTP error line = %s", lines[1])
+	}
+	if !strings.Contains(lines[2], "level=WARN") || !strings.Contains(lines[2], `error="connection refused"`) {
+		t.Errorf("transport error line = %s", lines[2])
+	}
+	if strings.Contains(output.String(), "Basic ") {
+		t.Error("log output contains credentials")
+	}
+}
@@ -81,6 +81,19 @@ func (transport *mockTransport) addCommandErrorResponse(completionCode, reasonCo
 	transport.addResponse(200, body, nil)
 }
 
+// addItemErrorResponse queues a failed MQSC response whose single
+// commandResponse item carries reasonCode, as the REST API reports a
+// failed command.
+func (transport *mockTransport) addItemErrorResponse(reasonCode int) {
+	transport.addResponse(200, map[string]any{
+		"overallCompletionCode": float64(2),
+		"overallReasonCode":     float64(3008),
+		"commandResponse": []any{
+			map[string]any{"completionCode": float64(2), "reasonCode": float64(reasonCode)},
+		},
+	}, nil)
+}
+
 func (transport *mockTransport) PostJSON(_ context.Context, url string, payload map[string]any,
 	headers map[string]string, timeout time.Duration, verifyTLS bool,
 ) (*TransportResponse, error) {
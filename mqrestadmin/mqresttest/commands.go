@@ -0,0 +1,426 @@
+package mqresttest
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// objectKind describes the objects an MQSC qualifier names.
+type objectKind struct {
+	// namespace is the kind of object, shared by the queue types, which
+	// cannot have the same name as each other.
+	namespace string
+	// nameKey is the attribute holding the object name.
+	nameKey string
+	// typeKey is the attribute holding the object type, if any, such as
+	// TYPE for queues and CHLTYPE for channels.
+	typeKey string
+	// typeValue is the type the qualifier names, such as QLOCAL, or empty
+	// when the type comes from the typeKey parameter.
+	typeValue string
+	// displayOnly marks a qualifier that only DISPLAY takes, such as QUEUE.
+	displayOnly bool
+	// defaults holds the attributes of a new object.
+	defaults map[string]any
+}
+
+var (
+	queueDefaults = map[string]any{"DESCR": "", "PUT": "ENABLED", "DEFPSIST": "NO", "CRDATE": "", "CRTIME": ""}
+
+	objectKinds = map[string]objectKind{
+		"QUEUE": {namespace: "QUEUE", nameKey: "QUEUE", typeKey: "TYPE", displayOnly: true},
+		"QLOCAL": {namespace: "QUEUE", nameKey: "QUEUE", typeKey: "TYPE", typeValue: "QLOCAL",
+			defaults: withDefaults(queueDefaults, map[string]any{
+				"GET": "ENABLED", "MAXDEPTH": 5000, "MAXMSGL": 4194304, "USAGE": "NORMAL", "MONQ": "QMGR",
+				"CURDEPTH": 0, "IPPROCS": 0, "OPPROCS": 0,
+			})},
+		"QREMOTE": {namespace: "QUEUE", nameKey: "QUEUE", typeKey: "TYPE", typeValue: "QREMOTE",
+			defaults: withDefaults(queueDefaults, map[string]any{"RNAME": "", "RQMNAME": "", "XMITQ": ""})},
+		"QALIAS": {namespace: "QUEUE", nameKey: "QUEUE", typeKey: "TYPE", typeValue: "QALIAS",
+			defaults: withDefaults(queueDefaults, map[string]any{"GET": "ENABLED", "TARGET": "", "TARGTYPE": "QUEUE"})},
+		"QMODEL": {namespace: "QUEUE", nameKey: "QUEUE", typeKey: "TYPE", typeValue: "QMODEL",
+			defaults: withDefaults(queueDefaults, map[string]any{
+				"GET": "ENABLED", "MAXDEPTH": 5000, "MAXMSGL": 4194304, "DEFTYPE": "PREDEFINED",
+			})},
+		"CHANNEL": {namespace: "CHANNEL", nameKey: "CHANNEL", typeKey: "CHLTYPE",
+			defaults: map[string]any{"DESCR": "", "TRPTYPE": "TCP", "MAXMSGL": 4194304, "HBINT": 300}},
+		"TOPIC": {namespace: "TOPIC", nameKey: "TOPIC", defaults: map[string]any{
+			"DESCR": "", "TYPE": "LOCAL", "TOPICSTR": "", "PUB": "ASPARENT", "SUB": "ASPARENT",
+			"DURSUB": "ASPARENT", "DEFPSIST": "ASPARENT",
+		}},
+		"SUB": {namespace: "SUB", nameKey: "SUB", defaults: map[string]any{
+			"TOPICSTR": "", "TOPICOBJ": "", "DEST": "", "DESTQMGR": "", "DURABLE": "YES", "SUBTYPE": "ADMIN",
+			"CRDATE": "", "CRTIME": "",
+		}},
+		"LISTENER": {namespace: "LISTENER", nameKey: "LISTENER", defaults: map[string]any{
+			"DESCR": "", "TRPTYPE": "TCP", "PORT": 0, "IPADDR": "", "BACKLOG": 0, "CONTROL": "MANUAL",
+		}},
+		"SERVICE": {namespace: "SERVICE", nameKey: "SERVICE", defaults: map[string]any{
+			"DESCR": "", "CONTROL": "MANUAL", "SERVTYPE": "COMMAND", "STARTCMD": "", "STARTARG": "",
+			"STOPCMD": "", "STOPARG": "", "STDOUT": "", "STDERR": "",
+		}},
+		"NAMELIST": {namespace: "NAMELIST", nameKey: "NAMELIST", defaults: map[string]any{
+			"DESCR": "", "NAMES": []any{}, "NAMCOUNT": 0,
+		}},
+		"PROCESS": {namespace: "PROCESS", nameKey: "PROCESS", defaults: map[string]any{
+			"DESCR": "", "APPLTYPE": "UNIX", "APPLICID": "", "USERDATA": "", "ENVRDATA": "",
+		}},
+	}
+)
+
+// commandKeywords are request parameters that control a command rather
+// than set attributes.
+var commandKeywords = []string{"REPLACE", "LIKE", "PURGE", "CMDSCOPE"}
+
+// preservedKeys are attributes a DEFINE with REPLACE keeps from the object
+// it replaces.
+var preservedKeys = []string{"CRDATE", "CRTIME", "CURDEPTH", "IPPROCS", "OPPROCS", "SUBID"}
+
+// objectCommands are the commands on the objects of an objectKind.
+var objectCommands = map[string]func(*qmgr, objectKind, commandRequest) map[string]any{
+	"DEFINE":  (*qmgr).define,
+	"ALTER":   (*qmgr).alter,
+	"DELETE":  (*qmgr).remove,
+	"DISPLAY": (*qmgr).display,
+	"START":   (*qmgr).start,
+	"STOP":    (*qmgr).stop,
+}
+
+func withDefaults(base, extra map[string]any) map[string]any {
+	defaults := maps.Clone(base)
+	maps.Copy(defaults, extra)
+	return defaults
+}
+
+// qmgr is the in-memory model of a queue manager.
+type qmgr struct {
+	name            string
+	transitionPolls int
+	started         time.Time
+	attributes      map[string]any
+	// objects holds the object attributes by namespace and name.
+	objects map[string]map[string]map[string]any
+	// statuses holds the status of started and stopped objects by
+	// namespace and name.
+	statuses  map[string]map[string]*objectStatus
+	nextSubID int
+}
+
+func newQmgr(name string, transitionPolls int) *qmgr {
+	qm := &qmgr{
+		name:            name,
+		transitionPolls: transitionPolls,
+		started:         time.Now(),
+		objects:         map[string]map[string]map[string]any{},
+		statuses:        map[string]map[string]*objectStatus{},
+		attributes: map[string]any{
+			"QMNAME": name, "DESCR": "", "PLATFORM": "UNIX", "CMDLEVEL": 940, "VERSION": "09040000",
+			"CCSID": 1208, "MAXMSGL": 4194304, "DEADQ": "", "DEFXMITQ": "", "CHLAUTH": "ENABLED",
+			"CONNAUTH": "SYSTEM.DEFAULT.AUTHINFO.IDPWOS", "MONQ": "OFF", "MONCHL": "OFF", "STATQ": "OFF",
+			"CRDATE": "", "CRTIME": "",
+		},
+	}
+	stamp(qm.attributes, true)
+	for _, kind := range objectKinds {
+		qm.objects[kind.namespace] = map[string]map[string]any{}
+		qm.statuses[kind.namespace] = map[string]*objectStatus{}
+	}
+	return qm
+}
+
+// run runs a runCommandJSON request and returns the response body.
+func (qm *qmgr) run(request commandRequest) map[string]any {
+	if request.Qualifier == "QMGR" {
+		return qm.runQmgr(request)
+	}
+	if display, isStatus := statusDisplays[request.Qualifier]; isStatus && request.Command == "DISPLAY" {
+		return display(qm, request)
+	}
+	kind, known := objectKinds[request.Qualifier]
+	command, supported := objectCommands[request.Command]
+	if !known || !supported || (kind.displayOnly && request.Command != "DISPLAY") {
+		return syntaxError(request)
+	}
+	return command(qm, kind, request)
+}
+
+// runQmgr runs DISPLAY QMGR and ALTER QMGR.
+func (qm *qmgr) runQmgr(request commandRequest) map[string]any {
+	switch request.Command {
+	case "DISPLAY":
+		return displayResponse([]map[string]any{
+			selectAttributes(qm.attributes, request.ResponseParameters, "QMNAME"),
+		})
+	case "ALTER":
+		maps.Copy(qm.attributes, attributeParameters(request.Parameters))
+		stamp(qm.attributes, false)
+		return successResponse(qmgrChangedMessage)
+	default:
+		return syntaxError(request)
+	}
+}
+
+// define runs DEFINE, which creates an object, or replaces one of the same
+// type when the REPLACE parameter is YES.
+func (qm *qmgr) define(kind objectKind, request commandRequest) map[string]any {
+	objectType := kind.requestedType(request.Parameters)
+	if !isSpecificName(request.Name) || (kind.typeKey != "" && objectType == "") {
+		return syntaxError(request)
+	}
+	objects := qm.objects[kind.namespace]
+	existing, exists := objects[request.Name]
+	if exists && kind.typeKey != "" && attributeString(existing[kind.typeKey]) != objectType {
+		return failureResponse(ReasonObjectWrongType, wrongTypeMessage(request.Name))
+	}
+	if exists && !isYes(request.Parameters["REPLACE"]) {
+		return failureResponse(ReasonObjectAlreadyExists, alreadyExistsMessage(request.Name))
+	}
+
+	attributes, failure := qm.baseAttributes(kind, request, objectType)
+	if failure != nil {
+		return failure
+	}
+	maps.Copy(attributes, attributeParameters(request.Parameters))
+	attributes[kind.nameKey] = request.Name
+	if kind.typeKey != "" {
+		attributes[kind.typeKey] = objectType
+	}
+	stamp(attributes, true)
+	for _, key := range preservedKeys {
+		if value, preserved := existing[key]; preserved {
+			attributes[key] = value
+		}
+	}
+	if kind.namespace == "SUB" && !exists {
+		qm.nextSubID++
+		attributes["SUBID"] = fmt.Sprintf("%048X", qm.nextSubID)
+	}
+	objects[request.Name] = attributes
+	return successResponse(messages[kind.namespace].created)
+}
+
+// baseAttributes returns the attributes a DEFINE starts from: those of the
+// object the LIKE parameter names, less its state, or else the defaults.
+func (qm *qmgr) baseAttributes(kind objectKind, request commandRequest, objectType string) (map[string]any, map[string]any) {
+	like, hasLike := request.Parameters["LIKE"]
+	if !hasLike {
+		return maps.Clone(kind.defaults), nil
+	}
+	likeName := fmt.Sprint(like)
+	base, found := qm.objects[kind.namespace][likeName]
+	if !found || (kind.typeKey != "" && attributeString(base[kind.typeKey]) != objectType) {
+		return nil, failureResponse(ReasonUnknownObjectName, notFoundMessage(likeName))
+	}
+	attributes := maps.Clone(base)
+	for _, key := range preservedKeys {
+		delete(attributes, key)
+		if value, hasDefault := kind.defaults[key]; hasDefault {
+			attributes[key] = value
+		}
+	}
+	return attributes, nil
+}
+
+// alter runs ALTER, which changes the attributes of an existing object.
+func (qm *qmgr) alter(kind objectKind, request commandRequest) map[string]any {
+	attributes, failure := qm.find(kind, request)
+	if failure != nil {
+		return failure
+	}
+	maps.Copy(attributes, attributeParameters(request.Parameters))
+	stamp(attributes, false)
+	return successResponse(messages[kind.namespace].changed)
+}
+
+// remove runs DELETE. A local queue must be empty unless the PURGE
+// parameter is YES, and a channel must be stopped.
+func (qm *qmgr) remove(kind objectKind, request commandRequest) map[string]any {
+	attributes, failure := qm.find(kind, request)
+	if failure != nil {
+		return failure
+	}
+	if isPositive(attributes["CURDEPTH"]) && !isYes(request.Parameters["PURGE"]) {
+		return failureResponse(ReasonQueueNotEmpty, queueNotEmptyMessage(request.Name))
+	}
+	status := qm.statuses[kind.namespace][request.Name]
+	if kind.namespace == "CHANNEL" && status != nil && status.current != statusStopped {
+		return failureResponse(ReasonChannelInUse, channelInUseMessage(request.Name))
+	}
+	delete(qm.objects[kind.namespace], request.Name)
+	delete(qm.statuses[kind.namespace], request.Name)
+	return successResponse(messages[kind.namespace].deleted)
+}
+
+// display runs DISPLAY, which returns the objects matching a name and, when
+// the qualifier names a type or the type parameter is given, of that type.
+func (qm *qmgr) display(kind objectKind, request commandRequest) map[string]any {
+	objectType := kind.requestedType(request.Parameters)
+	objects := qm.objects[kind.namespace]
+	var rows []map[string]any
+	for _, name := range matchingNames(objects, request.Name) {
+		attributes := objects[name]
+		if objectType != "" && attributeString(attributes[kind.typeKey]) != objectType {
+			continue
+		}
+		rows = append(rows, selectAttributes(attributes, request.ResponseParameters, kind.nameKey, kind.typeKey))
+	}
+	if len(rows) == 0 {
+		return failureResponse(ReasonUnknownObjectName, notFoundMessage(displayName(request.Name)))
+	}
+	return displayResponse(rows)
+}
+
+// find returns the attributes of the object a non-DISPLAY command names,
+// or the failure response when the name is generic or the object does not
+// exist or has another type.
+func (qm *qmgr) find(kind objectKind, request commandRequest) (map[string]any, map[string]any) {
+	if !isSpecificName(request.Name) {
+		return nil, syntaxError(request)
+	}
+	attributes, exists := qm.objects[kind.namespace][request.Name]
+	if !exists {
+		return nil, failureResponse(ReasonUnknownObjectName, notFoundMessage(request.Name))
+	}
+	objectType := kind.requestedType(request.Parameters)
+	if objectType != "" && attributeString(attributes[kind.typeKey]) != objectType {
+		return nil, failureResponse(ReasonObjectWrongType, wrongTypeMessage(request.Name))
+	}
+	return attributes, nil
+}
+
+// requestedType returns the object type the qualifier names, or the type
+// parameter of the request, or "" when there is neither.
+func (kind objectKind) requestedType(parameters map[string]any) string {
+	if kind.typeValue != "" || kind.typeKey == "" {
+		return kind.typeValue
+	}
+	return attributeString(parameters[kind.typeKey])
+}
+
+// attributeParameters returns the request parameters that set attributes.
+func attributeParameters(parameters map[string]any) map[string]any {
+	attributes := maps.Clone(parameters)
+	for _, keyword := range commandKeywords {
+		delete(attributes, keyword)
+	}
+	return attributes
+}
+
+// selectAttributes returns the attributes a DISPLAY returns, with
+// lower-case names: all of them when responseParameters is empty or holds
+// "all", otherwise the named ones and the always-returned keys.
+func selectAttributes(attributes map[string]any, responseParameters []string, keys ...string) map[string]any {
+	all := len(responseParameters) == 0 || slices.ContainsFunc(responseParameters, func(name string) bool {
+		return strings.EqualFold(name, "all")
+	})
+	selected := map[string]any{}
+	for key, value := range attributes {
+		wanted := all || slices.Contains(keys, key) || slices.ContainsFunc(responseParameters, func(name string) bool {
+			return strings.EqualFold(name, key)
+		})
+		if wanted {
+			selected[strings.ToLower(key)] = value
+		}
+	}
+	return selected
+}
+
+// matchingNames returns the sorted names of objects matching name, which
+// may be generic: "*", or a prefix followed by "*". An empty name matches
+// every object.
+func matchingNames[T any](objects map[string]T, name string) []string {
+	var names []string
+	for candidate := range objects {
+		if matchesName(candidate, name) {
+			names = append(names, candidate)
+		}
+	}
+	slices.Sort(names)
+	return names
+}
+
+func matchesName(candidate, name string) bool {
+	if prefix, generic := strings.CutSuffix(name, "*"); generic || name == "" {
+		return strings.HasPrefix(candidate, prefix)
+	}
+	return candidate == name
+}
+
+// isSpecificName reports whether name names a single object.
+func isSpecificName(name string) bool {
+	return name != "" && !strings.Contains(name, "*")
+}
+
+func displayName(name string) string {
+	if name == "" {
+		return "*"
+	}
+	return name
+}
+
+// stamp sets the alteration date and time, and on creation the creation
+// date and time of objects that have them.
+func stamp(attributes map[string]any, created bool) {
+	date, clock := dateTime(time.Now())
+	attributes["ALTDATE"], attributes["ALTTIME"] = date, clock
+	if _, hasCreation := attributes["CRDATE"]; hasCreation && created {
+		attributes["CRDATE"], attributes["CRTIME"] = date, clock
+	}
+}
+
+// dateTime formats a time as MQSC dates and times are.
+func dateTime(moment time.Time) (date, clock string) {
+	return moment.Format("2006-01-02"), moment.Format("15.04.05")
+}
+
+func attributeString(value any) string {
+	if value == nil {
+		return ""
+	}
+	return strings.ToUpper(fmt.Sprint(value))
+}
+
+func isYes(value any) bool {
+	return attributeString(value) == "YES"
+}
+
+func isPositive(value any) bool {
+	number, err := strconv.ParseFloat(fmt.Sprint(value), 64)
+	return err == nil && number > 0
+}
+
+func successResponse(message string) map[string]any {
+	return commandResponse(completionOK, 0, []any{map[string]any{
+		"completionCode": completionOK, "reasonCode": 0, "message": []any{message},
+	}})
+}
+
+func failureResponse(reasonCode int, message string) map[string]any {
+	return commandResponse(completionFailed, ReasonCommandFailed, []any{map[string]any{
+		"completionCode": completionFailed, "reasonCode": reasonCode, "message": []any{message},
+	}})
+}
+
+func syntaxError(request commandRequest) map[string]any {
+	return failureResponse(ReasonCommandFailed, syntaxErrorMessage(request.Command, request.Qualifier))
+}
+
+func displayResponse(rows []map[string]any) map[string]any {
+	items := make([]any, len(rows))
+	for index, row := range rows {
+		items[index] = map[string]any{"completionCode": completionOK, "reasonCode": 0, "parameters": row}
+	}
+	return commandResponse(completionOK, 0, items)
+}
+
+func commandResponse(completionCode, reasonCode int, items []any) map[string]any {
+	return map[string]any{
+		"commandResponse":       items,
+		"overallCompletionCode": completionCode,
+		"overallReasonCode":     reasonCode,
+	}
+}
@@ -0,0 +1,136 @@
+package mqresttest
+
+import "fmt"
+
+// Reason codes the server reports for failed commands. A failed command's
+// response has an overall completion code of 2 and an overall reason code
+// of ReasonCommandFailed, and its item carries the specific reason code
+// and AMQ message.
+const (
+	// ReasonQueueNotEmpty is MQRC_Q_NOT_EMPTY: DELETE of a local queue
+	// that holds messages, without PURGE.
+	ReasonQueueNotEmpty = 2055
+	// ReasonQmgrNameError is MQRC_Q_MGR_NAME_ERROR: a request for a queue
+	// manager the server does not have.
+	ReasonQmgrNameError = 2058
+	// ReasonUnknownObjectName is MQRC_UNKNOWN_OBJECT_NAME: the object, or
+	// any object matching a generic name, does not exist.
+	ReasonUnknownObjectName = 2085
+	// ReasonCommandFailed is MQRCCF_COMMAND_FAILED: the overall reason code
+	// of a failed command, and the reason code of a command the server
+	// does not support.
+	ReasonCommandFailed = 3008
+	// ReasonChannelStatusNotFound is MQRCCF_CHL_STATUS_NOT_FOUND: DISPLAY
+	// CHSTATUS of channels that have no status.
+	ReasonChannelStatusNotFound = 3065
+	// ReasonObjectAlreadyExists is MQRCCF_OBJECT_ALREADY_EXISTS: DEFINE of
+	// an existing object without REPLACE.
+	ReasonObjectAlreadyExists = 4001
+	// ReasonObjectWrongType is MQRCCF_OBJECT_WRONG_TYPE: a command for a
+	// queue or channel of another type than the existing one.
+	ReasonObjectWrongType = 4002
+	// ReasonChannelInUse is MQRCCF_CHANNEL_IN_USE: DELETE of a channel
+	// that is not stopped.
+	ReasonChannelInUse = 4031
+	// ReasonChannelNotActive is MQRCCF_CHANNEL_NOT_ACTIVE: STOP of a
+	// channel that has no status.
+	ReasonChannelNotActive = 4064
+)
+
+const (
+	completionOK     = 0
+	completionFailed = 2
+)
+
+// objectMessages holds the AMQ messages for the commands on one kind of
+// object.
+type objectMessages struct {
+	created string
+	changed string
+	deleted string
+	started string
+	stopped string
+}
+
+// Messages by object namespace.
+var messages = map[string]objectMessages{
+	"QUEUE": {
+		created: "AMQ8006I: IBM MQ queue created.",
+		changed: "AMQ8008I: IBM MQ queue changed.",
+		deleted: "AMQ8007I: IBM MQ queue deleted.",
+	},
+	"CHANNEL": {
+		created: "AMQ8014I: IBM MQ channel created.",
+		changed: "AMQ8016I: IBM MQ channel changed.",
+		deleted: "AMQ8015I: IBM MQ channel deleted.",
+		started: "AMQ8018I: Start IBM MQ channel accepted.",
+		stopped: "AMQ8019I: Stop IBM MQ channel accepted.",
+	},
+	"TOPIC": {
+		created: "AMQ8690I: IBM MQ topic created.",
+		changed: "AMQ8691I: IBM MQ topic changed.",
+		deleted: "AMQ8692I: IBM MQ topic deleted.",
+	},
+	"SUB": {
+		created: "AMQ8094I: IBM MQ subscription created.",
+		changed: "AMQ8096I: IBM MQ subscription changed.",
+		deleted: "AMQ8095I: IBM MQ subscription deleted.",
+	},
+	"LISTENER": {
+		created: "AMQ8626I: IBM MQ listener created.",
+		changed: "AMQ8628I: IBM MQ listener changed.",
+		deleted: "AMQ8627I: IBM MQ listener deleted.",
+		started: "AMQ8021I: Request to start IBM MQ listener accepted.",
+		stopped: "AMQ8706I: Request to stop IBM MQ listener accepted.",
+	},
+	"SERVICE": {
+		created: "AMQ8629I: IBM MQ service created.",
+		changed: "AMQ8631I: IBM MQ service changed.",
+		deleted: "AMQ8630I: IBM MQ service deleted.",
+		started: "AMQ8733I: Request to start service accepted.",
+		stopped: "AMQ8734I: Request to stop service accepted.",
+	},
+	"NAMELIST": {
+		created: "AMQ8010I: IBM MQ namelist created.",
+		changed: "AMQ8012I: IBM MQ namelist changed.",
+		deleted: "AMQ8011I: IBM MQ namelist deleted.",
+	},
+	"PROCESS": {
+		created: "AMQ8002I: IBM MQ process created.",
+		changed: "AMQ8004I: IBM MQ process changed.",
+		deleted: "AMQ8003I: IBM MQ process deleted.",
+	},
+}
+
+const qmgrChangedMessage = "AMQ8005I: IBM MQ queue manager changed."
+
+func notFoundMessage(name string) string {
+	return fmt.Sprintf("AMQ8147E: IBM MQ object %s not found.", name)
+}
+
+func alreadyExistsMessage(name string) string {
+	return fmt.Sprintf("AMQ8150E: IBM MQ object %s already exists.", name)
+}
+
+func wrongTypeMessage(name string) string {
+	return fmt.Sprintf("AMQ8151E: IBM MQ object %s has a different type.", name)
+}
+
+func queueNotEmptyMessage(name string) string {
+	return fmt.Sprintf("AMQ8143E: IBM MQ queue %s not empty.", name)
+}
+
+func channelInUseMessage(name string) string {
+	return fmt.Sprintf("AMQ9514E: Channel '%s' is in use.", name)
+}
+
+func channelNotActiveMessage(name string) string {
+	return fmt.Sprintf("AMQ9533E: Channel '%s' is not currently active.", name)
+}
+
+const channelStatusNotFoundMessage = "AMQ8420I: Channel Status not found."
+
+func syntaxErrorMessage(command, qualifier string) string {
+	return fmt.Sprintf("AMQ8405E: Syntax error detected at or near end of command segment below:- %s %s",
+		command, qualifier)
+}
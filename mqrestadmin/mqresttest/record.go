@@ -0,0 +1,373 @@
+package mqresttest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/wphillipmoore/mq-rest-admin-go/mqrestadmin"
+)
+
+// cassetteFormatVersion is the version of the cassette file format.
+const cassetteFormatVersion = 1
+
+// ErrNoInteraction is returned by ReplayTransport for a request that no
+// unused recorded interaction matches.
+var ErrNoInteraction = errors.New("mqresttest: no recorded interaction matches the request")
+
+// CassetteOption configures a RecordingTransport or ReplayTransport.
+type CassetteOption func(*cassetteConfig)
+
+type cassetteConfig struct {
+	redactor *mqrestadmin.Redactor
+}
+
+// WithRedactor sets the redactor that redacts credentials in requests and
+// responses, such as one from mqrestadmin.NewRedactor with the attributes
+// a session protects with mqrestadmin.WithSensitiveAttributes. A replaying
+// transport must use the same redactor as the recording one. The default
+// is mqrestadmin.NewRedactor().
+func WithRedactor(redactor *mqrestadmin.Redactor) CassetteOption {
+	return func(config *cassetteConfig) {
+		config.redactor = redactor
+	}
+}
+
+// newCassetteConfig applies options over the defaults.
+func newCassetteConfig(options []CassetteOption) cassetteConfig {
+	config := cassetteConfig{}
+	for _, option := range options {
+		option(&config)
+	}
+	if config.redactor == nil {
+		config.redactor = mqrestadmin.NewRedactor()
+	}
+	return config
+}
+
+// cassette is the file a RecordingTransport writes and a ReplayTransport
+// reads.
+type cassette struct {
+	FormatVersion int           `json:"format_version"`
+	Interactions  []interaction `json:"interactions"`
+}
+
+// interaction is a recorded request with its response, or the error the
+// transport returned instead.
+type interaction struct {
+	Request  recordedRequest   `json:"request"`
+	Response *recordedResponse `json:"response,omitempty"`
+	Error    string            `json:"error,omitempty"`
+}
+
+type recordedRequest struct {
+	Method  string            `json:"method"`
+	URL     string            `json:"url"`
+	Headers map[string]string `json:"headers,omitempty"`
+	Payload map[string]any    `json:"payload,omitempty"`
+	Body    string            `json:"body,omitempty"`
+}
+
+// recordedResponse holds a response. A JSON object or array body is stored
+// as JSON, so cassettes are readable; any other body as a string.
+type recordedResponse struct {
+	StatusCode int               `json:"status_code"`
+	Headers    map[string]string `json:"headers,omitempty"`
+	JSON       json.RawMessage   `json:"json,omitempty"`
+	Body       string            `json:"body,omitempty"`
+}
+
+// RecordingTransport wraps a Transport and records each request and
+// response, to be written to a cassette file with Save and served back by
+// ReplayTransport. Credentials are redacted as mqrestadmin.Redactor does:
+// Authorization headers, cookie values, the login password, and password
+// attributes such as LDAPPWD, in parameters and in RunMQSC command text.
+// WithRedactor redacts further attributes. It is safe for concurrent use.
+//
+// RecordingTransport implements RequestTransport, whose requests fail when
+// the wrapped transport does not. It does not implement
+// StreamingTransport, so DISPLAY iterators read whole responses, which are
+// recorded in full.
+type RecordingTransport struct {
+	next     mqrestadmin.Transport
+	path     string
+	redactor *mqrestadmin.Redactor
+
+	mutex        sync.Mutex
+	interactions []interaction
+}
+
+// NewRecordingTransport returns a RecordingTransport that sends requests
+// through next and saves them to the cassette file at path.
+func NewRecordingTransport(next mqrestadmin.Transport, path string,
+	options ...CassetteOption,
+) *RecordingTransport {
+	return &RecordingTransport{
+		next:         next,
+		path:         path,
+		redactor:     newCassetteConfig(options).redactor,
+		interactions: []interaction{},
+	}
+}
+
+// PostJSON sends a JSON POST request through the wrapped transport and
+// records it.
+func (transport *RecordingTransport) PostJSON(ctx context.Context, requestURL string,
+	payload map[string]any, headers map[string]string, timeout time.Duration,
+	verifyTLS bool,
+) (*mqrestadmin.TransportResponse, error) {
+	response, err := transport.next.PostJSON(ctx, requestURL, payload, headers, timeout, verifyTLS)
+	transport.record(recordedRequest{
+		Method:  http.MethodPost,
+		URL:     requestURL,
+		Headers: transport.redactor.Headers(headers),
+		Payload: transport.redactor.Payload(payload),
+	}, response, err)
+	return response, err
+}
+
+// SendRequest sends a request through the wrapped transport and records
+// it.
+func (transport *RecordingTransport) SendRequest(ctx context.Context, method, requestURL string,
+	body []byte, headers map[string]string, timeout time.Duration, verifyTLS bool,
+) (*mqrestadmin.TransportResponse, error) {
+	next, supported := transport.next.(mqrestadmin.RequestTransport)
+	if !supported {
+		return nil, errors.New("mqresttest: wrapped transport does not implement RequestTransport")
+	}
+	response, err := next.SendRequest(ctx, method, requestURL, body, headers, timeout, verifyTLS)
+	transport.record(recordedRequest{
+		Method:  method,
+		URL:     requestURL,
+		Headers: transport.redactor.Headers(headers),
+		Body:    string(body),
+	}, response, err)
+	return response, err
+}
+
+// record appends an interaction.
+func (transport *RecordingTransport) record(request recordedRequest, response *mqrestadmin.TransportResponse,
+	err error,
+) {
+	recorded := interaction{Request: request}
+	if err != nil {
+		recorded.Error = err.Error()
+	} else {
+		recorded.Response = &recordedResponse{
+			StatusCode: response.StatusCode,
+			Headers:    transport.redactor.Headers(response.Headers),
+		}
+		if body := strings.TrimSpace(response.Body); (strings.HasPrefix(body, "{") || strings.HasPrefix(body, "[")) &&
+			json.Valid([]byte(body)) {
+			recorded.Response.JSON = json.RawMessage(body)
+		} else {
+			recorded.Response.Body = response.Body
+		}
+	}
+
+	transport.mutex.Lock()
+	defer transport.mutex.Unlock()
+	transport.interactions = append(transport.interactions, recorded)
+}
+
+// Save writes the interactions recorded so far to the cassette file as
+// indented JSON, creating its directory if needed.
+func (transport *RecordingTransport) Save() error {
+	transport.mutex.Lock()
+	data, err := json.MarshalIndent(cassette{
+		FormatVersion: cassetteFormatVersion,
+		Interactions:  transport.interactions,
+	}, "", "  ")
+	transport.mutex.Unlock()
+	if err != nil {
+		return fmt.Errorf("mqresttest: encode cassette: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(transport.path), 0o755); err != nil {
+		return fmt.Errorf("mqresttest: %w", err)
+	}
+	if err := os.WriteFile(transport.path, append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("mqresttest: %w", err)
+	}
+	return nil
+}
+
+// ReplayTransport serves the responses in a cassette file written by
+// RecordingTransport, without a server. It is safe for concurrent use.
+//
+// A request matches a recorded one with the same method and URL path and
+// query; the scheme and host are ignored, so a cassette recorded against
+// one server replays for a session created with any URL. A runCommandJSON
+// or runCommand request must also have the same command, qualifier, name
+// and parameters, with numbers compared by value and redacted parameters
+// matching any value. The request's credentials are redacted before it is
+// compared, so RunMQSC text matches with its passwords redacted. Each
+// recorded interaction is served once, in the order recorded, so repeated
+// status queries replay the recorded sequence. A request that no unused
+// interaction matches fails with ErrNoInteraction.
+type ReplayTransport struct {
+	redactor *mqrestadmin.Redactor
+
+	mutex        sync.Mutex
+	interactions []interaction
+	used         []bool
+}
+
+// NewReplayTransport reads the cassette file at path and returns a
+// ReplayTransport serving it.
+func NewReplayTransport(path string, options ...CassetteOption) (*ReplayTransport, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("mqresttest: %w", err)
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var recorded cassette
+	if err := decoder.Decode(&recorded); err != nil {
+		return nil, fmt.Errorf("mqresttest: decode cassette %s: %w", path, err)
+	}
+	if recorded.FormatVersion != cassetteFormatVersion {
+		return nil, fmt.Errorf("mqresttest: cassette %s has format version %d, want %d", path,
+			recorded.FormatVersion, cassetteFormatVersion)
+	}
+	return &ReplayTransport{
+		redactor:     newCassetteConfig(options).redactor,
+		interactions: recorded.Interactions,
+		used:         make([]bool, len(recorded.Interactions)),
+	}, nil
+}
+
+// PostJSON returns the recorded response to a JSON POST request.
+func (transport *ReplayTransport) PostJSON(_ context.Context, requestURL string,
+	payload map[string]any, _ map[string]string, _ time.Duration, _ bool,
+) (*mqrestadmin.TransportResponse, error) {
+	return transport.replay(recordedRequest{Method: http.MethodPost, URL: requestURL,
+		Payload: transport.redactor.Payload(payload)})
+}
+
+// SendRequest returns the recorded response to a request.
+func (transport *ReplayTransport) SendRequest(_ context.Context, method, requestURL string,
+	_ []byte, _ map[string]string, _ time.Duration, _ bool,
+) (*mqrestadmin.TransportResponse, error) {
+	return transport.replay(recordedRequest{Method: method, URL: requestURL})
+}
+
+// Remaining returns the number of recorded interactions not yet served.
+// Tests can check it is zero to confirm that the code under test sent
+// every recorded request.
+func (transport *ReplayTransport) Remaining() int {
+	transport.mutex.Lock()
+	defer transport.mutex.Unlock()
+	remaining := 0
+	for _, used := range transport.used {
+		if !used {
+			remaining++
+		}
+	}
+	return remaining
+}
+
+// replay serves the first unused interaction matching request.
+func (transport *ReplayTransport) replay(request recordedRequest) (*mqrestadmin.TransportResponse, error) {
+	transport.mutex.Lock()
+	defer transport.mutex.Unlock()
+	for index, recorded := range transport.interactions {
+		if transport.used[index] || !matchesRequest(recorded.Request, request) {
+			continue
+		}
+		transport.used[index] = true
+		if recorded.Response == nil {
+			return nil, errors.New(recorded.Error)
+		}
+		body := recorded.Response.Body
+		if recorded.Response.JSON != nil {
+			// The cassette decoded, so the JSON is valid.
+			var compact bytes.Buffer
+			_ = json.Compact(&compact, recorded.Response.JSON)
+			body = compact.String()
+		}
+		return &mqrestadmin.TransportResponse{
+			StatusCode: recorded.Response.StatusCode,
+			Body:       body,
+			Headers:    recorded.Response.Headers,
+		}, nil
+	}
+	return nil, fmt.Errorf("%w: %s", ErrNoInteraction, describeRequest(request))
+}
+
+// matchesRequest reports whether a request matches a recorded one.
+func matchesRequest(recorded, request recordedRequest) bool {
+	if recorded.Method != request.Method || requestURI(recorded.URL) != requestURI(request.URL) {
+		return false
+	}
+	if !isCommandPayload(recorded.Payload) && !isCommandPayload(request.Payload) {
+		return true
+	}
+	for _, key := range []string{"type", "command", "qualifier", "name"} {
+		if recorded.Payload[key] != request.Payload[key] {
+			return false
+		}
+	}
+	return matchesParameters(recorded.Payload["parameters"], request.Payload["parameters"])
+}
+
+// isCommandPayload reports whether a payload is an admin command.
+func isCommandPayload(payload map[string]any) bool {
+	return payload["type"] == "runCommandJSON" || payload["type"] == "runCommand"
+}
+
+// matchesParameters reports whether request parameters match recorded
+// ones. Values are compared by their JSON encoding, and a redacted value
+// matches any value.
+func matchesParameters(recorded, request any) bool {
+	recordedParameters, _ := recorded.(map[string]any)
+	requestParameters, _ := request.(map[string]any)
+	if len(recordedParameters) != len(requestParameters) {
+		return false
+	}
+	for key, recordedValue := range recordedParameters {
+		requestValue, exists := requestParameters[key]
+		if !exists {
+			return false
+		}
+		if recordedValue == mqrestadmin.RedactedValue {
+			continue
+		}
+		recordedJSON, _ := json.Marshal(recordedValue)
+		requestJSON, _ := json.Marshal(requestValue)
+		if !bytes.Equal(recordedJSON, requestJSON) {
+			return false
+		}
+	}
+	return true
+}
+
+// requestURI returns the path and query of a URL, or the URL itself when
+// it does not parse.
+func requestURI(rawURL string) string {
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return rawURL
+	}
+	return parsed.RequestURI()
+}
+
+// describeRequest describes a request for an error message, such as
+// "POST /ibmmq/rest/v2/admin/action/qmgr/QM1/mqsc DISPLAY QLOCAL APP.*".
+func describeRequest(request recordedRequest) string {
+	fields := []string{request.Method, requestURI(request.URL)}
+	for _, key := range []string{"command", "qualifier", "name"} {
+		if value, _ := request.Payload[key].(string); value != "" {
+			fields = append(fields, value)
+		}
+	}
+	return strings.Join(fields, " ")
+}
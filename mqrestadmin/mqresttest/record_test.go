@@ -0,0 +1,328 @@
+package mqresttest
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/wphillipmoore/mq-rest-admin-go/mqrestadmin"
+)
+
+// replayURL is the base URL of replaying sessions, which no server
+// listens on.
+const replayURL = "https://mq.invalid:9443/ibmmq/rest/v2"
+
+// recordSession records a session against a new server with credentials,
+// calls exercise, and saves the cassette, returning its path and content.
+func recordSession(t *testing.T, credentials mqrestadmin.Credentials,
+	exercise func(session *mqrestadmin.Session), options ...CassetteOption,
+) (string, string) {
+	t.Helper()
+	server := NewServer(WithTransitionPolls(1))
+	t.Cleanup(server.Close)
+	path := filepath.Join(t.TempDir(), "testdata", "cassette.json")
+	recorder := NewRecordingTransport(&mqrestadmin.HTTPTransport{}, path, options...)
+	session, err := mqrestadmin.NewSession(server.URL, "QM1", credentials, mqrestadmin.WithTransport(recorder))
+	if err != nil {
+		t.Fatal(err)
+	}
+	exercise(session)
+	if err := recorder.Save(); err != nil {
+		t.Fatalf("Save() = %v", err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return path, string(data)
+}
+
+// replaySession returns a session replaying the cassette at path.
+func replaySession(t *testing.T, path string, credentials mqrestadmin.Credentials,
+	options ...CassetteOption,
+) (*mqrestadmin.Session, *ReplayTransport) {
+	t.Helper()
+	replay, err := NewReplayTransport(path, options...)
+	if err != nil {
+		t.Fatalf("NewReplayTransport() = %v", err)
+	}
+	session, err := mqrestadmin.NewSession(replayURL, "QM1", credentials, mqrestadmin.WithTransport(replay))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return session, replay
+}
+
+// defineAndDisplay defines a queue and a channel, starts the channel, and
+// returns what the session reported.
+func defineAndDisplay(t *testing.T, session *mqrestadmin.Session) (map[string]any, mqrestadmin.SyncResult) {
+	t.Helper()
+	ctx := context.Background()
+	if err := session.DefineQlocal(ctx, "APP.Q", parameters(map[string]any{"max_queue_depth": 5000})); err != nil {
+		t.Fatal(err)
+	}
+	queues, err := session.DisplayQueue(ctx, "APP.Q")
+	if err != nil || len(queues) != 1 {
+		t.Fatalf("DisplayQueue() = %v, %v", queues, err)
+	}
+	if err := session.DefineChannel(ctx, "TO.QM2", parameters(map[string]any{"channel_type": "SDR"})); err != nil {
+		t.Fatal(err)
+	}
+	result, err := session.StartChannelSync(ctx, "TO.QM2",
+		mqrestadmin.SyncConfig{Timeout: 5 * time.Second, PollInterval: time.Millisecond})
+	if err != nil {
+		t.Fatal(err)
+	}
+	result.ElapsedSeconds = 0
+	return queues[0], result
+}
+
+func TestRecord_ReplayMatchesRecording(t *testing.T) {
+	credentials := mqrestadmin.BasicAuth{Username: DefaultUsername, Password: DefaultPassword}
+	var recordedQueue map[string]any
+	var recordedResult mqrestadmin.SyncResult
+	path, content := recordSession(t, credentials, func(session *mqrestadmin.Session) {
+		recordedQueue, recordedResult = defineAndDisplay(t, session)
+	})
+	if strings.Contains(content, "YWRtaW46cGFzc3cwcmQ=") || !strings.Contains(content, `"Authorization": "[REDACTED]"`) {
+		t.Error("cassette does not redact the Authorization header")
+	}
+
+	session, replay := replaySession(t, path, credentials)
+	queue, result := defineAndDisplay(t, session)
+	if queue["max_queue_depth"] != recordedQueue["max_queue_depth"] || queue["creation_date"] != recordedQueue["creation_date"] {
+		t.Errorf("replayed queue = %v, want %v", queue, recordedQueue)
+	}
+	if result != recordedResult || result.Polls != 2 {
+		t.Errorf("replayed StartChannelSync() = %+v, want %+v", result, recordedResult)
+	}
+	if remaining := replay.Remaining(); remaining != 0 {
+		t.Errorf("Remaining() = %d, want 0", remaining)
+	}
+
+	_, err := session.DisplayQueue(context.Background(), "APP.Q")
+	if !errors.Is(err, ErrNoInteraction) ||
+		!strings.Contains(err.Error(), "POST /ibmmq/rest/v2/admin/action/qmgr/QM1/mqsc DISPLAY QUEUE APP.Q") {
+		t.Errorf("DisplayQueue() after the cassette is used = %v, want ErrNoInteraction", err)
+	}
+}
+
+func TestRecord_ParameterMatching(t *testing.T) {
+	credentials := mqrestadmin.BasicAuth{Username: DefaultUsername, Password: DefaultPassword}
+	path, content := recordSession(t, credentials, func(session *mqrestadmin.Session) {
+		ctx := context.Background()
+		for _, name := range []string{"APP.1", "APP.2"} {
+			if err := session.DefineQlocal(ctx, name, parameters(map[string]any{"max_queue_depth": 100})); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if err := session.DefineChannel(ctx, "APP.SVRCONN", parameters(map[string]any{
+			"channel_type": "SVRCONN", "password": "secret",
+		})); err != nil {
+			t.Fatal(err)
+		}
+	})
+	if strings.Contains(content, "secret") {
+		t.Error("cassette does not redact the password parameter")
+	}
+
+	session, replay := replaySession(t, path, credentials)
+	ctx := context.Background()
+	for _, test := range []struct {
+		name       string
+		parameters map[string]any
+		matches    bool
+	}{
+		{"APP.2", map[string]any{"max_queue_depth": 200}, false},
+		{"APP.2", map[string]any{"max_queue_depth": 100, "description": "extra"}, false},
+		{"APP.2", map[string]any{"description": "other"}, false},
+		{"APP.2", map[string]any{"max_queue_depth": 100}, true},
+		{"APP.1", map[string]any{"max_queue_depth": 100}, true},
+	} {
+		err := session.DefineQlocal(ctx, test.name, parameters(test.parameters))
+		if matched := !errors.Is(err, ErrNoInteraction); matched != test.matches {
+			t.Errorf("DefineQlocal(%s, %v) = %v, want a match: %v", test.name, test.parameters, err, test.matches)
+		}
+	}
+	if err := session.DefineChannel(ctx, "APP.SVRCONN", parameters(map[string]any{
+		"channel_type": "SVRCONN", "password": "another",
+	})); err != nil {
+		t.Errorf("DefineChannel() with a different redacted password = %v", err)
+	}
+	if remaining := replay.Remaining(); remaining != 0 {
+		t.Errorf("Remaining() = %d, want 0", remaining)
+	}
+}
+
+func TestRecord_RunMQSCRedaction(t *testing.T) {
+	credentials := mqrestadmin.BasicAuth{Username: DefaultUsername, Password: DefaultPassword}
+	commands := []string{
+		"DEFINE AUTHINFO(LDAP.AUTH) AUTHTYPE(IDPWLDAP) LDAPPWD('s3cret') LDAPUSER('cn=mq')",
+		"ALTER QMGR SSLKEYP('k3y''pass')",
+	}
+	path, content := recordSession(t, credentials, func(session *mqrestadmin.Session) {
+		for _, command := range commands {
+			// The server answers runCommand requests with an error, which
+			// is recorded like any other response.
+			_, _ = session.RunMQSC(context.Background(), command)
+		}
+	})
+	if strings.Contains(content, "s3cret") || strings.Contains(content, "k3y") ||
+		!strings.Contains(content, "LDAPPWD([REDACTED]) LDAPUSER('cn=mq')") ||
+		!strings.Contains(content, "ALTER QMGR SSLKEYP([REDACTED])") {
+		t.Errorf("cassette does not redact RunMQSC passwords:\n%s", content)
+	}
+
+	session, replay := replaySession(t, path, credentials)
+	for _, command := range []string{
+		strings.Replace(commands[0], "s3cret", "other", 1),
+		commands[1],
+	} {
+		if _, err := session.RunMQSC(context.Background(), command); errors.Is(err, ErrNoInteraction) {
+			t.Errorf("RunMQSC(%q) = %v, want the recorded response", command, err)
+		}
+	}
+	if _, err := session.RunMQSC(context.Background(), "ALTER QMGR DESCR('x')"); !errors.Is(err, ErrNoInteraction) {
+		t.Errorf("RunMQSC() of an unrecorded command = %v, want ErrNoInteraction", err)
+	}
+	if remaining := replay.Remaining(); remaining != 0 {
+		t.Errorf("Remaining() = %d, want 0", remaining)
+	}
+}
+
+func TestRecord_WithRedactor(t *testing.T) {
+	credentials := mqrestadmin.BasicAuth{Username: DefaultUsername, Password: DefaultPassword}
+	redactor := WithRedactor(mqrestadmin.NewRedactor("ssl_cipher_spec"))
+	define := func(session *mqrestadmin.Session, cipherSpec string) error {
+		return session.DefineChannel(context.Background(), "APP.SVRCONN", parameters(map[string]any{
+			"channel_type": "SVRCONN", "ssl_cipher_spec": cipherSpec,
+		}))
+	}
+	path, content := recordSession(t, credentials, func(session *mqrestadmin.Session) {
+		if err := define(session, "TLS_AES_128_GCM_SHA256"); err != nil {
+			t.Fatal(err)
+		}
+	}, redactor)
+	if strings.Contains(content, "TLS_AES_128_GCM_SHA256") || !strings.Contains(content, `"SSLCIPH": "[REDACTED]"`) {
+		t.Errorf("cassette does not redact SSLCIPH:\n%s", content)
+	}
+
+	session, replay := replaySession(t, path, credentials, redactor)
+	if err := define(session, "TLS_AES_256_GCM_SHA384"); err != nil {
+		t.Errorf("DefineChannel() with a different redacted cipher spec = %v", err)
+	}
+	if remaining := replay.Remaining(); remaining != 0 {
+		t.Errorf("Remaining() = %d, want 0", remaining)
+	}
+}
+
+func TestRecord_LTPAAndRequests(t *testing.T) {
+	credentials := mqrestadmin.LTPAAuth{Username: DefaultUsername, Password: DefaultPassword}
+	var recorded mqrestadmin.Capabilities
+	path, content := recordSession(t, credentials, func(session *mqrestadmin.Session) {
+		var err error
+		if recorded, err = session.Capabilities(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+	})
+	if strings.Contains(content, DefaultPassword) || strings.Contains(content, "LtpaToken2=mqresttest-") ||
+		!strings.Contains(content, `"password": "[REDACTED]"`) {
+		t.Errorf("cassette does not redact the login password and LTPA token:\n%s", content)
+	}
+
+	session, replay := replaySession(t, path, credentials)
+	if remaining := replay.Remaining(); remaining != 2 {
+		t.Errorf("Remaining() after login = %d, want 2", remaining)
+	}
+	if _, err := replay.SendRequest(context.Background(), "GET", replayURL+"/admin/qmgr", nil, nil, 0,
+		true); !errors.Is(err, ErrNoInteraction) {
+		t.Errorf("SendRequest() of an unrecorded resource = %v, want ErrNoInteraction", err)
+	}
+	capabilities, err := session.Capabilities(context.Background())
+	if err != nil || capabilities != recorded || capabilities.InstallationVersion != "9.4.0.0" {
+		t.Errorf("replayed Capabilities() = %+v, %v; want %+v", capabilities, err, recorded)
+	}
+	if remaining := replay.Remaining(); remaining != 0 {
+		t.Errorf("Remaining() = %d, want 0", remaining)
+	}
+}
+
+// failingTransport fails every request, and implements only Transport.
+type failingTransport struct{}
+
+func (failingTransport) PostJSON(context.Context, string, map[string]any, map[string]string, time.Duration,
+	bool,
+) (*mqrestadmin.TransportResponse, error) {
+	return nil, errors.New("connection refused")
+}
+
+func TestRecord_TransportErrors(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cassette.json")
+	recorder := NewRecordingTransport(failingTransport{}, path)
+	ctx := context.Background()
+	if _, err := recorder.PostJSON(ctx, replayURL+"/login", nil, nil, time.Second, true); err == nil {
+		t.Error("PostJSON() through a failing transport succeeded")
+	}
+	if _, err := recorder.SendRequest(ctx, "GET", replayURL+"/admin/installation", nil, nil, time.Second,
+		true); err == nil || !strings.Contains(err.Error(), "does not implement RequestTransport") {
+		t.Errorf("SendRequest() through a transport without RequestTransport = %v", err)
+	}
+	if err := recorder.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	replay, err := NewReplayTransport(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := replay.PostJSON(ctx, "http://other/ibmmq/rest/v2/login", nil, nil, 0, true); err == nil ||
+		err.Error() != "connection refused" {
+		t.Errorf("replayed PostJSON() = %v, want the recorded error", err)
+	}
+	if _, err := replay.SendRequest(ctx, "GET", "%zz", nil, nil, 0, true); !errors.Is(err, ErrNoInteraction) ||
+		!strings.Contains(err.Error(), "GET %zz") {
+		t.Errorf("replayed SendRequest() of an unparsable URL = %v", err)
+	}
+}
+
+func TestRecord_CassetteErrors(t *testing.T) {
+	directory := t.TempDir()
+	for _, test := range []struct {
+		name    string
+		content string
+		message string
+	}{
+		{"missing.json", "", "no such file"},
+		{"malformed.json", "{", "decode cassette"},
+		{"version.json", `{"format_version": 2}`, "has format version 2, want 1"},
+	} {
+		path := filepath.Join(directory, test.name)
+		if test.content != "" {
+			if err := os.WriteFile(path, []byte(test.content), 0o644); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if _, err := NewReplayTransport(path); err == nil || !strings.Contains(err.Error(), test.message) {
+			t.Errorf("NewReplayTransport(%s) = %v, want %q", test.name, err, test.message)
+		}
+	}
+
+	file := filepath.Join(directory, "file")
+	if err := os.WriteFile(file, nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	for _, path := range []string{filepath.Join(file, "cassette.json"), directory} {
+		if err := NewRecordingTransport(failingTransport{}, path).Save(); err == nil {
+			t.Errorf("Save() to %s succeeded", path)
+		}
+	}
+	recorder := NewRecordingTransport(failingTransport{}, filepath.Join(directory, "unencodable.json"))
+	recorder.record(recordedRequest{Payload: map[string]any{"value": func() {}}}, nil, errors.New("failed"))
+	if err := recorder.Save(); err == nil || !strings.Contains(err.Error(), "encode cassette") {
+		t.Errorf("Save() of an unencodable payload = %v", err)
+	}
+}
@@ -0,0 +1,415 @@
+// Package mqresttest provides an in-memory fake of the IBM MQ REST API for
+// tests, so code built on mqrestadmin can be tested without a queue
+// manager:
+//
+//	server := mqresttest.NewServer()
+//	defer server.Close()
+//	session, err := server.NewSession()
+//
+// The server answers runCommandJSON requests on the mqsc endpoint of each
+// of its queue managers. DEFINE, ALTER, DELETE and DISPLAY work on queues,
+// channels, topics, subscriptions, listeners, services, namelists and
+// processes; DISPLAY and ALTER QMGR on the queue manager attributes;
+// DISPLAY QSTATUS, CHSTATUS, QMSTATUS, LSSTATUS and SVSTATUS on the
+// object status; and START and STOP on channels, listeners and services.
+// DISPLAY takes generic names ending in an asterisk. Failed commands
+// report the completion code, reason code and AMQ message a queue manager
+// would, so a test sees a CommandError where it would against MQ.
+//
+// Started channels, listeners and services report STARTING for
+// WithTransitionPolls status queries before RUNNING, and stopped ones
+// STOPPING before STOPPED, which exercises the polling of the sync
+// methods. Requests are authenticated with HTTP basic authentication or
+// an LtpaToken2 cookie from a login, and must carry the
+// ibm-mq-rest-csrf-token header, as the REST API requires.
+//
+// GET /admin/installation and GET /admin/qmgr report a single 9.4
+// installation with the queue managers running in it, which is what
+// Session.Capabilities and Session.QmgrState read.
+//
+// The server does not validate attribute names or values: parameters are
+// stored as sent and returned by DISPLAY, with the MQSC names in lower
+// case, the way the REST API returns them.
+//
+// For behaviour the fake does not model, RecordingTransport captures the
+// requests and responses of a real queue manager in a cassette file once,
+// and ReplayTransport serves them back in tests that run without MQ.
+package mqresttest
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/wphillipmoore/mq-rest-admin-go/mqrestadmin"
+)
+
+// Default credentials, those of the IBM MQ developer container.
+const (
+	DefaultUsername = "admin"
+	DefaultPassword = "passw0rd"
+)
+
+const (
+	restPath       = "/ibmmq/rest/v2"
+	installation   = "Installation1"
+	ltpaCookieName = "LtpaToken2"
+	csrfHeader     = "ibm-mq-rest-csrf-token"
+	gatewayHeader  = "ibm-mq-rest-gateway-qmgr"
+)
+
+// Server is a fake MQ REST API server backed by an in-memory model of its
+// queue managers. It is safe for concurrent use.
+type Server struct {
+	// URL is the REST API base URL, such as
+	// http://127.0.0.1:41563/ibmmq/rest/v2.
+	URL string
+
+	httpServer      *httptest.Server
+	username        string
+	password        string
+	qmgrNames       []string
+	transitionPolls int
+
+	mutex     sync.Mutex
+	qmgrs     map[string]*qmgr
+	tokens    map[string]bool
+	nextToken int
+	commands  []Command
+}
+
+// Option configures a Server.
+type Option func(*Server)
+
+// WithQmgrs sets the names of the server's queue managers. The default is
+// a single queue manager named QM1. Any of them can act as the gateway
+// queue manager for the others.
+func WithQmgrs(names ...string) Option {
+	return func(server *Server) {
+		server.qmgrNames = names
+	}
+}
+
+// WithCredentials sets the username and password the server accepts for
+// basic authentication and LTPA login. The default is DefaultUsername and
+// DefaultPassword.
+func WithCredentials(username, password string) Option {
+	return func(server *Server) {
+		server.username = username
+		server.password = password
+	}
+}
+
+// WithTransitionPolls sets how many status queries a started or stopped
+// object reports STARTING or STOPPING before RUNNING or STOPPED. The
+// default is 1; 0 completes the transition at once.
+func WithTransitionPolls(polls int) Option {
+	return func(server *Server) {
+		server.transitionPolls = polls
+	}
+}
+
+// Command is a runCommandJSON request the server received.
+type Command struct {
+	// Qmgr is the queue manager the command ran on.
+	Qmgr string
+	// Command and Qualifier are the MQSC verb and object keyword, such as
+	// DEFINE and QLOCAL.
+	Command   string
+	Qualifier string
+	// Name is the object name, or empty when the request has none.
+	Name string
+	// Parameters holds the request parameters, keyed by upper-case MQSC
+	// name.
+	Parameters map[string]any
+}
+
+// commandRequest is the runCommandJSON request body.
+type commandRequest struct {
+	Type               string         `json:"type"`
+	Command            string         `json:"command"`
+	Qualifier          string         `json:"qualifier"`
+	Name               string         `json:"name"`
+	Parameters         map[string]any `json:"parameters"`
+	ResponseParameters []string       `json:"responseParameters"`
+}
+
+// NewServer starts a Server. The caller should call Close when finished.
+func NewServer(opts ...Option) *Server {
+	server := &Server{
+		username:        DefaultUsername,
+		password:        DefaultPassword,
+		qmgrNames:       []string{"QM1"},
+		transitionPolls: 1,
+		qmgrs:           map[string]*qmgr{},
+		tokens:          map[string]bool{},
+	}
+	for _, opt := range opts {
+		opt(server)
+	}
+	for _, name := range server.qmgrNames {
+		server.qmgrs[name] = newQmgr(name, server.transitionPolls)
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST "+restPath+"/login", server.handleLogin)
+	mux.HandleFunc("DELETE "+restPath+"/login", server.handleLogout)
+	mux.HandleFunc("POST "+restPath+"/admin/action/qmgr/{qmgr}/mqsc", server.handleMQSC)
+	mux.HandleFunc("GET "+restPath+"/admin/installation", server.handleInstallation)
+	mux.HandleFunc("GET "+restPath+"/admin/qmgr", server.handleQmgrs)
+	mux.HandleFunc("GET "+restPath+"/admin/qmgr/{qmgr}", server.handleQmgrs)
+	server.httpServer = httptest.NewServer(mux)
+	server.URL = server.httpServer.URL + restPath
+	return server
+}
+
+// Close shuts the server down.
+func (server *Server) Close() {
+	server.httpServer.Close()
+}
+
+// NewSession creates a session for the server's first queue manager,
+// authenticated with basic authentication.
+func (server *Server) NewSession(opts ...mqrestadmin.Option) (*mqrestadmin.Session, error) {
+	return mqrestadmin.NewSession(server.URL, server.qmgrNames[0],
+		mqrestadmin.BasicAuth{Username: server.username, Password: server.password}, opts...)
+}
+
+// Commands returns the runCommandJSON requests the server has received, in
+// order.
+func (server *Server) Commands() []Command {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+	return slices.Clone(server.commands)
+}
+
+// Object returns a copy of the attributes of an object, keyed by
+// upper-case MQSC name, and whether the object exists. The qualifier is
+// QUEUE for every queue type, or the object keyword, such as CHANNEL; QMGR
+// returns the queue manager attributes.
+func (server *Server) Object(qmgrName, qualifier, name string) (map[string]any, bool) {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+	attributes, exists := server.lookupObject(qmgrName, qualifier, name)
+	return maps.Clone(attributes), exists
+}
+
+// SetAttributes sets attributes of an existing object, keyed by MQSC name,
+// without the checks of ALTER. Use it to set state that no command can,
+// such as a queue's CURDEPTH. The qualifier is as for Object.
+func (server *Server) SetAttributes(qmgrName, qualifier, name string, attributes map[string]any) error {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+	current, exists := server.lookupObject(qmgrName, qualifier, name)
+	if !exists {
+		return fmt.Errorf("mqresttest: %s %s not found on %s", qualifier, name, qmgrName)
+	}
+	maps.Copy(current, upperKeys(attributes))
+	return nil
+}
+
+// lookupObject returns the stored attributes of an object.
+func (server *Server) lookupObject(qmgrName, qualifier, name string) (map[string]any, bool) {
+	qm, exists := server.qmgrs[qmgrName]
+	if !exists {
+		return nil, false
+	}
+	if strings.EqualFold(qualifier, "QMGR") {
+		return qm.attributes, true
+	}
+	attributes, exists := qm.objects[strings.ToUpper(qualifier)][name]
+	return attributes, exists
+}
+
+// handleLogin checks the login credentials and sets an LtpaToken2 cookie.
+func (server *Server) handleLogin(writer http.ResponseWriter, request *http.Request) {
+	if !checkCSRF(writer, request) {
+		return
+	}
+	var login struct {
+		Username string `json:"username"`
+		Password string `json:"password"`
+	}
+	if err := json.NewDecoder(request.Body).Decode(&login); err != nil {
+		writeError(writer, http.StatusBadRequest, "the login request body is not valid JSON")
+		return
+	}
+	if login.Username != server.username || login.Password != server.password {
+		writeError(writer, http.StatusUnauthorized, "the user name or password is not valid")
+		return
+	}
+
+	server.mutex.Lock()
+	server.nextToken++
+	token := "mqresttest-" + strconv.Itoa(server.nextToken)
+	server.tokens[token] = true
+	server.mutex.Unlock()
+
+	http.SetCookie(writer, &http.Cookie{Name: ltpaCookieName, Value: token, Path: "/", HttpOnly: true})
+	writer.WriteHeader(http.StatusNoContent)
+}
+
+// handleLogout invalidates the request's LtpaToken2 cookie.
+func (server *Server) handleLogout(writer http.ResponseWriter, request *http.Request) {
+	if !checkCSRF(writer, request) || !server.authenticate(writer, request) {
+		return
+	}
+	if cookie, err := request.Cookie(ltpaCookieName); err == nil {
+		server.mutex.Lock()
+		delete(server.tokens, cookie.Value)
+		server.mutex.Unlock()
+	}
+	http.SetCookie(writer, &http.Cookie{Name: ltpaCookieName, Value: "", Path: "/", MaxAge: -1})
+	writer.WriteHeader(http.StatusNoContent)
+}
+
+// handleMQSC runs a runCommandJSON request on the queue manager named in
+// the URL.
+func (server *Server) handleMQSC(writer http.ResponseWriter, request *http.Request) {
+	if !checkCSRF(writer, request) || !server.authenticate(writer, request) {
+		return
+	}
+	var payload commandRequest
+	decoder := json.NewDecoder(request.Body)
+	decoder.UseNumber()
+	if err := decoder.Decode(&payload); err != nil {
+		writeError(writer, http.StatusBadRequest, "the request body is not valid JSON")
+		return
+	}
+	if payload.Type != "runCommandJSON" {
+		writeError(writer, http.StatusBadRequest,
+			fmt.Sprintf("only runCommandJSON requests are supported, not %q", payload.Type))
+		return
+	}
+
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+	qmgrName := request.PathValue("qmgr")
+	qm, exists := server.qmgrs[qmgrName]
+	if gateway := request.Header.Get(gatewayHeader); gateway != "" && server.qmgrs[gateway] == nil {
+		qmgrName, exists = gateway, false
+	}
+	if !exists {
+		writeQmgrNotFound(writer, qmgrName)
+		return
+	}
+
+	payload.Command = strings.ToUpper(payload.Command)
+	payload.Qualifier = strings.ToUpper(payload.Qualifier)
+	payload.Parameters = upperKeys(payload.Parameters)
+	server.commands = append(server.commands, Command{
+		Qmgr:       qmgrName,
+		Command:    payload.Command,
+		Qualifier:  payload.Qualifier,
+		Name:       payload.Name,
+		Parameters: maps.Clone(payload.Parameters),
+	})
+	writeJSON(writer, http.StatusOK, qm.run(payload))
+}
+
+// handleInstallation reports the server's installation.
+func (server *Server) handleInstallation(writer http.ResponseWriter, request *http.Request) {
+	if !server.authenticate(writer, request) {
+		return
+	}
+	writeJSON(writer, http.StatusOK, map[string]any{"installation": []any{map[string]any{
+		"name":     installation,
+		"version":  "9.4.0.0",
+		"platform": "unix",
+		"extended": map[string]any{"installationPath": "/opt/mqm", "dataPath": "/var/mqm"},
+	}}})
+}
+
+// handleQmgrs reports the queue manager named in the URL, or every queue
+// manager when it names none, as running.
+func (server *Server) handleQmgrs(writer http.ResponseWriter, request *http.Request) {
+	if !server.authenticate(writer, request) {
+		return
+	}
+	names := server.qmgrNames
+	if name := request.PathValue("qmgr"); name != "" {
+		if _, exists := server.qmgrs[name]; !exists {
+			writeQmgrNotFound(writer, name)
+			return
+		}
+		names = []string{name}
+	}
+	qmgrs := make([]any, len(names))
+	for index, name := range names {
+		qmgrs[index] = map[string]any{
+			"name":     name,
+			"state":    "running",
+			"extended": map[string]any{"installationName": installation, "isDefaultQmgr": index == 0},
+		}
+	}
+	writeJSON(writer, http.StatusOK, map[string]any{"qmgr": qmgrs})
+}
+
+// authenticate reports whether the request carries the server's basic
+// authentication credentials or a current LtpaToken2 cookie, and answers
+// it with 401 Unauthorized when it does not.
+func (server *Server) authenticate(writer http.ResponseWriter, request *http.Request) bool {
+	authenticated := false
+	if username, password, present := request.BasicAuth(); present {
+		authenticated = username == server.username && password == server.password
+	} else if cookie, err := request.Cookie(ltpaCookieName); err == nil {
+		server.mutex.Lock()
+		authenticated = server.tokens[cookie.Value]
+		server.mutex.Unlock()
+	}
+	if !authenticated {
+		writeError(writer, http.StatusUnauthorized, "the request is not authenticated")
+	}
+	return authenticated
+}
+
+// checkCSRF reports whether the request carries the CSRF token header, and
+// answers it with 403 Forbidden when it does not.
+func checkCSRF(writer http.ResponseWriter, request *http.Request) bool {
+	if _, present := request.Header[http.CanonicalHeaderKey(csrfHeader)]; present {
+		return true
+	}
+	writeError(writer, http.StatusForbidden, "the request has no "+csrfHeader+" header")
+	return false
+}
+
+// writeQmgrNotFound writes the response for a queue manager the server does
+// not have.
+func writeQmgrNotFound(writer http.ResponseWriter, qmgrName string) {
+	writeJSON(writer, http.StatusNotFound, map[string]any{"error": []any{map[string]any{
+		"msgId":          "MQWB0009E",
+		"completionCode": completionFailed,
+		"reasonCode":     ReasonQmgrNameError,
+		"message":        fmt.Sprintf("MQWB0009E: Could not query the queue manager '%s'.", qmgrName),
+	}}})
+}
+
+// writeError writes a REST API error response.
+func writeError(writer http.ResponseWriter, statusCode int, message string) {
+	writeJSON(writer, statusCode, map[string]any{"error": []any{map[string]any{
+		"type":    "rest",
+		"message": "mqresttest: " + message,
+	}}})
+}
+
+// writeJSON writes body as a JSON response.
+func writeJSON(writer http.ResponseWriter, statusCode int, body map[string]any) {
+	writer.Header().Set("Content-Type", "application/json")
+	writer.WriteHeader(statusCode)
+	_ = json.NewEncoder(writer).Encode(body)
+}
+
+// upperKeys returns a copy of attributes with upper-case keys.
+func upperKeys(attributes map[string]any) map[string]any {
+	upper := make(map[string]any, len(attributes))
+	for key, value := range attributes {
+		upper[strings.ToUpper(key)] = value
+	}
+	return upper
+}
@@ -0,0 +1,189 @@
+package mqresttest
+
+import "time"
+
+const (
+	statusStarting = "STARTING"
+	statusRunning  = "RUNNING"
+	statusStopping = "STOPPING"
+	statusStopped  = "STOPPED"
+)
+
+// startableNamespaces are the kinds of object START and STOP take.
+var startableNamespaces = map[string]bool{"CHANNEL": true, "LISTENER": true, "SERVICE": true}
+
+// objectStatus is the status of a started or stopped object. It reports
+// the transitional status for pendingPolls status queries, then the
+// target status.
+type objectStatus struct {
+	current      string
+	target       string
+	pendingPolls int
+	since        time.Time
+}
+
+// newStatus returns a status moving through transitional to target over
+// polls status queries.
+func newStatus(transitional, target string, polls int) *objectStatus {
+	status := &objectStatus{current: transitional, target: target, pendingPolls: polls, since: time.Now()}
+	if polls <= 0 {
+		status.current = target
+	}
+	return status
+}
+
+// poll returns the status a status query reports, and advances the
+// transition.
+func (status *objectStatus) poll() string {
+	current := status.current
+	if status.pendingPolls > 0 {
+		status.pendingPolls--
+		if status.pendingPolls == 0 {
+			status.current = status.target
+		}
+	}
+	return current
+}
+
+// statusDisplays are the DISPLAY commands on object status, by qualifier.
+var statusDisplays = map[string]func(*qmgr, commandRequest) map[string]any{
+	"QMSTATUS": (*qmgr).displayQmgrStatus,
+	"QSTATUS":  (*qmgr).displayQueueStatus,
+	"CHSTATUS": (*qmgr).displayChannelStatus,
+	"LSSTATUS": func(qm *qmgr, request commandRequest) map[string]any {
+		return qm.displayProcessStatus(objectKinds["LISTENER"], request, "PORT", "TRPTYPE", "IPADDR", "BACKLOG")
+	},
+	"SVSTATUS": func(qm *qmgr, request commandRequest) map[string]any {
+		return qm.displayProcessStatus(objectKinds["SERVICE"], request, "SERVTYPE", "STARTCMD", "STARTARG",
+			"STOPCMD", "STOPARG", "STDOUT", "STDERR")
+	},
+}
+
+// start runs START, which moves a channel, listener or service through
+// STARTING to RUNNING. Starting a running object is accepted and changes
+// nothing.
+func (qm *qmgr) start(kind objectKind, request commandRequest) map[string]any {
+	if !startableNamespaces[kind.namespace] {
+		return syntaxError(request)
+	}
+	if _, failure := qm.find(kind, request); failure != nil {
+		return failure
+	}
+	statuses := qm.statuses[kind.namespace]
+	if status := statuses[request.Name]; status == nil || status.target != statusRunning {
+		statuses[request.Name] = newStatus(statusStarting, statusRunning, qm.transitionPolls)
+	}
+	return successResponse(messages[kind.namespace].started)
+}
+
+// stop runs STOP, which moves a channel, listener or service through
+// STOPPING to STOPPED. A channel must have a status to be stopped.
+func (qm *qmgr) stop(kind objectKind, request commandRequest) map[string]any {
+	if !startableNamespaces[kind.namespace] {
+		return syntaxError(request)
+	}
+	if _, failure := qm.find(kind, request); failure != nil {
+		return failure
+	}
+	statuses := qm.statuses[kind.namespace]
+	status := statuses[request.Name]
+	if kind.namespace == "CHANNEL" && (status == nil || status.current == statusStopped) {
+		return failureResponse(ReasonChannelNotActive, channelNotActiveMessage(request.Name))
+	}
+	if status == nil || status.target != statusStopped {
+		statuses[request.Name] = newStatus(statusStopping, statusStopped, qm.transitionPolls)
+	}
+	return successResponse(messages[kind.namespace].stopped)
+}
+
+// displayQmgrStatus runs DISPLAY QMSTATUS.
+func (qm *qmgr) displayQmgrStatus(request commandRequest) map[string]any {
+	date, clock := dateTime(qm.started)
+	status := map[string]any{
+		"QMNAME": qm.name, "STATUS": statusRunning, "CHINIT": statusRunning, "CMDSERV": statusRunning,
+		"CONNS": 0, "STARTDA": date, "STARTTI": clock, "INSTNAME": "Installation1", "INSTPATH": "/opt/mqm",
+	}
+	return displayResponse([]map[string]any{selectAttributes(status, request.ResponseParameters, "QMNAME")})
+}
+
+// displayQueueStatus runs DISPLAY QSTATUS, which reports the local queues
+// matching a name. The depth and handle counts are the queue's CURDEPTH,
+// IPPROCS and OPPROCS attributes.
+func (qm *qmgr) displayQueueStatus(request commandRequest) map[string]any {
+	queues := qm.objects["QUEUE"]
+	var rows []map[string]any
+	for _, name := range matchingNames(queues, request.Name) {
+		queue := queues[name]
+		if queue["TYPE"] != "QLOCAL" {
+			continue
+		}
+		status := map[string]any{
+			"QUEUE": name, "TYPE": "QUEUE", "UNCOM": "NO", "MSGAGE": "", "QTIME": ", ",
+			"LGETDATE": "", "LGETTIME": "", "LPUTDATE": "", "LPUTTIME": "", "MONQ": queue["MONQ"],
+		}
+		for _, key := range []string{"CURDEPTH", "IPPROCS", "OPPROCS"} {
+			status[key] = queue[key]
+		}
+		rows = append(rows, selectAttributes(status, request.ResponseParameters, "QUEUE", "TYPE"))
+	}
+	if len(rows) == 0 {
+		return failureResponse(ReasonUnknownObjectName, notFoundMessage(displayName(request.Name)))
+	}
+	return displayResponse(rows)
+}
+
+// displayChannelStatus runs DISPLAY CHSTATUS, which reports the channels
+// matching a name that have been started or stopped.
+func (qm *qmgr) displayChannelStatus(request commandRequest) map[string]any {
+	statuses := qm.statuses["CHANNEL"]
+	var rows []map[string]any
+	for _, name := range matchingNames(statuses, request.Name) {
+		channel := qm.objects["CHANNEL"][name]
+		status := statuses[name]
+		date, clock := dateTime(status.since)
+		row := map[string]any{
+			"CHANNEL": name, "CHLTYPE": channel["CHLTYPE"], "STATUS": status.poll(), "SUBSTATE": "",
+			"CONNAME": channelAttribute(channel, "CONNAME"), "XMITQ": channelAttribute(channel, "XMITQ"),
+			"MSGS": 0, "BYTSSENT": 0, "BYTSRCVD": 0, "BUFSSENT": 0, "BUFSRCVD": 0,
+			"CHSTADA": date, "CHSTATI": clock,
+		}
+		rows = append(rows, selectAttributes(row, request.ResponseParameters, "CHANNEL", "CHLTYPE", "STATUS"))
+	}
+	if len(rows) == 0 {
+		return failureResponse(ReasonChannelStatusNotFound, channelStatusNotFoundMessage)
+	}
+	return displayResponse(rows)
+}
+
+// displayProcessStatus runs DISPLAY LSSTATUS or SVSTATUS, which report the
+// listeners or services matching a name, with the object attributes named
+// by keys. One that has not been started reports STOPPED.
+func (qm *qmgr) displayProcessStatus(kind objectKind, request commandRequest, keys ...string) map[string]any {
+	objects := qm.objects[kind.namespace]
+	var rows []map[string]any
+	for _, name := range matchingNames(objects, request.Name) {
+		attributes := objects[name]
+		row := map[string]any{kind.nameKey: name, "STATUS": statusStopped}
+		if status := qm.statuses[kind.namespace][name]; status != nil {
+			row["STATUS"] = status.poll()
+			row["STARTDA"], row["STARTTI"] = dateTime(status.since)
+		}
+		for _, key := range append([]string{"DESCR", "CONTROL"}, keys...) {
+			row[key] = attributes[key]
+		}
+		rows = append(rows, selectAttributes(row, request.ResponseParameters, kind.nameKey, "STATUS"))
+	}
+	if len(rows) == 0 {
+		return failureResponse(ReasonUnknownObjectName, notFoundMessage(displayName(request.Name)))
+	}
+	return displayResponse(rows)
+}
+
+// channelAttribute returns a channel attribute, or "" when the channel
+// type has none.
+func channelAttribute(channel map[string]any, key string) any {
+	if value, exists := channel[key]; exists {
+		return value
+	}
+	return ""
+}
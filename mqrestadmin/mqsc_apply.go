@@ -0,0 +1,168 @@
+package mqrestadmin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// ScriptErrorMode controls how ApplyMQSCScript handles a failing command.
+type ScriptErrorMode int
+
+const (
+	// ScriptStopOnError stops at the first command that fails to parse or
+	// run. This is the default.
+	ScriptStopOnError ScriptErrorMode = iota
+	// ScriptContinueOnError runs every command and reports each failure.
+	ScriptContinueOnError
+)
+
+// String returns the name of the error mode.
+func (mode ScriptErrorMode) String() string {
+	switch mode {
+	case ScriptStopOnError:
+		return "stop"
+	case ScriptContinueOnError:
+		return "continue"
+	default:
+		return "unknown"
+	}
+}
+
+// ScriptOptions configures ApplyMQSCScript.
+type ScriptOptions struct {
+	ErrorMode ScriptErrorMode
+}
+
+// ScriptCommandResult reports the outcome of one script command.
+type ScriptCommandResult struct {
+	// Command is the parsed command. For a syntax error only Line and Text
+	// are set.
+	Command MQSCCommand
+	// Parameters are the request parameters passed to the session, with
+	// MQSC names reverse-mapped to snake_case where the mapping data allows.
+	Parameters map[string]any
+	// Objects holds the response objects, for DISPLAY commands.
+	Objects []map[string]any
+	// Err is the parse or command error, or nil on success.
+	Err error
+}
+
+// ScriptResult is the per-command report from ApplyMQSCScript. In stop mode
+// it ends with the failing command; later commands are not run or listed.
+type ScriptResult struct {
+	Commands []ScriptCommandResult
+}
+
+// Failures returns the results of the commands that failed.
+func (result *ScriptResult) Failures() []ScriptCommandResult {
+	var failures []ScriptCommandResult
+	for _, command := range result.Commands {
+		if command.Err != nil {
+			failures = append(failures, command)
+		}
+	}
+	return failures
+}
+
+// ApplyMQSCScript parses an MQSC script (see ParseMQSCScript) and runs each
+// command through the session as a runCommandJSON request. Parameters are
+// reverse-mapped to snake_case where possible and sent through the request
+// mapping pipeline in permissive mode, so attributes the mapping data does
+// not cover are passed through under their MQSC names. Integer and list
+// attributes are converted using the mapping data's type metadata.
+//
+// The returned error joins every command failure, each annotated with its
+// script line; the result reports each command that was attempted.
+func ApplyMQSCScript(ctx context.Context, session *Session, reader io.Reader,
+	opts ScriptOptions,
+) (*ScriptResult, error) {
+	statements, err := scanMQSCStatements(reader)
+	if err != nil {
+		return nil, fmt.Errorf("read MQSC script: %w", err)
+	}
+
+	result := &ScriptResult{}
+	var failures []error
+	for _, statement := range statements {
+		if err := ctx.Err(); err != nil {
+			failures = append(failures, err)
+			break
+		}
+
+		commandResult := session.applyScriptStatement(ctx, statement)
+		result.Commands = append(result.Commands, commandResult)
+		if commandResult.Err == nil {
+			continue
+		}
+
+		var syntaxErr *MQSCSyntaxError
+		if errors.As(commandResult.Err, &syntaxErr) {
+			failures = append(failures, commandResult.Err)
+		} else {
+			failures = append(failures, fmt.Errorf("MQSC line %d (%s %s): %w", statement.line,
+				commandResult.Command.Verb, commandResult.Command.Qualifier, commandResult.Err))
+		}
+		if opts.ErrorMode == ScriptStopOnError {
+			break
+		}
+	}
+	return result, errors.Join(failures...)
+}
+
+func (session *Session) applyScriptStatement(ctx context.Context, statement mqscStatement) ScriptCommandResult {
+	command, err := parseMQSCStatement(statement)
+	if err != nil {
+		return ScriptCommandResult{Command: MQSCCommand{Line: statement.line, Text: statement.text}, Err: err}
+	}
+
+	parameters := session.scriptParameters(command)
+	var name *string
+	if command.Name != "" {
+		name = &command.Name
+	}
+
+	objects, err := session.dispatchCommand(ctx, command.Verb, command.Qualifier, name,
+		commandConfig{requestParameters: parameters}, command.Verb == "DISPLAY", false)
+	return ScriptCommandResult{Command: command, Parameters: parameters, Objects: objects, Err: err}
+}
+
+// scriptParameters converts parsed MQSC parameters to typed, snake_case
+// request parameters when attribute mapping is enabled.
+func (session *Session) scriptParameters(command MQSCCommand) map[string]any {
+	if !session.mapAttributes || session.mapper == nil {
+		return command.Parameters
+	}
+
+	mappingQualifier := session.mapper.resolveMappingQualifier(command.Verb, command.Qualifier)
+	types := session.mapper.data.Qualifiers[mappingQualifier].ResponseTypeMap
+	typed := make(map[string]any, len(command.Parameters))
+	for key, value := range command.Parameters {
+		typed[key] = convertScriptValue(value, types[key])
+	}
+	return session.mapper.reverseRequestAttributes(mappingQualifier, typed)
+}
+
+// convertScriptValue converts a parsed MQSC value to the type the mapping
+// data declares for the attribute.
+func convertScriptValue(value any, valueType string) any {
+	switch valueType {
+	case valueTypeInteger:
+		return convertInteger(value)
+	case valueTypeIntegerList:
+		if items, isList := value.([]string); isList {
+			values := make([]any, len(items))
+			for index, item := range items {
+				values[index] = item
+			}
+			value = values
+		}
+		return convertIntegerList(value)
+	case valueTypeList:
+		if text, isString := value.(string); isString {
+			return []string{text}
+		}
+	}
+	return value
+}
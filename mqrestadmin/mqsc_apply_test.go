@@ -0,0 +1,220 @@
+package mqrestadmin
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestScriptErrorMode_String(t *testing.T) {
+	tests := []struct {
+		mode ScriptErrorMode
+		want string
+	}{
+		{ScriptStopOnError, "stop"},
+		{ScriptContinueOnError, "continue"},
+		{ScriptErrorMode(99), "unknown"},
+	}
+	for _, tt := range tests {
+		if got := tt.mode.String(); got != tt.want {
+			t.Errorf("ScriptErrorMode(%d).String() = %q, want %q", int(tt.mode), got, tt.want)
+		}
+	}
+}
+
+func TestApplyMQSCScript_MappedParameters(t *testing.T) {
+	transport := newMockTransport()
+	transport.addSuccessResponse()
+	transport.addSuccessResponse()
+	transport.addSuccessResponse(map[string]any{"QUEUE": "APP.Q", "CURDEPTH": float64(0)})
+	session := newTestSessionWithMapping(transport)
+
+	script := `DEFINE QLOCAL(APP.Q) DESCR('Orders') MAXDEPTH(5000) DEFPSIST(YES) NOREPLACE ZZATTR(X)
+DEFINE NAMELIST(CLUSTERS) NAMES(CLUS1)
+DISPLAY QLOCAL(APP.Q) CURDEPTH
+`
+	result, err := ApplyMQSCScript(context.Background(), session, strings.NewReader(script), ScriptOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(result.Commands) != 3 || len(result.Failures()) != 0 {
+		t.Fatalf("result = %+v, want three successful commands", result)
+	}
+
+	define := result.Commands[0]
+	wantParams := map[string]any{
+		"description":         "Orders",
+		"max_queue_depth":     int64(5000),
+		"default_persistence": "yes",
+		"noreplace":           "yes",
+		"ZZATTR":              "X",
+	}
+	if !reflect.DeepEqual(define.Parameters, wantParams) {
+		t.Errorf("Parameters = %#v, want %#v", define.Parameters, wantParams)
+	}
+
+	firstPayload := transport.calls[0].Payload
+	sent, _ := firstPayload["parameters"].(map[string]any)
+	wantSent := map[string]any{
+		"DESCR":    "Orders",
+		"MAXDEPTH": int64(5000),
+		"DEFPSIST": "YES",
+		"REPLACE":  "NO",
+		"ZZATTR":   "X",
+	}
+	if !reflect.DeepEqual(sent, wantSent) {
+		t.Errorf("sent parameters = %#v, want %#v", sent, wantSent)
+	}
+	if firstPayload["command"] != "DEFINE" || firstPayload["qualifier"] != "QLOCAL" || firstPayload["name"] != "APP.Q" {
+		t.Errorf("payload = %v, want DEFINE QLOCAL APP.Q", firstPayload)
+	}
+
+	if names := result.Commands[1].Parameters["names"]; !reflect.DeepEqual(names, []string{"CLUS1"}) {
+		t.Errorf("names = %#v, want single-item list", names)
+	}
+
+	display := result.Commands[2]
+	if len(display.Objects) != 1 || display.Objects[0]["queue_name"] != "APP.Q" {
+		t.Errorf("Objects = %v, want mapped DISPLAY rows", display.Objects)
+	}
+	if responseParams, _ := transport.calls[2].Payload["responseParameters"].([]string); len(responseParams) == 0 {
+		t.Error("DISPLAY should default responseParameters")
+	}
+}
+
+func TestApplyMQSCScript_MappingDisabled(t *testing.T) {
+	transport := newMockTransport()
+	transport.addSuccessResponse()
+	session := newTestSession(transport)
+
+	result, err := ApplyMQSCScript(context.Background(), session,
+		strings.NewReader("DEFINE QLOCAL(Q1) MAXDEPTH(10)\n"), ScriptOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Commands[0].Parameters["MAXDEPTH"] != "10" {
+		t.Errorf("Parameters = %v, want raw MQSC parameters", result.Commands[0].Parameters)
+	}
+}
+
+func TestApplyMQSCScript_StopOnError(t *testing.T) {
+	transport := newMockTransport()
+	transport.addSuccessResponse()
+	transport.addCommandErrorResponse(2, 2085)
+	session := newTestSessionWithMapping(transport)
+
+	script := "DEFINE QLOCAL(Q1)\nDELETE QLOCAL(MISSING)\nDEFINE QLOCAL(Q3)\n"
+	result, err := ApplyMQSCScript(context.Background(), session, strings.NewReader(script), ScriptOptions{})
+
+	var cmdErr *CommandError
+	if !errors.As(err, &cmdErr) {
+		t.Fatalf("expected CommandError, got %T: %v", err, err)
+	}
+	if !strings.Contains(err.Error(), "MQSC line 2 (DELETE QLOCAL)") {
+		t.Errorf("err = %v, want line annotation", err)
+	}
+	if len(result.Commands) != 2 || transport.callCount() != 2 {
+		t.Errorf("commands = %d, calls = %d; want stop after line 2", len(result.Commands), transport.callCount())
+	}
+}
+
+func TestApplyMQSCScript_ContinueOnError(t *testing.T) {
+	transport := newMockTransport()
+	transport.addCommandErrorResponse(2, 2085)
+	transport.addSuccessResponse()
+	session := newTestSessionWithMapping(transport)
+
+	script := "DELETE QLOCAL(MISSING)\nDEFINE QLOCAL(\nDEFINE QLOCAL(Q3)\n"
+	result, err := ApplyMQSCScript(context.Background(), session, strings.NewReader(script),
+		ScriptOptions{ErrorMode: ScriptContinueOnError})
+
+	var syntaxErr *MQSCSyntaxError
+	if !errors.As(err, &syntaxErr) || syntaxErr.Line != 2 {
+		t.Errorf("err = %v, want syntax error at line 2", err)
+	}
+	var cmdErr *CommandError
+	if !errors.As(err, &cmdErr) {
+		t.Errorf("err = %v, want CommandError joined", err)
+	}
+	if len(result.Commands) != 3 || len(result.Failures()) != 2 {
+		t.Errorf("commands = %d, failures = %d; want 3 and 2", len(result.Commands), len(result.Failures()))
+	}
+	if result.Commands[1].Command.Line != 2 || result.Commands[1].Command.Text != "DEFINE QLOCAL(" {
+		t.Errorf("syntax error command = %+v", result.Commands[1].Command)
+	}
+}
+
+func TestApplyMQSCScript_ContextCanceled(t *testing.T) {
+	transport := newMockTransport()
+	session := newTestSessionWithMapping(transport)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	result, err := ApplyMQSCScript(ctx, session, strings.NewReader("DEFINE QLOCAL(Q1)\n"),
+		ScriptOptions{ErrorMode: ScriptContinueOnError})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("err = %v, want context.Canceled", err)
+	}
+	if len(result.Commands) != 0 || transport.callCount() != 0 {
+		t.Error("no commands should run after cancellation")
+	}
+}
+
+func TestApplyMQSCScript_ReadError(t *testing.T) {
+	session := newTestSessionWithMapping(newMockTransport())
+
+	if _, err := ApplyMQSCScript(context.Background(), session, failingReader{}, ScriptOptions{}); err == nil {
+		t.Error("expected read error")
+	}
+}
+
+func TestConvertScriptValue(t *testing.T) {
+	tests := []struct {
+		value     any
+		valueType string
+		want      any
+	}{
+		{"42", valueTypeInteger, int64(42)},
+		{"abc", valueTypeInteger, "abc"},
+		{[]string{"1", "2"}, valueTypeIntegerList, []int64{1, 2}},
+		{"3", valueTypeIntegerList, []int64{3}},
+		{"A", valueTypeList, []string{"A"}},
+		{[]string{"A", "B"}, valueTypeList, []string{"A", "B"}},
+		{"X", "", "X"},
+	}
+	for _, tt := range tests {
+		if got := convertScriptValue(tt.value, tt.valueType); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("convertScriptValue(%#v, %q) = %#v, want %#v", tt.value, tt.valueType, got, tt.want)
+		}
+	}
+}
+
+func TestReverseRequestAttributes(t *testing.T) {
+	mapper, err := newAttributeMapper()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	reversed := mapper.reverseRequestAttributes("queue", map[string]any{
+		"DESCR":    "text",
+		"DEFPSIST": "UNKNOWNVALUE",
+		"REPLACE":  "YES",
+		"PURGE":    []string{"odd"},
+	})
+	want := map[string]any{
+		"description":         "text",
+		"default_persistence": "UNKNOWNVALUE",
+		"replace":             "yes",
+		"PURGE":               []string{"odd"},
+	}
+	if !reflect.DeepEqual(reversed, want) {
+		t.Errorf("reversed = %#v, want %#v", reversed, want)
+	}
+
+	unknown := mapper.reverseRequestAttributes("nosuchqualifier", map[string]any{"A": "B"})
+	if unknown["A"] != "B" {
+		t.Errorf("unknown qualifier = %v, want passthrough", unknown)
+	}
+}
@@ -0,0 +1,166 @@
+package mqrestadmin
+
+import (
+	"regexp"
+	"strings"
+)
+
+// mqscMessageIDPattern matches a message ID at the start of a response line,
+// such as AMQ8409I or CSQM401I, followed by a colon, whitespace, or the end
+// of the line.
+var mqscMessageIDPattern = regexp.MustCompile(`^([A-Z]{3}[A-Z0-9]{4}[A-Z])(?::|\s|$)`)
+
+// MQSCMessage is one message from plain-text MQSC output, together with the
+// KEY(VALUE) attributes reported on the lines that follow it.
+type MQSCMessage struct {
+	// MessageID is the message identifier, e.g. "AMQ8409I". It is empty for
+	// lines that appear before any message ID.
+	MessageID string
+	// Text is the message text following the ID, plus any continuation
+	// lines that do not hold attributes.
+	Text string
+	// Attributes maps attribute keywords to their values. Keywords reported
+	// without a value map to an empty string.
+	Attributes map[string]string
+}
+
+// Severity returns the severity suffix of the message ID: "I"
+// (informational), "W" (warning), "E" (error), "S" (severe), or "A"
+// (action required). It returns an empty string when there is no message ID.
+func (message MQSCMessage) Severity() string {
+	if message.MessageID == "" {
+		return ""
+	}
+	return message.MessageID[len(message.MessageID)-1:]
+}
+
+// IsError reports whether the message ID carries an error or severe
+// severity.
+func (message MQSCMessage) IsError() bool {
+	severity := message.Severity()
+	return severity == "E" || severity == "S"
+}
+
+// ParseMQSCOutput splits plain-text MQSC output into messages. A line that
+// starts with a message ID begins a new message; lines holding KEY(VALUE)
+// pairs add attributes to the current message; any other line is appended
+// to the current message text.
+func ParseMQSCOutput(lines []string) []MQSCMessage {
+	var messages []MQSCMessage
+
+	for _, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" {
+			continue
+		}
+
+		if match := mqscMessageIDPattern.FindStringSubmatch(trimmed); match != nil {
+			text := strings.TrimSpace(strings.TrimPrefix(trimmed[len(match[1]):], ":"))
+			messages = append(messages, MQSCMessage{
+				MessageID:  match[1],
+				Text:       text,
+				Attributes: map[string]string{},
+			})
+			continue
+		}
+
+		if len(messages) == 0 {
+			messages = append(messages, MQSCMessage{Attributes: map[string]string{}})
+		}
+		current := &messages[len(messages)-1]
+
+		if attributes, isAttributeLine := parseMQSCAttributeLine(trimmed); isAttributeLine {
+			for key, value := range attributes {
+				current.Attributes[key] = value
+			}
+			continue
+		}
+
+		if current.Text == "" {
+			current.Text = trimmed
+		} else {
+			current.Text += " " + trimmed
+		}
+	}
+	return messages
+}
+
+// parseMQSCAttributeLine parses a line of KEY(VALUE) pairs and bare
+// keywords. It reports false when the line holds anything else, or no
+// KEY(VALUE) pair at all, so ordinary text is not mistaken for keywords.
+func parseMQSCAttributeLine(line string) (map[string]string, bool) {
+	attributes := map[string]string{}
+	hasPair := false
+	position := 0
+
+	for position < len(line) {
+		if line[position] == ' ' || line[position] == '\t' {
+			position++
+			continue
+		}
+
+		keyStart := position
+		for position < len(line) && isMQSCKeywordChar(line[position]) {
+			position++
+		}
+		if position == keyStart {
+			return nil, false
+		}
+		key := strings.ToUpper(line[keyStart:position])
+
+		if position == len(line) || line[position] != '(' {
+			if position < len(line) && line[position] != ' ' && line[position] != '\t' {
+				return nil, false
+			}
+			attributes[key] = ""
+			continue
+		}
+
+		value, end, closed := scanParenthesizedValue(line, position)
+		if !closed {
+			return nil, false
+		}
+		attributes[key] = unquoteMQSCValue(value)
+		hasPair = true
+		position = end
+	}
+	return attributes, hasPair
+}
+
+// scanParenthesizedValue reads the value inside the parentheses opening at
+// start, honoring single-quoted strings and nested parentheses. It returns
+// the trimmed value text, the position after the closing parenthesis, and
+// whether the parentheses were closed.
+func scanParenthesizedValue(line string, start int) (string, int, bool) {
+	depth := 0
+	inQuotes := false
+	for position := start; position < len(line); position++ {
+		switch character := line[position]; {
+		case character == '\'':
+			inQuotes = !inQuotes
+		case inQuotes:
+		case character == '(':
+			depth++
+		case character == ')':
+			depth--
+			if depth == 0 {
+				return strings.TrimSpace(line[start+1 : position]), position + 1, true
+			}
+		}
+	}
+	return "", 0, false
+}
+
+// unquoteMQSCValue strips surrounding single quotes from an MQSC value and
+// collapses doubled quotes inside it.
+func unquoteMQSCValue(value string) string {
+	if len(value) >= 2 && value[0] == '\'' && value[len(value)-1] == '\'' {
+		return strings.ReplaceAll(value[1:len(value)-1], "''", "'")
+	}
+	return value
+}
+
+func isMQSCKeywordChar(character byte) bool {
+	return (character >= 'A' && character <= 'Z') || (character >= 'a' && character <= 'z') ||
+		(character >= '0' && character <= '9') || character == '_'
+}
@@ -0,0 +1,107 @@
+package mqrestadmin
+
+import "testing"
+
+func TestParseMQSCOutput_DisplayDetails(t *testing.T) {
+	messages := ParseMQSCOutput([]string{
+		"AMQ8409I: Display Queue details.",
+		"   QUEUE(APP.Q)                          TYPE(QLOCAL)",
+		"   DESCR('Orders (EU) ''primary''')      CURDEPTH(12)",
+		"   ALTDATE(2026-01-02)                   CLUSTER( )",
+		"AMQ8409I: Display Queue details.",
+		"   QUEUE(APP.R)                          TYPE(QLOCAL)",
+	})
+
+	if len(messages) != 2 {
+		t.Fatalf("got %d messages, want 2", len(messages))
+	}
+	first := messages[0]
+	if first.MessageID != "AMQ8409I" || first.Text != "Display Queue details." {
+		t.Errorf("first = %+v", first)
+	}
+	want := map[string]string{
+		"QUEUE":    "APP.Q",
+		"TYPE":     "QLOCAL",
+		"DESCR":    "Orders (EU) 'primary'",
+		"CURDEPTH": "12",
+		"ALTDATE":  "2026-01-02",
+		"CLUSTER":  "",
+	}
+	for key, value := range want {
+		if got, exists := first.Attributes[key]; !exists || got != value {
+			t.Errorf("Attributes[%s] = %q, want %q", key, got, value)
+		}
+	}
+	if messages[1].Attributes["QUEUE"] != "APP.R" {
+		t.Errorf("second QUEUE = %q, want APP.R", messages[1].Attributes["QUEUE"])
+	}
+}
+
+func TestParseMQSCOutput_ErrorAndContinuationText(t *testing.T) {
+	messages := ParseMQSCOutput([]string{
+		"",
+		"AMQ8147E: IBM MQ object MISSING not found.",
+		"Check the object name",
+		"and retry.",
+	})
+
+	if len(messages) != 1 {
+		t.Fatalf("got %d messages, want 1", len(messages))
+	}
+	if messages[0].Text != "IBM MQ object MISSING not found. Check the object name and retry." {
+		t.Errorf("Text = %q", messages[0].Text)
+	}
+	if !messages[0].IsError() || messages[0].Severity() != "E" {
+		t.Errorf("severity = %q, want E", messages[0].Severity())
+	}
+}
+
+func TestParseMQSCOutput_ZOSMessagesAndBareKeywords(t *testing.T) {
+	messages := ParseMQSCOutput([]string{
+		"CSQM401I !QM01 QLOCAL(APP.Q) TYPE(QLOCAL)",
+		"QSGDISP(QMGR) NOTRIGGER",
+		"CSQ9022I !QM01 CSQMDRTS ' DISPLAY QUEUE' NORMAL COMPLETION",
+	})
+
+	if len(messages) != 2 {
+		t.Fatalf("got %d messages, want 2", len(messages))
+	}
+	if messages[0].Attributes["QSGDISP"] != "QMGR" {
+		t.Errorf("QSGDISP = %q, want QMGR", messages[0].Attributes["QSGDISP"])
+	}
+	if value, exists := messages[0].Attributes["NOTRIGGER"]; !exists || value != "" {
+		t.Errorf("NOTRIGGER = %q (exists %v), want bare keyword", value, exists)
+	}
+	if messages[1].MessageID != "CSQ9022I" || messages[1].IsError() {
+		t.Errorf("second = %+v, want informational CSQ9022I", messages[1])
+	}
+}
+
+func TestParseMQSCOutput_LinesBeforeMessageID(t *testing.T) {
+	messages := ParseMQSCOutput([]string{"   QUEUE(Q1)", "free text"})
+
+	if len(messages) != 1 {
+		t.Fatalf("got %d messages, want 1", len(messages))
+	}
+	if messages[0].MessageID != "" || messages[0].Severity() != "" || messages[0].IsError() {
+		t.Errorf("message = %+v, want no message ID", messages[0])
+	}
+	if messages[0].Attributes["QUEUE"] != "Q1" || messages[0].Text != "free text" {
+		t.Errorf("message = %+v", messages[0])
+	}
+}
+
+func TestParseMQSCAttributeLine_Rejects(t *testing.T) {
+	lines := []string{
+		"Queue manager not found",
+		"QUEUE(unterminated",
+		"SYSTEM.DEFAULT(X)",
+		"(VALUE)",
+		"KEY(A)extra.",
+	}
+	for _, line := range lines {
+		if _, isAttributeLine := parseMQSCAttributeLine(line); isAttributeLine {
+			t.Errorf("parseMQSCAttributeLine(%q) accepted, want rejected", line)
+		}
+	}
+}
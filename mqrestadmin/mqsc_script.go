@@ -0,0 +1,300 @@
+package mqrestadmin
+
+import (
+	"bufio"
+	"io"
+	"strings"
+)
+
+// MQSCCommand is one command parsed from an MQSC script.
+type MQSCCommand struct {
+	// Line is the 1-based line number where the command starts.
+	Line int
+	// Text is the command text with continuation lines joined.
+	Text string
+	// Verb is the command verb with abbreviations expanded, e.g. "DEFINE".
+	Verb string
+	// Qualifier is the object keyword with abbreviations expanded, e.g.
+	// "QLOCAL".
+	Qualifier string
+	// Name is the value in the qualifier's parentheses, or empty when the
+	// command has none.
+	Name string
+	// Parameters maps MQSC attribute keywords to their values. Values are
+	// strings, or []string for comma-separated lists. Unquoted values are
+	// folded to upper case as runmqsc does; quoted values keep their case.
+	// A bare keyword such as REPLACE maps to "YES", and its NO-prefixed
+	// negation such as NOREPLACE maps the keyword to "NO".
+	Parameters map[string]any
+}
+
+// mqscVerbAbbreviations expands the verb abbreviations runmqsc accepts.
+var mqscVerbAbbreviations = map[string]string{
+	"ALT": "ALTER",
+	"DEF": "DEFINE",
+	"DIS": "DISPLAY",
+}
+
+// mqscQualifierAbbreviations expands the object keyword abbreviations
+// runmqsc accepts.
+var mqscQualifierAbbreviations = map[string]string{
+	"CHL":          "CHANNEL",
+	"NL":           "NAMELIST",
+	"PRO":          "PROCESS",
+	"QA":           "QALIAS",
+	"QL":           "QLOCAL",
+	"QM":           "QMODEL",
+	"QR":           "QREMOTE",
+	"STC":          "STGCLASS",
+	"SUBSCRIPTION": "SUB",
+}
+
+// mqscNegatableKeywords lists bare keywords whose NO-prefixed form sets the
+// keyword to NO, e.g. NOREPLACE or NOTRIGGER.
+var mqscNegatableKeywords = map[string]bool{
+	"HARDENBO": true,
+	"PURGE":    true,
+	"REPLACE":  true,
+	"SHARE":    true,
+	"TRIGGER":  true,
+}
+
+// mqscStatement is one command's text, with continuations joined, and the
+// line it starts on.
+type mqscStatement struct {
+	line int
+	text string
+}
+
+type mqscToken struct {
+	keyword  string
+	value    string
+	hasValue bool
+}
+
+// ParseMQSCScript parses an MQSC script of the kind runmqsc and dmpmqcfg
+// consume. Lines whose first non-blank character is an asterisk are
+// comments. A line ending in "-" continues on the next line from its first
+// column; a line ending in "+" continues from the next line's first
+// non-blank character. It returns an MQSCSyntaxError for the first command
+// that cannot be parsed.
+func ParseMQSCScript(reader io.Reader) ([]MQSCCommand, error) {
+	statements, err := scanMQSCStatements(reader)
+	if err != nil {
+		return nil, err
+	}
+
+	commands := make([]MQSCCommand, 0, len(statements))
+	for _, statement := range statements {
+		command, err := parseMQSCStatement(statement)
+		if err != nil {
+			return nil, err
+		}
+		commands = append(commands, command)
+	}
+	return commands, nil
+}
+
+// scanMQSCStatements splits a script into commands, dropping comments and
+// blank lines and joining continuation lines.
+func scanMQSCStatements(reader io.Reader) ([]mqscStatement, error) {
+	scanner := bufio.NewScanner(reader)
+	var statements []mqscStatement
+	var builder strings.Builder
+	lineNumber := 0
+	startLine := 0
+	trimNext := false
+
+	for scanner.Scan() {
+		lineNumber++
+		line := strings.TrimRight(scanner.Text(), " \t\r")
+
+		if startLine == 0 {
+			trimmed := strings.TrimSpace(line)
+			if trimmed == "" || strings.HasPrefix(trimmed, "*") {
+				continue
+			}
+			startLine = lineNumber
+		} else if trimNext {
+			line = strings.TrimLeft(line, " \t")
+		}
+
+		if strings.HasSuffix(line, "-") || strings.HasSuffix(line, "+") {
+			trimNext = strings.HasSuffix(line, "+")
+			builder.WriteString(line[:len(line)-1])
+			continue
+		}
+
+		builder.WriteString(line)
+		statements = append(statements, mqscStatement{line: startLine, text: strings.TrimSpace(builder.String())})
+		builder.Reset()
+		startLine = 0
+		trimNext = false
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	if startLine != 0 && strings.TrimSpace(builder.String()) != "" {
+		statements = append(statements, mqscStatement{line: startLine, text: strings.TrimSpace(builder.String())})
+	}
+	return statements, nil
+}
+
+// parseMQSCStatement parses a single command into its verb, qualifier,
+// name, and parameters.
+func parseMQSCStatement(statement mqscStatement) (MQSCCommand, error) {
+	syntaxError := func(reason string) error {
+		return &MQSCSyntaxError{Line: statement.line, Text: statement.text, Reason: reason}
+	}
+
+	tokens, reason := tokenizeMQSC(statement.text)
+	if reason != "" {
+		return MQSCCommand{}, syntaxError(reason)
+	}
+	if len(tokens) < 2 {
+		return MQSCCommand{}, syntaxError("expected a verb and an object keyword")
+	}
+	if tokens[0].hasValue {
+		return MQSCCommand{}, syntaxError("command verb cannot take a value")
+	}
+
+	command := MQSCCommand{
+		Line:       statement.line,
+		Text:       statement.text,
+		Verb:       expandMQSCKeyword(tokens[0].keyword, mqscVerbAbbreviations),
+		Qualifier:  expandMQSCKeyword(tokens[1].keyword, mqscQualifierAbbreviations),
+		Parameters: map[string]any{},
+	}
+	if tokens[1].hasValue {
+		name, isString := parseMQSCValue(tokens[1].value).(string)
+		if !isString {
+			return MQSCCommand{}, syntaxError("object name cannot be a list")
+		}
+		command.Name = name
+	}
+
+	for _, token := range tokens[2:] {
+		switch {
+		case token.hasValue:
+			command.Parameters[token.keyword] = parseMQSCValue(token.value)
+		case strings.HasPrefix(token.keyword, "NO") && mqscNegatableKeywords[token.keyword[2:]]:
+			command.Parameters[token.keyword[2:]] = "NO"
+		default:
+			command.Parameters[token.keyword] = "YES"
+		}
+	}
+	return command, nil
+}
+
+func expandMQSCKeyword(keyword string, abbreviations map[string]string) string {
+	if expanded, exists := abbreviations[keyword]; exists {
+		return expanded
+	}
+	return keyword
+}
+
+// tokenizeMQSC splits command text into keywords with optional
+// parenthesized values. It returns a non-empty reason when the text is
+// malformed.
+func tokenizeMQSC(text string) ([]mqscToken, string) {
+	var tokens []mqscToken
+	position := 0
+
+	for position < len(text) {
+		if isMQSCBlank(text[position]) {
+			position++
+			continue
+		}
+
+		keywordStart := position
+		for position < len(text) && isMQSCKeywordChar(text[position]) {
+			position++
+		}
+		if position == keywordStart {
+			return nil, "unexpected character " + string(text[position])
+		}
+		token := mqscToken{keyword: strings.ToUpper(text[keywordStart:position])}
+
+		valueStart := position
+		for valueStart < len(text) && isMQSCBlank(text[valueStart]) {
+			valueStart++
+		}
+		if valueStart < len(text) && text[valueStart] == '(' {
+			value, end, closed := scanParenthesizedValue(text, valueStart)
+			if !closed {
+				return nil, "unbalanced parentheses or quotes in " + token.keyword
+			}
+			token.value = value
+			token.hasValue = true
+			position = end
+		}
+		tokens = append(tokens, token)
+	}
+	return tokens, ""
+}
+
+// parseMQSCValue converts a raw parenthesized value into a string, or a
+// []string when it holds a comma-separated list.
+func parseMQSCValue(raw string) any {
+	items := splitMQSCList(raw)
+	if len(items) == 1 {
+		return foldMQSCValue(items[0])
+	}
+
+	values := make([]string, len(items))
+	for index, item := range items {
+		values[index] = foldMQSCValue(item)
+	}
+	return values
+}
+
+// splitMQSCList splits a value on commas outside quotes and nested
+// parentheses.
+func splitMQSCList(raw string) []string {
+	var items []string
+	depth := 0
+	inQuotes := false
+	itemStart := 0
+	for position := 0; position < len(raw); position++ {
+		switch character := raw[position]; {
+		case character == '\'':
+			inQuotes = !inQuotes
+		case inQuotes:
+		case character == '(':
+			depth++
+		case character == ')':
+			depth--
+		case character == ',' && depth == 0:
+			items = append(items, strings.TrimSpace(raw[itemStart:position]))
+			itemStart = position + 1
+		}
+	}
+	return append(items, strings.TrimSpace(raw[itemStart:]))
+}
+
+// foldMQSCValue unquotes a fully quoted value, or folds the unquoted parts
+// of any other value to upper case.
+func foldMQSCValue(item string) string {
+	if len(item) >= 2 && item[0] == '\'' && item[len(item)-1] == '\'' {
+		return unquoteMQSCValue(item)
+	}
+
+	var builder strings.Builder
+	inQuotes := false
+	for _, character := range item {
+		if character == '\'' {
+			inQuotes = !inQuotes
+		}
+		if inQuotes {
+			builder.WriteRune(character)
+		} else {
+			builder.WriteString(strings.ToUpper(string(character)))
+		}
+	}
+	return builder.String()
+}
+
+func isMQSCBlank(character byte) bool {
+	return character == ' ' || character == '\t'
+}
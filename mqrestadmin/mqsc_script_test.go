@@ -0,0 +1,148 @@
+package mqrestadmin
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// parseTestScript parses a script covering comments, continuations,
+// quoting, keyword forms, and value lists.
+func parseTestScript(t *testing.T) []MQSCCommand {
+	t.Helper()
+	script := `* Queue definitions
+   * indented comment
+
+DEF QL(app.orders) +
+    DESCR('Orders (EU) ''primary''') -
+ MAXDEPTH(5000) REPLACE
+DEFINE QREMOTE(APP.REMOTE) RNAME('remote.q') RQMNAME(QM2) XMITQ(QM2.XMIT) NOREPLACE
+ALTER QMGR DESCR('Gateway')
+SET AUTHREC PROFILE('APP.**') OBJTYPE(QUEUE) PRINCIPAL('app') AUTHADD(PUT, GET,'browse')
+DEFINE NAMELIST(CLUSTERS) NAMES(A, B)
+DEFINE QLOCAL(UNTERMINATED) +
+`
+
+	commands, err := ParseMQSCScript(strings.NewReader(script))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(commands) != 6 {
+		t.Fatalf("got %d commands, want 6", len(commands))
+	}
+	return commands
+}
+
+func TestParseMQSCScript_CommandsAndContinuations(t *testing.T) {
+	commands := parseTestScript(t)
+
+	first := commands[0]
+	if first.Line != 4 || first.Verb != "DEFINE" || first.Qualifier != "QLOCAL" || first.Name != "APP.ORDERS" {
+		t.Errorf("first = %+v, want DEFINE QLOCAL APP.ORDERS at line 4", first)
+	}
+	if first.Text != "DEF QL(app.orders) DESCR('Orders (EU) ''primary''')  MAXDEPTH(5000) REPLACE" {
+		t.Errorf("Text = %q", first.Text)
+	}
+	wantParams := map[string]any{
+		"DESCR":    "Orders (EU) 'primary'",
+		"MAXDEPTH": "5000",
+		"REPLACE":  "YES",
+	}
+	if !reflect.DeepEqual(first.Parameters, wantParams) {
+		t.Errorf("Parameters = %v, want %v", first.Parameters, wantParams)
+	}
+	if commands[5].Line != 11 || commands[5].Name != "UNTERMINATED" {
+		t.Errorf("last = %+v, want trailing continuation kept", commands[5])
+	}
+}
+
+func TestParseMQSCScript_ParameterForms(t *testing.T) {
+	commands := parseTestScript(t)
+
+	if commands[1].Parameters["REPLACE"] != "NO" || commands[1].Parameters["RNAME"] != "remote.q" {
+		t.Errorf("second = %v, want REPLACE NO and quoted RNAME", commands[1].Parameters)
+	}
+	if commands[2].Name != "" || commands[2].Qualifier != "QMGR" {
+		t.Errorf("third = %+v, want ALTER QMGR without a name", commands[2])
+	}
+	authAdd := commands[3].Parameters["AUTHADD"]
+	if !reflect.DeepEqual(authAdd, []string{"PUT", "GET", "browse"}) {
+		t.Errorf("AUTHADD = %#v, want list", authAdd)
+	}
+}
+
+func TestParseMQSCScript_SyntaxErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		script string
+		reason string
+	}{
+		{"unbalanced", "DEFINE QLOCAL(Q1) DESCR('open", "unbalanced"},
+		{"unexpected character", "DEFINE QLOCAL(Q1) = MAXDEPTH(1)", "unexpected character"},
+		{"verb only", "END", "expected a verb"},
+		{"verb with value", "DEFINE(X) QLOCAL(Q1)", "verb cannot take a value"},
+		{"list name", "DEFINE QLOCAL(A,B)", "cannot be a list"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseMQSCScript(strings.NewReader("* header\n" + tt.script + "\n"))
+			var syntaxErr *MQSCSyntaxError
+			if !errors.As(err, &syntaxErr) {
+				t.Fatalf("expected MQSCSyntaxError, got %T: %v", err, err)
+			}
+			if syntaxErr.Line != 2 || !strings.Contains(syntaxErr.Reason, tt.reason) {
+				t.Errorf("error = %+v, want line 2 and reason %q", syntaxErr, tt.reason)
+			}
+		})
+	}
+}
+
+type failingReader struct{}
+
+func (failingReader) Read([]byte) (int, error) {
+	return 0, errors.New("disk error")
+}
+
+func TestParseMQSCScript_ReadError(t *testing.T) {
+	if _, err := ParseMQSCScript(failingReader{}); err == nil || !strings.Contains(err.Error(), "disk error") {
+		t.Errorf("err = %v, want read error", err)
+	}
+}
+
+func TestParseMQSCValue(t *testing.T) {
+	tests := []struct {
+		raw  string
+		want any
+	}{
+		{"app.q", "APP.Q"},
+		{"'app.q'", "app.q"},
+		{"", ""},
+		{"CURDEPTH GT 'a b'", "CURDEPTH GT 'a b'"},
+		{"descr eq 'Mixed'", "DESCR EQ 'Mixed'"},
+		{"host(1414),'Other(1415)'", []string{"HOST(1414)", "Other(1415)"}},
+	}
+	for _, tt := range tests {
+		if got := parseMQSCValue(tt.raw); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("parseMQSCValue(%q) = %#v, want %#v", tt.raw, got, tt.want)
+		}
+	}
+}
+
+func TestParseMQSCScript_KeywordSpacingAndAbbreviations(t *testing.T) {
+	commands, err := ParseMQSCScript(strings.NewReader(
+		"dis chl (TO.QM2)\nALT SUBSCRIPTION(S1) NOTRIGGER FORCE\n"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if commands[0].Verb != "DISPLAY" || commands[0].Qualifier != "CHANNEL" || commands[0].Name != "TO.QM2" {
+		t.Errorf("first = %+v", commands[0])
+	}
+	if commands[1].Verb != "ALTER" || commands[1].Qualifier != "SUB" {
+		t.Errorf("second = %+v", commands[1])
+	}
+	if commands[1].Parameters["TRIGGER"] != "NO" || commands[1].Parameters["FORCE"] != "YES" {
+		t.Errorf("Parameters = %v, want TRIGGER NO and FORCE YES", commands[1].Parameters)
+	}
+}
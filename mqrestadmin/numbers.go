@@ -0,0 +1,163 @@
+package mqrestadmin
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// NumberMode controls how numeric values in REST API responses are surfaced.
+// Responses are always decoded with json.Decoder.UseNumber, so no precision
+// is lost before the mode is applied.
+type NumberMode int
+
+const (
+	// NumberFloat64 surfaces numbers as float64. Integers above 2^53 lose
+	// precision. This is the default.
+	NumberFloat64 NumberMode = iota
+	// NumberJSON surfaces numbers as json.Number, preserving the exact text
+	// returned by the REST API.
+	NumberJSON
+	// NumberInt64 surfaces integral numbers as int64 and all other numbers
+	// as float64.
+	NumberInt64
+)
+
+// String returns the name of the number mode.
+func (mode NumberMode) String() string {
+	switch mode {
+	case NumberFloat64:
+		return "float64"
+	case NumberJSON:
+		return "json.Number"
+	case NumberInt64:
+		return "int64"
+	default:
+		return "unknown"
+	}
+}
+
+// WithNumberMode controls how numeric response values are surfaced in
+// command results and LastResponsePayload. Defaults to NumberFloat64.
+func WithNumberMode(mode NumberMode) Option {
+	return func(config *sessionConfig) {
+		config.numberMode = mode
+	}
+}
+
+// decodeJSONObject decodes a JSON object, preserving numbers as json.Number
+// and then normalizing them according to mode.
+func decodeJSONObject(data []byte, mode NumberMode) (map[string]any, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var result map[string]any
+	if err := decoder.Decode(&result); err != nil {
+		return nil, err
+	}
+	if _, err := decoder.Token(); !errors.Is(err, io.EOF) {
+		return nil, errors.New("unexpected data after JSON object")
+	}
+	normalizeNumbers(result, mode)
+	return result, nil
+}
+
+// normalizeNumbers walks a decoded JSON value and returns it with every
+// json.Number converted according to mode. Maps and slices are updated in
+// place.
+func normalizeNumbers(value any, mode NumberMode) any {
+	switch typed := value.(type) {
+	case json.Number:
+		return convertJSONNumber(typed, mode)
+	case map[string]any:
+		for key, item := range typed {
+			typed[key] = normalizeNumbers(item, mode)
+		}
+	case []any:
+		for index, item := range typed {
+			typed[index] = normalizeNumbers(item, mode)
+		}
+	}
+	return value
+}
+
+func convertJSONNumber(number json.Number, mode NumberMode) any {
+	switch mode {
+	case NumberJSON:
+		return number
+	case NumberInt64:
+		if integer, err := number.Int64(); err == nil {
+			return integer
+		}
+	}
+	floatValue, err := number.Float64()
+	if err != nil {
+		// Out of float64 range; keep the exact text rather than an infinity.
+		return number
+	}
+	return floatValue
+}
+
+// Float64Value returns a response value as a float64, whichever NumberMode
+// decoded it. Numeric strings, as MQSC text returns them, are parsed too.
+func Float64Value(value any) (float64, bool) {
+	switch typed := value.(type) {
+	case float64:
+		return typed, true
+	case int64:
+		return float64(typed), true
+	case int:
+		return float64(typed), true
+	case json.Number:
+		number, err := typed.Float64()
+		return number, err == nil
+	case string:
+		number, err := strconv.ParseFloat(strings.TrimSpace(typed), 64)
+		return number, err == nil
+	default:
+		return 0, false
+	}
+}
+
+// Int64Value returns an integral response value as an int64, whichever
+// NumberMode decoded it. Integer strings are parsed too; fractional numbers
+// are not integral and report false.
+func Int64Value(value any) (int64, bool) {
+	switch typed := value.(type) {
+	case float64:
+		return int64(typed), typed == float64(int64(typed))
+	case int64:
+		return typed, true
+	case int:
+		return int64(typed), true
+	case json.Number:
+		number, err := typed.Int64()
+		return number, err == nil
+	case string:
+		number, err := strconv.ParseInt(strings.TrimSpace(typed), 10, 64)
+		return number, err == nil
+	default:
+		return 0, false
+	}
+}
+
+// FormatNumber renders a decoded number in plain decimal notation, keeping
+// the exact text of a json.Number. It reports false for any other value,
+// including strings.
+func FormatNumber(value any) (string, bool) {
+	switch typed := value.(type) {
+	case float64:
+		return strconv.FormatFloat(typed, 'f', -1, 64), true
+	case int64:
+		return strconv.FormatInt(typed, 10), true
+	case int:
+		return strconv.Itoa(typed), true
+	case json.Number:
+		return typed.String(), true
+	default:
+		return "", false
+	}
+}
@@ -0,0 +1,284 @@
+package mqrestadmin
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// largeSequenceNumber is above 2^53 and cannot be represented exactly as a
+// float64.
+const largeSequenceNumber = "9007199254740993"
+
+func TestNumberMode_String(t *testing.T) {
+	tests := []struct {
+		mode NumberMode
+		want string
+	}{
+		{NumberFloat64, "float64"},
+		{NumberJSON, "json.Number"},
+		{NumberInt64, "int64"},
+		{NumberMode(99), "unknown"},
+	}
+	for _, tt := range tests {
+		if got := tt.mode.String(); got != tt.want {
+			t.Errorf("NumberMode(%d).String() = %q, want %q", int(tt.mode), got, tt.want)
+		}
+	}
+}
+
+func TestWithNumberMode(t *testing.T) {
+	transport := newMockTransport()
+	session, err := NewSession("https://localhost:9443/ibmmq/rest/v2", "QM1",
+		BasicAuth{Username: "u", Password: "p"},
+		WithTransport(transport), WithNumberMode(NumberInt64))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if session.numberMode != NumberInt64 {
+		t.Errorf("numberMode = %v, want int64", session.numberMode)
+	}
+}
+
+func TestDisplayChstatus_NumberModes(t *testing.T) {
+	tests := []struct {
+		mode      NumberMode
+		wantMsgs  any
+		wantDepth any
+	}{
+		{NumberFloat64, float64(9007199254740992), float64(3)},
+		{NumberJSON, json.Number(largeSequenceNumber), json.Number("3")},
+		{NumberInt64, int64(9007199254740993), int64(3)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.mode.String(), func(t *testing.T) {
+			transport := newMockTransport()
+			transport.addSuccessResponse(map[string]any{
+				"CHANNEL": "TO.QM2",
+				"MSGS":    json.Number(largeSequenceNumber),
+				"XQTIME":  json.Number("3"),
+			})
+			session := newTestSession(transport)
+			session.numberMode = tt.mode
+
+			rows, err := session.DisplayChstatus(context.Background(), "TO.QM2")
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if rows[0]["MSGS"] != tt.wantMsgs {
+				t.Errorf("MSGS = %#v, want %#v", rows[0]["MSGS"], tt.wantMsgs)
+			}
+			if rows[0]["XQTIME"] != tt.wantDepth {
+				t.Errorf("XQTIME = %#v, want %#v", rows[0]["XQTIME"], tt.wantDepth)
+			}
+			completionCode := session.LastResponsePayload["overallCompletionCode"]
+			if fmt.Sprintf("%T", completionCode) != fmt.Sprintf("%T", tt.wantDepth) {
+				t.Errorf("overallCompletionCode = %#v, want same type as %#v",
+					completionCode, tt.wantDepth)
+			}
+		})
+	}
+}
+
+func TestNumberModes_CommandErrorsDetected(t *testing.T) {
+	for _, mode := range []NumberMode{NumberFloat64, NumberJSON, NumberInt64} {
+		t.Run(mode.String(), func(t *testing.T) {
+			transport := newMockTransport()
+			transport.addCommandErrorResponse(2, 3008)
+			session := newTestSession(transport)
+			session.numberMode = mode
+
+			_, err := session.DisplayQueue(context.Background(), "MISSING")
+			var cmdErr *CommandError
+			if !errors.As(err, &cmdErr) {
+				t.Fatalf("expected CommandError, got %T: %v", err, err)
+			}
+		})
+	}
+}
+
+func TestNumberModes_MappingAndConversion(t *testing.T) {
+	transport := newMockTransport()
+	transport.addSuccessResponse(map[string]any{
+		"QUEUE":    "Q1",
+		"CURDEPTH": json.Number(largeSequenceNumber),
+		"DEFPSIST": "YES",
+	})
+	transport.addSuccessResponse(map[string]any{"QUEUE": "Q1", "CURDEPTH": json.Number("5")})
+	session := newConvertingTestSession(transport)
+	session.numberMode = NumberJSON
+
+	rows, err := session.DisplayQueue(context.Background(), "Q1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if rows[0]["current_queue_depth"] != int64(9007199254740993) {
+		t.Errorf("current_queue_depth = %#v, want exact int64", rows[0]["current_queue_depth"])
+	}
+	if rows[0]["default_persistence"] != "yes" {
+		t.Errorf("default_persistence = %#v, want mapped yes", rows[0]["default_persistence"])
+	}
+
+	session.numberMode = NumberInt64
+	rows, err = session.DisplayQueue(context.Background(), "Q1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if rows[0]["current_queue_depth"] != int64(5) {
+		t.Errorf("current_queue_depth = %#v, want int64(5)", rows[0]["current_queue_depth"])
+	}
+}
+
+func TestDisplaySeq_NumberModes(t *testing.T) {
+	transport := &streamingMockTransport{statusCode: 200, body: `{"commandResponse":[` +
+		streamItem(`{"MSGS":`+largeSequenceNumber+`,"SEQS":[1,2]}`) +
+		`],"overallCompletionCode":0,"overallReasonCode":0}`}
+	session := newTestSession(&transport.mockTransport)
+	session.transport = transport
+	session.numberMode = NumberInt64
+
+	rows, err := collectSeq(t, session.DisplayChstatusSeq(context.Background(), "*"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if rows[0]["MSGS"] != int64(9007199254740993) {
+		t.Errorf("MSGS = %#v, want exact int64", rows[0]["MSGS"])
+	}
+	if sequences, _ := rows[0]["SEQS"].([]any); len(sequences) != 2 || sequences[1] != int64(2) {
+		t.Errorf("SEQS = %#v, want int64 elements", rows[0]["SEQS"])
+	}
+	if session.LastResponsePayload["overallReasonCode"] != int64(0) {
+		t.Errorf("overallReasonCode = %#v, want int64(0)", session.LastResponsePayload["overallReasonCode"])
+	}
+}
+
+func TestConvertJSONNumber(t *testing.T) {
+	tests := []struct {
+		name   string
+		number json.Number
+		mode   NumberMode
+		want   any
+	}{
+		{"float mode", "1.5", NumberFloat64, 1.5},
+		{"int mode integral", "42", NumberInt64, int64(42)},
+		{"int mode fractional", "1.5", NumberInt64, 1.5},
+		{"int mode overflow", "99999999999999999999", NumberInt64, float64(1e20)},
+		{"json mode", "1.50", NumberJSON, json.Number("1.50")},
+		{"float out of range", "1e400", NumberFloat64, json.Number("1e400")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := convertJSONNumber(tt.number, tt.mode); got != tt.want {
+				t.Errorf("convertJSONNumber(%q) = %#v, want %#v", tt.number, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsNonZeroNumber_WideTypes(t *testing.T) {
+	tests := []struct {
+		value any
+		want  bool
+	}{
+		{int64(0), false},
+		{int64(2), true},
+		{json.Number("0"), false},
+		{json.Number("0.0"), false},
+		{json.Number("2035"), true},
+		{json.Number("bogus"), false},
+	}
+	for _, tt := range tests {
+		if got := isNonZeroNumber(tt.value); got != tt.want {
+			t.Errorf("isNonZeroNumber(%#v) = %v, want %v", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestFloat64Value(t *testing.T) {
+	tests := []struct {
+		value  any
+		want   float64
+		number bool
+	}{
+		{float64(1.5), 1.5, true},
+		{int64(2), 2, true},
+		{3, 3, true},
+		{json.Number("4.25"), 4.25, true},
+		{json.Number("bogus"), 0, false},
+		{" 42 ", 42, true},
+		{"CHANNEL", 0, false},
+		{nil, 0, false},
+	}
+	for _, tt := range tests {
+		if got, number := Float64Value(tt.value); got != tt.want || number != tt.number {
+			t.Errorf("Float64Value(%#v) = %v, %v; want %v, %v", tt.value, got, number, tt.want, tt.number)
+		}
+	}
+}
+
+func TestInt64Value(t *testing.T) {
+	tests := []struct {
+		value   any
+		want    int64
+		integer bool
+	}{
+		{float64(2), 2, true},
+		{float64(2.5), 2, false},
+		{int64(9007199254740993), 9007199254740993, true},
+		{3, 3, true},
+		{json.Number("9007199254740993"), 9007199254740993, true},
+		{json.Number("1.5"), 0, false},
+		{" 42 ", 42, true},
+		{"4.2", 0, false},
+		{true, 0, false},
+	}
+	for _, tt := range tests {
+		if got, integer := Int64Value(tt.value); got != tt.want || integer != tt.integer {
+			t.Errorf("Int64Value(%#v) = %v, %v; want %v, %v", tt.value, got, integer, tt.want, tt.integer)
+		}
+	}
+}
+
+func TestFormatNumber(t *testing.T) {
+	tests := []struct {
+		value  any
+		want   string
+		number bool
+	}{
+		{float64(1e21), "1000000000000000000000", true},
+		{float64(0.5), "0.5", true},
+		{int64(-7), "-7", true},
+		{8, "8", true},
+		{json.Number("1.50"), "1.50", true},
+		{"42", "", false},
+		{nil, "", false},
+	}
+	for _, tt := range tests {
+		if got, number := FormatNumber(tt.value); got != tt.want || number != tt.number {
+			t.Errorf("FormatNumber(%#v) = %q, %v; want %q, %v", tt.value, got, number, tt.want, tt.number)
+		}
+	}
+}
+
+func TestParseResponsePayload_TrailingData(t *testing.T) {
+	for _, body := range []string{`{"a":1} x`, `{"a":1}{"b":2}`, `{"a":1}]`} {
+		if _, err := parseResponsePayload(body, NumberJSON); err == nil ||
+			!strings.Contains(err.Error(), "unexpected data after JSON object") {
+			t.Errorf("parseResponsePayload(%q) = %v, want trailing data error", body, err)
+		}
+	}
+	if _, err := parseResponsePayload("{\"a\":1}\n", NumberJSON); err != nil {
+		t.Errorf("parseResponsePayload() with trailing whitespace = %v", err)
+	}
+}
+
+func TestParseResponsePayload_Invalid(t *testing.T) {
+	if _, err := parseResponsePayload("not json", NumberJSON); err == nil ||
+		!strings.Contains(err.Error(), "invalid character") {
+		t.Errorf("err = %v, want JSON syntax error", err)
+	}
+}
@@ -0,0 +1,300 @@
+// Package otelmq instruments mqrestadmin sessions with OpenTelemetry
+// tracing and metrics. It is a separate module, with its own go.mod, so the
+// core mqrestadmin module does not depend on OpenTelemetry.
+//
+// Middleware returns a mqrestadmin.Middleware that records a client span
+// for every request a session sends, and command count, latency and error
+// metrics for MQSC commands:
+//
+//	session, err := mqrestadmin.NewSession(restBaseURL, "QM1", credentials,
+//		mqrestadmin.WithMiddleware(otelmq.Middleware()))
+//
+// Command spans are named after the MQSC command and qualifier, such as
+// "DISPLAY QUEUE", and carry the mq.command, mq.qualifier, mq.object_name,
+// mq.qmgr, mq.completion_code and mq.reason_code attributes along with the
+// HTTP method, URL and status code. Spans are started from the context
+// passed to the session method, so they nest under the caller's span; an
+// instrumented transport, such as one using otelhttp, nests its spans
+// under them in turn. The LTPA login NewSession performs is recorded as an
+// "mq.login" span, and messaging, MFT and administrative resource requests
+// as "mq.request" spans. The login span starts from the context set with
+// mqrestadmin.WithLoginContext; without it, the login is a separate trace.
+//
+// SyncObserver returns a mqrestadmin.SyncObserver that records an "mq.sync"
+// span for each sync or wait call, such as StartChannelSync, so the command
+// and the status checks it makes nest under one span:
+//
+//	session, err := mqrestadmin.NewSession(restBaseURL, "QM1", credentials,
+//		mqrestadmin.WithMiddleware(otelmq.Middleware()),
+//		mqrestadmin.WithSyncObserver(otelmq.SyncObserver()))
+//
+// The status check spans carry mq.sync.operation and mq.sync.poll.
+package otelmq
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/wphillipmoore/mq-rest-admin-go/mqrestadmin"
+)
+
+// ScopeName is the instrumentation scope name of the tracer and meter.
+const ScopeName = "github.com/wphillipmoore/mq-rest-admin-go/mqrestadmin/otelmq"
+
+// Span and metric attribute keys.
+const (
+	CommandKey        = attribute.Key("mq.command")
+	QualifierKey      = attribute.Key("mq.qualifier")
+	ObjectNameKey     = attribute.Key("mq.object_name")
+	QmgrKey           = attribute.Key("mq.qmgr")
+	GatewayQmgrKey    = attribute.Key("mq.gateway_qmgr")
+	CompletionCodeKey = attribute.Key("mq.completion_code")
+	ReasonCodeKey     = attribute.Key("mq.reason_code")
+	SyncOperationKey  = attribute.Key("mq.sync.operation")
+	SyncPollKey       = attribute.Key("mq.sync.poll")
+	ErrorTypeKey      = attribute.Key("error.type")
+)
+
+// Error types recorded in the error.type attribute of the command error
+// counter.
+const (
+	// ErrorTypeTransport is a request that failed without a response.
+	ErrorTypeTransport = "transport"
+	// ErrorTypeHTTP is a response with an HTTP error status.
+	ErrorTypeHTTP = "http"
+	// ErrorTypeCommand is a response with a non-zero MQ completion or
+	// reason code.
+	ErrorTypeCommand = "command"
+)
+
+// Option configures the instrumentation.
+type Option func(*config)
+
+type config struct {
+	tracerProvider trace.TracerProvider
+	meterProvider  metric.MeterProvider
+}
+
+// WithTracerProvider sets the tracer provider. The global provider is used
+// by default.
+func WithTracerProvider(provider trace.TracerProvider) Option {
+	return func(config *config) {
+		config.tracerProvider = provider
+	}
+}
+
+// WithMeterProvider sets the meter provider. The global provider is used
+// by default.
+func WithMeterProvider(provider metric.MeterProvider) Option {
+	return func(config *config) {
+		config.meterProvider = provider
+	}
+}
+
+// instruments holds the tracer and command metrics.
+type instruments struct {
+	tracer   trace.Tracer
+	commands metric.Int64Counter
+	duration metric.Float64Histogram
+	errors   metric.Int64Counter
+}
+
+// Middleware returns a middleware that traces every request and records
+// command metrics:
+//
+//   - mq.client.commands counts MQSC commands by mq.command, mq.qualifier
+//     and mq.qmgr.
+//   - mq.client.command.duration records their latency in seconds with the
+//     same attributes.
+//   - mq.client.command.errors counts failed commands, adding error.type
+//     and, when the response has a non-zero completion or reason code,
+//     mq.reason_code.
+//
+// Completion and reason codes are read from buffered responses; the DISPLAY
+// iterator methods stream their responses, so their spans and metrics
+// carry the HTTP status only.
+func Middleware(opts ...Option) mqrestadmin.Middleware {
+	config := newConfig(opts)
+	meter := config.meterProvider.Meter(ScopeName)
+	instruments := &instruments{tracer: config.tracerProvider.Tracer(ScopeName)}
+	var err error
+	instruments.commands, err = meter.Int64Counter("mq.client.commands",
+		metric.WithDescription("MQSC commands sent"), metric.WithUnit("{command}"))
+	otel.Handle(err)
+	instruments.duration, err = meter.Float64Histogram("mq.client.command.duration",
+		metric.WithDescription("MQSC command latency"), metric.WithUnit("s"))
+	otel.Handle(err)
+	instruments.errors, err = meter.Int64Counter("mq.client.command.errors",
+		metric.WithDescription("MQSC commands that failed"), metric.WithUnit("{command}"))
+	otel.Handle(err)
+
+	return mqrestadmin.NewMiddleware(func(next mqrestadmin.RoundTrip) mqrestadmin.RoundTrip {
+		return func(ctx context.Context, request *mqrestadmin.TransportRequest) (*mqrestadmin.TransportResponse, error) {
+			return instruments.roundTrip(ctx, request, next)
+		}
+	})
+}
+
+// SyncObserver returns a sync observer that records an "mq.sync" span for
+// each sync or wait call, with mq.sync.operation and mq.object_name
+// attributes. The spans of the call's requests nest under it. Only
+// WithTracerProvider applies.
+func SyncObserver(opts ...Option) mqrestadmin.SyncObserver {
+	tracer := newConfig(opts).tracerProvider.Tracer(ScopeName)
+	return func(ctx context.Context, operation mqrestadmin.SyncOperation,
+		name string,
+	) (context.Context, func(error)) {
+		ctx, span := tracer.Start(ctx, "mq.sync", trace.WithSpanKind(trace.SpanKindInternal),
+			trace.WithAttributes(SyncOperationKey.String(operation.String()), ObjectNameKey.String(name)))
+		return ctx, func(err error) {
+			if err != nil {
+				span.RecordError(err)
+				span.SetStatus(codes.Error, err.Error())
+			}
+			span.End()
+		}
+	}
+}
+
+// newConfig applies opts to the default configuration, which uses the
+// global providers.
+func newConfig(opts []Option) config {
+	config := config{
+		tracerProvider: otel.GetTracerProvider(),
+		meterProvider:  otel.GetMeterProvider(),
+	}
+	for _, opt := range opts {
+		opt(&config)
+	}
+	return config
+}
+
+// roundTrip sends request inside a span and records its metrics.
+func (instruments *instruments) roundTrip(ctx context.Context, request *mqrestadmin.TransportRequest,
+	next mqrestadmin.RoundTrip,
+) (*mqrestadmin.TransportResponse, error) {
+	isCommand := request.Command != ""
+	var attributes []attribute.KeyValue
+	if qmgr := qmgrFromURL(request.URL); qmgr != "" {
+		attributes = append(attributes, QmgrKey.String(qmgr))
+	}
+	if isCommand {
+		attributes = append(attributes, CommandKey.String(request.Command), QualifierKey.String(request.Qualifier))
+	}
+	metricAttributes := attributes
+
+	spanAttributes := slices.Clone(attributes)
+	if request.Name != "" {
+		spanAttributes = append(spanAttributes, ObjectNameKey.String(request.Name))
+	}
+	if gateway := request.Headers["ibm-mq-rest-gateway-qmgr"]; gateway != "" {
+		spanAttributes = append(spanAttributes, GatewayQmgrKey.String(gateway))
+	}
+	if poll, exists := mqrestadmin.SyncPollFromContext(ctx); exists {
+		spanAttributes = append(spanAttributes,
+			SyncOperationKey.String(poll.Operation.String()), SyncPollKey.Int(poll.Poll))
+	}
+	spanAttributes = append(spanAttributes,
+		attribute.String("http.request.method", request.Method),
+		attribute.String("url.full", request.URL))
+	if parsed, err := url.Parse(request.URL); err == nil {
+		spanAttributes = append(spanAttributes, attribute.String("server.address", parsed.Hostname()))
+	}
+
+	ctx, span := instruments.tracer.Start(ctx, spanName(request),
+		trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(spanAttributes...))
+	defer span.End()
+
+	start := time.Now()
+	response, err := next(ctx, request)
+	elapsed := time.Since(start).Seconds()
+
+	errorType := ""
+	var completionCode, reasonCode int
+	if err != nil {
+		errorType = ErrorTypeTransport
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	} else {
+		span.SetAttributes(attribute.Int("http.response.status_code", response.StatusCode))
+		var hasCodes bool
+		completionCode, reasonCode, hasCodes = responseCodes(response.Body)
+		if hasCodes {
+			span.SetAttributes(CompletionCodeKey.Int(completionCode), ReasonCodeKey.Int(reasonCode))
+		}
+		switch {
+		case completionCode != 0 || reasonCode != 0:
+			errorType = ErrorTypeCommand
+			span.SetStatus(codes.Error, "MQ reason code "+strconv.Itoa(reasonCode))
+		case response.StatusCode >= 400:
+			errorType = ErrorTypeHTTP
+			span.SetStatus(codes.Error, "HTTP "+strconv.Itoa(response.StatusCode))
+		}
+	}
+
+	if isCommand {
+		measurement := metric.WithAttributes(metricAttributes...)
+		instruments.commands.Add(ctx, 1, measurement)
+		instruments.duration.Record(ctx, elapsed, measurement)
+		if errorType != "" {
+			errorAttributes := append(slices.Clone(metricAttributes), ErrorTypeKey.String(errorType))
+			if errorType == ErrorTypeCommand {
+				errorAttributes = append(errorAttributes, ReasonCodeKey.Int(reasonCode))
+			}
+			instruments.errors.Add(ctx, 1, metric.WithAttributes(errorAttributes...))
+		}
+	}
+	return response, err
+}
+
+// spanName names a request's span: the MQSC command and qualifier, such as
+// "DISPLAY QUEUE", "mq.login" for the LTPA login, or "mq.request".
+func spanName(request *mqrestadmin.TransportRequest) string {
+	switch {
+	case request.Command != "":
+		return strings.TrimSpace(request.Command + " " + request.Qualifier)
+	case strings.HasSuffix(request.URL, "/login"):
+		return "mq.login"
+	default:
+		return "mq.request"
+	}
+}
+
+// qmgrFromURL returns the queue manager named in a REST API URL path, such
+// as QM1 in /admin/action/qmgr/QM1/mqsc, or empty when there is none.
+func qmgrFromURL(requestURL string) string {
+	_, rest, found := strings.Cut(requestURL, "/qmgr/")
+	if !found {
+		return ""
+	}
+	qmgr, _, _ := strings.Cut(rest, "/")
+	qmgr, _, _ = strings.Cut(qmgr, "?")
+	return qmgr
+}
+
+// responseCodes reads the overall completion and reason codes from a
+// runCommandJSON response body.
+func responseCodes(body string) (completionCode, reasonCode int, found bool) {
+	var response struct {
+		OverallCompletionCode *int `json:"overallCompletionCode"`
+		OverallReasonCode     *int `json:"overallReasonCode"`
+	}
+	if err := json.Unmarshal([]byte(body), &response); err != nil || response.OverallCompletionCode == nil {
+		return 0, 0, false
+	}
+	if response.OverallReasonCode != nil {
+		reasonCode = *response.OverallReasonCode
+	}
+	return *response.OverallCompletionCode, reasonCode, true
+}
@@ -0,0 +1,338 @@
+package otelmq
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/wphillipmoore/mq-rest-admin-go/mqrestadmin"
+)
+
+type fakeResponse struct {
+	response *mqrestadmin.TransportResponse
+	err      error
+}
+
+// fakeTransport answers requests from a queue of canned responses.
+type fakeTransport struct {
+	responses []fakeResponse
+}
+
+func (transport *fakeTransport) add(statusCode int, body string, headers map[string]string) {
+	transport.responses = append(transport.responses, fakeResponse{
+		response: &mqrestadmin.TransportResponse{StatusCode: statusCode, Body: body, Headers: headers},
+	})
+}
+
+func (transport *fakeTransport) next() (*mqrestadmin.TransportResponse, error) {
+	response := transport.responses[0]
+	transport.responses = transport.responses[1:]
+	return response.response, response.err
+}
+
+func (transport *fakeTransport) PostJSON(context.Context, string, map[string]any, map[string]string,
+	time.Duration, bool,
+) (*mqrestadmin.TransportResponse, error) {
+	return transport.next()
+}
+
+func (transport *fakeTransport) SendRequest(context.Context, string, string, []byte, map[string]string,
+	time.Duration, bool,
+) (*mqrestadmin.TransportResponse, error) {
+	return transport.next()
+}
+
+func commandBody(completionCode, reasonCode int, rows ...string) string {
+	items := ""
+	for index, row := range rows {
+		if index > 0 {
+			items += ","
+		}
+		items += `{"completionCode":0,"reasonCode":0,"parameters":` + row + `}`
+	}
+	return `{"commandResponse":[` + items + `],"overallCompletionCode":` + strconv.Itoa(completionCode) +
+		`,"overallReasonCode":` + strconv.Itoa(reasonCode) + `}`
+}
+
+type telemetry struct {
+	spans   *tracetest.SpanRecorder
+	metrics *sdkmetric.ManualReader
+}
+
+func newInstrumentedSession(t *testing.T, transport *fakeTransport, credentials mqrestadmin.Credentials,
+	options ...mqrestadmin.Option,
+) (*mqrestadmin.Session, telemetry) {
+	t.Helper()
+	recorder := tracetest.NewSpanRecorder()
+	reader := sdkmetric.NewManualReader()
+	middleware := Middleware(
+		WithTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))),
+		WithMeterProvider(sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))))
+
+	options = append([]mqrestadmin.Option{mqrestadmin.WithTransport(transport),
+		mqrestadmin.WithMapAttributes(false), mqrestadmin.WithMiddleware(middleware)}, options...)
+	session, err := mqrestadmin.NewSession("https://mq.example.com:9443/ibmmq/rest/v2", "QM1", credentials,
+		options...)
+	if err != nil {
+		t.Fatalf("NewSession: %v", err)
+	}
+	return session, telemetry{spans: recorder, metrics: reader}
+}
+
+func spanAttributes(span sdktrace.ReadOnlySpan) map[attribute.Key]attribute.Value {
+	attributes := map[attribute.Key]attribute.Value{}
+	for _, keyValue := range span.Attributes() {
+		attributes[keyValue.Key] = keyValue.Value
+	}
+	return attributes
+}
+
+// checkAttributes reports the span attributes that differ from want,
+// compared as emitted strings.
+func checkAttributes(t *testing.T, span sdktrace.ReadOnlySpan, want map[attribute.Key]string) {
+	t.Helper()
+	attributes := spanAttributes(span)
+	for key, value := range want {
+		if got := attributes[key].Emit(); got != value {
+			t.Errorf("%s span %s = %q, want %q", span.Name(), key, got, value)
+		}
+	}
+}
+
+func TestMiddleware_CommandSpans(t *testing.T) {
+	transport := &fakeTransport{}
+	transport.add(200, "{}", map[string]string{"Set-Cookie": "LtpaToken2=token; Path=/"})
+	transport.add(200, commandBody(0, 0, `{"QUEUE":"APP.IN"}`), nil)
+	transport.add(200, commandBody(2, 2085), nil)
+	ctx, parent := sdktrace.NewTracerProvider().Tracer("test").Start(context.Background(), "provision")
+	session, telemetry := newInstrumentedSession(t, transport,
+		mqrestadmin.LTPAAuth{Username: "admin", Password: "admin"}, mqrestadmin.WithLoginContext(ctx))
+
+	if _, err := session.DisplayQueue(ctx, "APP.*"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := session.ForQmgr("QM2").DisplayQueue(ctx, "MISSING"); err == nil {
+		t.Fatal("expected command error")
+	}
+	parent.End()
+
+	spans := telemetry.spans.Ended()
+	if len(spans) != 3 {
+		t.Fatalf("recorded %d spans, want 3", len(spans))
+	}
+	login, display, missing := spans[0], spans[1], spans[2]
+
+	if login.Name() != "mq.login" || login.Parent().SpanID() != parent.SpanContext().SpanID() {
+		t.Errorf("login span = %s, parent %v", login.Name(), login.Parent())
+	}
+
+	if display.Name() != "DISPLAY QUEUE" || display.SpanKind() != trace.SpanKindClient ||
+		display.Parent().SpanID() != parent.SpanContext().SpanID() {
+		t.Errorf("display span = %s, kind %v, parent %v", display.Name(), display.SpanKind(), display.Parent())
+	}
+	checkAttributes(t, display, map[attribute.Key]string{
+		CommandKey: "DISPLAY", QualifierKey: "QUEUE", ObjectNameKey: "APP.*", QmgrKey: "QM1",
+		"http.request.method": "POST", "server.address": "mq.example.com",
+		"url.full":                  "https://mq.example.com:9443/ibmmq/rest/v2/admin/action/qmgr/QM1/mqsc",
+		"http.response.status_code": "200", ReasonCodeKey: "0",
+	})
+	if display.Status().Code != codes.Unset {
+		t.Errorf("display span status = %v", display.Status())
+	}
+
+	checkAttributes(t, missing, map[attribute.Key]string{
+		QmgrKey: "QM2", GatewayQmgrKey: "QM1", CompletionCodeKey: "2", ReasonCodeKey: "2085",
+	})
+	if missing.Status().Code != codes.Error || missing.Status().Description != "MQ reason code 2085" {
+		t.Errorf("missing span status = %v", missing.Status())
+	}
+}
+
+func TestMiddleware_SyncPollsAndRequests(t *testing.T) {
+	transport := &fakeTransport{}
+	transport.add(200, commandBody(0, 0), nil)
+	transport.add(200, commandBody(0, 0, `{"CHANNEL":"TO.QM2","STATUS":"RUNNING"}`), nil)
+	transport.add(200, `{"installation":[]}`, nil)
+	transport.responses = append(transport.responses, fakeResponse{err: errors.New("connection refused")})
+	session, telemetry := newInstrumentedSession(t, transport,
+		mqrestadmin.BasicAuth{Username: "admin", Password: "admin"})
+
+	if _, err := session.StartChannelSync(context.Background(), "TO.QM2",
+		mqrestadmin.SyncConfig{PollInterval: time.Millisecond}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := session.Installations(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := session.ListQmgrs(context.Background()); err == nil {
+		t.Fatal("expected transport error")
+	}
+
+	spans := telemetry.spans.Ended()
+	if len(spans) != 4 {
+		t.Fatalf("recorded %d spans, want 4", len(spans))
+	}
+	start, poll, installations, failed := spans[0], spans[1], spans[2], spans[3]
+	if _, exists := spanAttributes(start)[SyncPollKey]; start.Name() != "START CHANNEL" || exists {
+		t.Errorf("start span = %s %v", start.Name(), spanAttributes(start))
+	}
+	attributes := spanAttributes(poll)
+	if poll.Name() != "DISPLAY CHSTATUS" || attributes[SyncOperationKey].AsString() != "started" ||
+		attributes[SyncPollKey].AsInt64() != 1 {
+		t.Errorf("poll span = %s %v", poll.Name(), attributes)
+	}
+	attributes = spanAttributes(installations)
+	if installations.Name() != "mq.request" || attributes["http.request.method"].AsString() != "GET" ||
+		attributes[QmgrKey].AsString() != "" {
+		t.Errorf("request span = %s %v", installations.Name(), attributes)
+	}
+	if failed.Status().Code != codes.Error || len(failed.Events()) != 1 {
+		t.Errorf("failed span status = %v, events %v", failed.Status(), failed.Events())
+	}
+}
+
+func TestSyncObserver(t *testing.T) {
+	transport := &fakeTransport{}
+	transport.add(200, commandBody(0, 0), nil)
+	transport.add(200, commandBody(0, 0, `{"CHANNEL":"TO.QM2","STATUS":"RUNNING"}`), nil)
+	transport.add(200, commandBody(2, 2085), nil)
+	recorder := tracetest.NewSpanRecorder()
+	provider := WithTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	session, err := mqrestadmin.NewSession("https://mq.example.com:9443/ibmmq/rest/v2", "QM1",
+		mqrestadmin.BasicAuth{Username: "admin", Password: "admin"},
+		mqrestadmin.WithTransport(transport), mqrestadmin.WithMapAttributes(false),
+		mqrestadmin.WithMiddleware(Middleware(provider)), mqrestadmin.WithSyncObserver(SyncObserver(provider)))
+	if err != nil {
+		t.Fatalf("NewSession: %v", err)
+	}
+
+	config := mqrestadmin.SyncConfig{PollInterval: time.Millisecond}
+	if _, err := session.StartChannelSync(context.Background(), "TO.QM2", config); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := session.StopChannelSync(context.Background(), "MISSING", config); err == nil {
+		t.Fatal("expected command error")
+	}
+
+	spans := recorder.Ended()
+	if len(spans) != 5 {
+		t.Fatalf("recorded %d spans, want 5", len(spans))
+	}
+	start, poll, started, stop, stopped := spans[0], spans[1], spans[2], spans[3], spans[4]
+	if started.Name() != "mq.sync" || started.SpanKind() != trace.SpanKindInternal ||
+		started.Status().Code != codes.Unset {
+		t.Errorf("sync span = %s, kind %v, status %v", started.Name(), started.SpanKind(), started.Status())
+	}
+	checkAttributes(t, started, map[attribute.Key]string{SyncOperationKey: "started", ObjectNameKey: "TO.QM2"})
+	for _, span := range []sdktrace.ReadOnlySpan{start, poll} {
+		if span.Parent().SpanID() != started.SpanContext().SpanID() {
+			t.Errorf("%s span parent = %v, want the sync span", span.Name(), span.Parent())
+		}
+	}
+	if stop.Parent().SpanID() != stopped.SpanContext().SpanID() {
+		t.Errorf("stop span parent = %v, want the sync span", stop.Parent())
+	}
+	checkAttributes(t, stopped, map[attribute.Key]string{SyncOperationKey: "stopped", ObjectNameKey: "MISSING"})
+	if stopped.Status().Code != codes.Error || len(stopped.Events()) != 1 {
+		t.Errorf("failed sync span status = %v, events %v", stopped.Status(), stopped.Events())
+	}
+}
+
+// collectMetrics collects the metrics in reader by name, checking they
+// belong to the otelmq scope.
+func collectMetrics(t *testing.T, reader *sdkmetric.ManualReader) map[string]metricdata.Metrics {
+	t.Helper()
+	var data metricdata.ResourceMetrics
+	if err := reader.Collect(context.Background(), &data); err != nil {
+		t.Fatalf("Collect: %v", err)
+	}
+	metrics := map[string]metricdata.Metrics{}
+	for _, scope := range data.ScopeMetrics {
+		if scope.Scope.Name != ScopeName {
+			t.Errorf("scope = %q", scope.Scope.Name)
+		}
+		for _, metric := range scope.Metrics {
+			metrics[metric.Name] = metric
+		}
+	}
+	return metrics
+}
+
+func TestMiddleware_Metrics(t *testing.T) {
+	transport := &fakeTransport{}
+	transport.add(200, commandBody(0, 0), nil)
+	transport.add(200, commandBody(2, 2085), nil)
+	transport.add(500, "Internal Server Error", nil)
+	transport.responses = append(transport.responses, fakeResponse{err: errors.New("connection refused")})
+	transport.add(200, `{"installation":[]}`, nil)
+	session, telemetry := newInstrumentedSession(t, transport,
+		mqrestadmin.BasicAuth{Username: "admin", Password: "admin"})
+
+	ctx := context.Background()
+	_, _ = session.DisplayQueue(ctx, "APP.*")
+	_, _ = session.DisplayQueue(ctx, "MISSING")
+	_, _ = session.DisplayQueue(ctx, "APP.*")
+	_, _ = session.DisplayQmgr(ctx)
+	_, _ = session.Installations(ctx)
+
+	metrics := collectMetrics(t, telemetry.metrics)
+	counts := map[string]int64{}
+	for _, point := range metrics["mq.client.commands"].Data.(metricdata.Sum[int64]).DataPoints {
+		qualifier, _ := point.Attributes.Value(QualifierKey)
+		counts[qualifier.AsString()] = point.Value
+	}
+	if counts["QUEUE"] != 3 || counts["QMGR"] != 1 || len(counts) != 2 {
+		t.Errorf("command counts = %v, want 3 QUEUE and 1 QMGR", counts)
+	}
+
+	histogram := metrics["mq.client.command.duration"].Data.(metricdata.Histogram[float64])
+	var observations uint64
+	for _, point := range histogram.DataPoints {
+		observations += point.Count
+	}
+	if observations != 4 || metrics["mq.client.command.duration"].Unit != "s" {
+		t.Errorf("duration observations = %d, unit %q", observations, metrics["mq.client.command.duration"].Unit)
+	}
+
+	errorCounts := map[string]int64{}
+	for _, point := range metrics["mq.client.command.errors"].Data.(metricdata.Sum[int64]).DataPoints {
+		errorType, _ := point.Attributes.Value(ErrorTypeKey)
+		key := errorType.AsString()
+		if reasonCode, exists := point.Attributes.Value(ReasonCodeKey); exists {
+			key += ":" + reasonCode.Emit()
+		}
+		errorCounts[key] = point.Value
+	}
+	want := map[string]int64{"command:2085": 1, "http": 1, "transport": 1}
+	if len(errorCounts) != len(want) {
+		t.Errorf("error counts = %v, want %v", errorCounts, want)
+	}
+	for key, count := range want {
+		if errorCounts[key] != count {
+			t.Errorf("error counts = %v, want %v", errorCounts, want)
+		}
+	}
+}
+
+func TestQmgrFromURL(t *testing.T) {
+	for url, want := range map[string]string{
+		"https://host/ibmmq/rest/v2/admin/action/qmgr/QM1/mqsc":         "QM1",
+		"https://host/ibmmq/rest/v2/admin/qmgr/QM2?attributes=*":        "QM2",
+		"https://host/ibmmq/rest/v2/messaging/qmgr/QM3/queue/Q/message": "QM3",
+		"https://host/ibmmq/rest/v2/admin/installation":                 "",
+	} {
+		if got := qmgrFromURL(url); got != want {
+			t.Errorf("qmgrFromURL(%q) = %q, want %q", url, got, want)
+		}
+	}
+}
@@ -0,0 +1,166 @@
+package mqrestadmin
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"maps"
+	"slices"
+	"unicode/utf8"
+)
+
+// ErrNoSubscribers is returned by PublishAndVerify when the topic string
+// has no subscriptions, so a publication could not be delivered anywhere.
+var ErrNoSubscribers = errors.New("topic string has no subscribers")
+
+// SubscriptionDelivery is one subscription's message count before and
+// after a verified publish.
+type SubscriptionDelivery struct {
+	SubscriptionID string
+	MessagesBefore int
+	MessagesAfter  int
+}
+
+// Delivered reports whether the subscription's message count moved.
+func (delivery SubscriptionDelivery) Delivered() bool {
+	return delivery.MessagesAfter > delivery.MessagesBefore
+}
+
+// PublishResult describes a publish confirmed by PublishAndVerify.
+type PublishResult struct {
+	// Subscriptions lists the subscriptions DISPLAY TPSTATUS TYPE(SUB)
+	// reported for the topic string before publishing, by subscription ID.
+	Subscriptions []SubscriptionDelivery
+	// Polls is the number of status checks performed after publishing.
+	Polls int
+	// ElapsedSeconds is the wall-clock time from publishing to delivery.
+	ElapsedSeconds float64
+}
+
+// Publish publishes body on a topic string through the messaging REST API,
+// using the session's credentials, CSRF token and timeout. The body is
+// sent as text when it is valid UTF-8 and as binary data otherwise.
+// Properties are message descriptor fields, as for Message.Properties.
+func (session *Session) Publish(ctx context.Context, topicString string, body []byte,
+	properties map[string]string,
+) error {
+	message := Message{Body: body, ContentType: BinaryContentType, Properties: properties}
+	if utf8.Valid(body) {
+		message.ContentType = TextContentType
+	}
+	return session.Messaging().Publish(ctx, topicString, message)
+}
+
+// PublishAndVerify publishes body on a topic string and confirms it was
+// delivered. DISPLAY TPSTATUS TYPE(SUB) is checked first, and nothing is
+// published if the topic string has no subscriptions (ErrNoSubscribers).
+// After publishing, it polls until the message count of every
+// subscription present before the publish has moved, and returns a
+// TimeoutError if that does not happen within config.Timeout.
+//
+// Message counts are per subscription, so publications from other
+// applications while polling can also move them.
+//
+// Delivery is judged by the NUMMSGS count of each subscription, which is
+// the count DISPLAY SBSTATUS reports. It is read from DISPLAY TPSTATUS
+// TYPE(SUB) because that lists exactly the subscriptions on the topic
+// string, by SUBID, in one command per poll, where DISPLAY SBSTATUS is
+// keyed by subscription name and would need a command per subscription.
+// DISPLAY TPSTATUS TYPE(PUB) is not used: it lists publishers that hold
+// the topic open, and the messaging REST API opens and closes the topic
+// for each publish, so no publisher count is left to move.
+func (session *Session) PublishAndVerify(ctx context.Context, topicString string, body []byte,
+	properties map[string]string, config SyncConfig,
+) (result PublishResult, err error) {
+	ctx, end := session.observeSync(ctx, SyncPublished, topicString)
+	defer func() { end(err) }()
+
+	config, err = normalizeSyncConfig(config)
+	if err != nil {
+		return PublishResult{}, err
+	}
+
+	before, err := session.subscriptionMessageCounts(ctx, topicString)
+	if err != nil {
+		return PublishResult{}, err
+	}
+	if len(before) == 0 {
+		return PublishResult{}, ErrNoSubscribers
+	}
+
+	if err := session.Publish(ctx, topicString, body, properties); err != nil {
+		return PublishResult{}, err
+	}
+
+	startTime := session.clock.now()
+	for {
+		session.clock.sleep(config.PollInterval)
+
+		after, err := session.subscriptionMessageCounts(withSyncPoll(ctx, SyncPublished, topicString, result.Polls+1),
+			topicString)
+		if err != nil {
+			return PublishResult{}, err
+		}
+		result.Polls++
+
+		result.Subscriptions = result.Subscriptions[:0]
+		delivered := true
+		for _, subscriptionID := range slices.Sorted(maps.Keys(before)) {
+			delivery := SubscriptionDelivery{
+				SubscriptionID: subscriptionID,
+				MessagesBefore: before[subscriptionID],
+				MessagesAfter:  before[subscriptionID],
+			}
+			if count, exists := after[subscriptionID]; exists {
+				delivery.MessagesAfter = count
+			}
+			delivered = delivered && delivery.Delivered()
+			result.Subscriptions = append(result.Subscriptions, delivery)
+		}
+
+		result.ElapsedSeconds = session.clock.now().Sub(startTime).Seconds()
+		if delivered {
+			return result, nil
+		}
+		if result.ElapsedSeconds >= config.Timeout.Seconds() {
+			return result, &TimeoutError{
+				Name:           topicString,
+				Operation:      SyncPublished,
+				ElapsedSeconds: result.ElapsedSeconds,
+			}
+		}
+	}
+}
+
+// subscriptionMessageCounts returns the message count of each subscription
+// on a topic string, keyed by subscription ID, from DISPLAY TPSTATUS
+// TYPE(SUB). The MQRC_NO_SUBSCRIPTION error, which the queue manager
+// reports when the topic string has no subscriptions, yields no counts.
+func (session *Session) subscriptionMessageCounts(ctx context.Context, topicString string) (map[string]int, error) {
+	typeKey := "TYPE"
+	if session.mapAttributes {
+		typeKey = "status_type"
+	}
+	rows, err := session.mqscCommand(ctx, "DISPLAY", "TPSTATUS", &topicString,
+		map[string]any{typeKey: "SUB"}, []string{"all"}, nil, true)
+	if HasReasonCode(err, ReasonNoSubscription) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	counts := map[string]int{}
+	for _, row := range rows {
+		subscriptionID := cmp.Or(stringAttribute(row, "subscription_id"), stringAttribute(row, "SUBID"))
+		if subscriptionID == "" {
+			continue
+		}
+		count, exists := row["number_of_messages"]
+		if !exists {
+			count = row["NUMMSGS"]
+		}
+		counts[subscriptionID] = intValue(convertInteger(count))
+	}
+	return counts, nil
+}
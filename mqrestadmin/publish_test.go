@@ -0,0 +1,218 @@
+package mqrestadmin
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestMessaging_Publish(t *testing.T) {
+	session, transport := newMessagingTestSession()
+	transport.addMessageResponse(201, "", nil)
+	transport.addMessageResponse(404,
+		`{"error":[{"completionCode":2,"reasonCode":2085,"message":"MQWB0009E: Could not open the topic"}]}`, nil)
+
+	err := session.Messaging().Publish(context.Background(), "prices/fx", TextMessage("1.08"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	call := transport.lastCall()
+	if call.URL != "https://localhost:9443/ibmmq/rest/v2/messaging/qmgr/QM1/topic/prices%2Ffx/message" {
+		t.Errorf("URL = %q", call.URL)
+	}
+	if transport.methods[0] != "POST" || transport.bodies[0] != "1.08" {
+		t.Errorf("request = %s %q", transport.methods[0], transport.bodies[0])
+	}
+	if call.Headers["Content-Type"] != TextContentType {
+		t.Errorf("Content-Type = %q", call.Headers["Content-Type"])
+	}
+
+	err = session.Messaging().Publish(context.Background(), "prices/fx", TextMessage("1.09"))
+	var messagingErr *MessagingError
+	if !errors.As(err, &messagingErr) || messagingErr.Topic != "prices/fx" || messagingErr.Queue != "" {
+		t.Fatalf("error = %v, want MessagingError for the topic", err)
+	}
+	if err.Error() != "mqrestadmin messaging error (HTTP 404, RC=2085) for topic prices/fx: MQWB0009E: Could not open the topic" {
+		t.Errorf("Error() = %q", err.Error())
+	}
+}
+
+func TestSession_Publish(t *testing.T) {
+	session, transport := newMessagingTestSession()
+	transport.addMessageResponse(201, "", nil)
+	transport.addMessageResponse(201, "", nil)
+
+	err := session.Publish(context.Background(), "prices/fx", []byte("1.08"), map[string]string{"persistence": "1"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	headers := transport.lastCall().Headers
+	if headers["Content-Type"] != TextContentType || headers["ibm-mq-md-persistence"] != "1" {
+		t.Errorf("headers = %v", headers)
+	}
+	if headers["Authorization"] == "" || headers["ibm-mq-rest-csrf-token"] != "local" {
+		t.Errorf("headers = %v, want session authentication", headers)
+	}
+
+	if err := session.Publish(context.Background(), "prices/fx", []byte{0xff, 0xfe}, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if contentType := transport.lastCall().Headers["Content-Type"]; contentType != BinaryContentType {
+		t.Errorf("Content-Type = %q, want binary", contentType)
+	}
+}
+
+// newPublishTestSession returns a messaging test session with attribute
+// mapping enabled and a mock clock.
+func newPublishTestSession() (*Session, *mockRequestTransport) {
+	session, transport := newMessagingTestSession()
+	mapped := newTestSessionWithMapping(transport.mockTransport)
+	session.mapAttributes = mapped.mapAttributes
+	session.mapper = mapped.mapper
+	session.clock = newMockClock()
+	return session, transport
+}
+
+func TestPublishAndVerify_Delivered(t *testing.T) {
+	session, transport := newPublishTestSession()
+	transport.addSuccessResponse(
+		map[string]any{"SUBID": "SUB2", "NUMMSGS": float64(3)},
+		map[string]any{"SUBID": "SUB1", "NUMMSGS": "0"},
+	)
+	transport.addMessageResponse(201, "", nil)
+	transport.addSuccessResponse(
+		map[string]any{"SUBID": "SUB2", "NUMMSGS": float64(4)},
+		map[string]any{"SUBID": "SUB1", "NUMMSGS": float64(0)},
+	)
+	transport.addSuccessResponse(
+		map[string]any{"SUBID": "SUB2", "NUMMSGS": float64(4)},
+		map[string]any{"SUBID": "SUB1", "NUMMSGS": float64(1)},
+		map[string]any{"NUMMSGS": float64(9)},
+	)
+
+	result, err := session.PublishAndVerify(context.Background(), "prices/fx", []byte("1.08"), nil,
+		SyncConfig{Timeout: 10 * time.Second, PollInterval: time.Second})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []SubscriptionDelivery{
+		{SubscriptionID: "SUB1", MessagesBefore: 0, MessagesAfter: 1},
+		{SubscriptionID: "SUB2", MessagesBefore: 3, MessagesAfter: 4},
+	}
+	if !reflect.DeepEqual(result.Subscriptions, want) {
+		t.Errorf("Subscriptions = %+v, want %+v", result.Subscriptions, want)
+	}
+	if result.Polls != 2 || result.ElapsedSeconds != 2 {
+		t.Errorf("Polls = %d, ElapsedSeconds = %v", result.Polls, result.ElapsedSeconds)
+	}
+
+	parameters := transport.calls[0].Payload["parameters"].(map[string]any)
+	if parameters["TYPE"] != "SUB" || transport.calls[0].Payload["qualifier"] != "TPSTATUS" {
+		t.Errorf("payload = %v, want DISPLAY TPSTATUS TYPE(SUB)", transport.calls[0].Payload)
+	}
+	if transport.methods[0] != "POST" || transport.bodies[0] != "1.08" {
+		t.Errorf("publish = %s %q", transport.methods[0], transport.bodies[0])
+	}
+}
+
+func TestPublishAndVerify_NoSubscribers(t *testing.T) {
+	session, transport := newMessagingTestSession()
+	transport.addItemErrorResponse(ReasonNoSubscription)
+	transport.addSuccessResponse()
+
+	for range 2 {
+		_, err := session.PublishAndVerify(context.Background(), "prices/fx", []byte("1.08"), nil, SyncConfig{})
+		if !errors.Is(err, ErrNoSubscribers) {
+			t.Errorf("error = %v, want ErrNoSubscribers", err)
+		}
+	}
+	if parameters := transport.lastCall().Payload["parameters"].(map[string]any); parameters["TYPE"] != "SUB" {
+		t.Errorf("parameters = %v", parameters)
+	}
+	if len(transport.methods) != 0 {
+		t.Errorf("published %d times, want none", len(transport.methods))
+	}
+}
+
+func TestPublishAndVerify_Timeout(t *testing.T) {
+	session, transport := newMessagingTestSession()
+	session.clock = newMockClock()
+	transport.addSuccessResponse(map[string]any{"SUBID": "SUB1", "NUMMSGS": float64(5)})
+	transport.addMessageResponse(201, "", nil)
+	transport.addSuccessResponse(map[string]any{"SUBID": "SUB1", "NUMMSGS": float64(5)})
+	transport.addItemErrorResponse(ReasonNoSubscription)
+
+	result, err := session.PublishAndVerify(context.Background(), "prices/fx", []byte("1.08"), nil,
+		SyncConfig{Timeout: 2 * time.Second, PollInterval: time.Second})
+	var timeoutErr *TimeoutError
+	if !errors.As(err, &timeoutErr) || timeoutErr.Operation != SyncPublished || timeoutErr.Name != "prices/fx" {
+		t.Fatalf("error = %v, want publish TimeoutError", err)
+	}
+	if err.Error() != "mqrestadmin timeout: published prices/fx after 2.0s" {
+		t.Errorf("Error() = %q", err.Error())
+	}
+	want := []SubscriptionDelivery{{SubscriptionID: "SUB1", MessagesBefore: 5, MessagesAfter: 5}}
+	if !reflect.DeepEqual(result.Subscriptions, want) || result.Polls != 2 {
+		t.Errorf("result = %+v", result)
+	}
+}
+
+func TestPublishAndVerify_Errors(t *testing.T) {
+	subscription := map[string]any{"SUBID": "SUB1", "NUMMSGS": float64(0)}
+
+	t.Run("invalid config", func(t *testing.T) {
+		session, _ := newMessagingTestSession()
+		_, err := session.PublishAndVerify(context.Background(), "prices/fx", nil, nil, SyncConfig{Timeout: -1})
+		if err == nil {
+			t.Error("expected error for negative timeout")
+		}
+	})
+
+	t.Run("status failure", func(t *testing.T) {
+		session, transport := newMessagingTestSession()
+		transport.addErrorResponse(errors.New("connection refused"))
+		_, err := session.PublishAndVerify(context.Background(), "prices/fx", nil, nil, SyncConfig{})
+		if err == nil || err.Error() != "connection refused" {
+			t.Errorf("error = %v, want transport failure", err)
+		}
+	})
+
+	t.Run("status command error", func(t *testing.T) {
+		session, transport := newMessagingTestSession()
+		transport.addItemErrorResponse(2035)
+		_, err := session.PublishAndVerify(context.Background(), "prices/fx", nil, nil, SyncConfig{})
+		var commandErr *CommandError
+		if !errors.As(err, &commandErr) || errors.Is(err, ErrNoSubscribers) {
+			t.Errorf("error = %v, want the CommandError", err)
+		}
+		if len(transport.methods) != 0 {
+			t.Errorf("published %d times, want none", len(transport.methods))
+		}
+	})
+
+	t.Run("publish failure", func(t *testing.T) {
+		session, transport := newMessagingTestSession()
+		transport.addSuccessResponse(subscription)
+		transport.addMessageResponse(500, "Internal error", nil)
+		_, err := session.PublishAndVerify(context.Background(), "prices/fx", nil, nil, SyncConfig{})
+		var messagingErr *MessagingError
+		if !errors.As(err, &messagingErr) {
+			t.Errorf("error = %v, want MessagingError", err)
+		}
+	})
+
+	t.Run("poll failure", func(t *testing.T) {
+		session, transport := newMessagingTestSession()
+		session.clock = newMockClock()
+		transport.addSuccessResponse(subscription)
+		transport.addMessageResponse(201, "", nil)
+		transport.addErrorResponse(errors.New("connection reset"))
+		_, err := session.PublishAndVerify(context.Background(), "prices/fx", nil, nil, SyncConfig{})
+		if err == nil || err.Error() != "connection reset" {
+			t.Errorf("error = %v, want transport failure", err)
+		}
+	})
+}
@@ -0,0 +1,97 @@
+package mqrestadmin
+
+// QmgrNameKey is the attribute added to a response row to name the queue
+// manager it came from. Rows from queue sharing group members carry it
+// when the REST API reports the member, and Fleet adds it to merged rows.
+const QmgrNameKey = "qmgr_name"
+
+// QsgDisposition enumerates the MQSC QSGDISP values, which select where an
+// object definition is held in a queue sharing group.
+type QsgDisposition string
+
+// QsgDisposition values.
+const (
+	QsgDispositionQmgr    QsgDisposition = "QMGR"
+	QsgDispositionCopy    QsgDisposition = "COPY"
+	QsgDispositionGroup   QsgDisposition = "GROUP"
+	QsgDispositionShared  QsgDisposition = "SHARED"
+	QsgDispositionPrivate QsgDisposition = "PRIVATE"
+	QsgDispositionLive    QsgDisposition = "LIVE"
+	QsgDispositionAll     QsgDisposition = "ALL"
+)
+
+// WithQsgDisposition sets QSGDISP, the disposition of the objects a command
+// defines, changes, or displays in a queue sharing group.
+func WithQsgDisposition(disposition QsgDisposition) CommandOption {
+	return func(config *commandConfig) {
+		config.qsgDisposition = disposition
+	}
+}
+
+// QsgMemberResult is the outcome of a command on one queue sharing group
+// member, taken from its commandResponse item.
+type QsgMemberResult struct {
+	QmgrName       string
+	CompletionCode int
+	ReasonCode     int
+	// Messages holds the member's response message text, if any.
+	Messages []string
+}
+
+// Succeeded reports whether the member completed the command without a
+// non-zero completion or reason code.
+func (member QsgMemberResult) Succeeded() bool {
+	return member.CompletionCode == 0 && member.ReasonCode == 0
+}
+
+// qsgMemberResults returns one result per commandResponse item that names
+// its queue manager, in response order, or nil when no item does.
+func qsgMemberResults(payload map[string]any) []QsgMemberResult {
+	items, _ := payload["commandResponse"].([]any)
+	var members []QsgMemberResult
+	for _, item := range items {
+		qmgrName := itemQmgrName(item)
+		if qmgrName == "" {
+			continue
+		}
+		itemMap, _ := item.(map[string]any)
+		members = append(members, QsgMemberResult{
+			QmgrName:       qmgrName,
+			CompletionCode: intValue(itemMap["completionCode"]),
+			ReasonCode:     intValue(itemMap["reasonCode"]),
+			Messages:       responseTextLines(itemMap["message"]),
+		})
+	}
+	return members
+}
+
+// itemQmgrName returns the queue manager named by a commandResponse item,
+// or "" when the item does not name one.
+func itemQmgrName(item any) string {
+	itemMap, _ := item.(map[string]any)
+	qmgrName, _ := itemMap["qmgrName"].(string)
+	return qmgrName
+}
+
+// scopeParameters returns the CMDSCOPE and QSGDISP request parameters set
+// by the command options, keyed by their MQSC names.
+func (config commandConfig) scopeParameters() map[string]any {
+	parameters := map[string]any{}
+	if config.commandScope != nil {
+		parameters["CMDSCOPE"] = *config.commandScope
+	}
+	if config.qsgDisposition != "" {
+		parameters["QSGDISP"] = string(config.qsgDisposition)
+	}
+	return parameters
+}
+
+// tagQmgrNames sets QmgrNameKey on each row whose originating queue manager
+// is known. qmgrNames runs parallel to rows.
+func tagQmgrNames(rows []map[string]any, qmgrNames []string) {
+	for index, row := range rows {
+		if qmgrNames[index] != "" {
+			row[QmgrNameKey] = qmgrNames[index]
+		}
+	}
+}
@@ -0,0 +1,268 @@
+package mqrestadmin
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// addQsgResponse queues a response with one commandResponse item per queue
+// sharing group member.
+func (transport *mockTransport) addQsgResponse(items ...map[string]any) {
+	commandResponse := make([]any, len(items))
+	for index, item := range items {
+		commandResponse[index] = item
+	}
+	overallCompletionCode := 0
+	for _, item := range items {
+		if item["completionCode"] != 0 {
+			overallCompletionCode = 2
+		}
+	}
+	transport.addResponse(200, map[string]any{
+		"commandResponse":       commandResponse,
+		"overallCompletionCode": overallCompletionCode,
+		"overallReasonCode":     0,
+	}, nil)
+}
+
+func qsgMember(qmgrName string, completionCode, reasonCode int, parameters map[string]any) map[string]any {
+	item := map[string]any{"qmgrName": qmgrName, "completionCode": completionCode, "reasonCode": reasonCode}
+	if parameters != nil {
+		item["parameters"] = parameters
+	}
+	return item
+}
+
+func TestWithCommandScope_AddsPayloadParameters(t *testing.T) {
+	transport := newMockTransport()
+	transport.addSuccessResponse()
+	session := newTestSessionWithMapping(transport)
+
+	err := session.DefineQlocal(context.Background(), "APP.Q",
+		WithCommandScope("*"),
+		WithQsgDisposition(QsgDispositionGroup),
+		WithRequestParameters(map[string]any{"max_queue_depth": 5000}),
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	parameters := transport.lastCall().Payload["parameters"]
+	want := map[string]any{"MAXDEPTH": 5000, "CMDSCOPE": "*", "QSGDISP": "GROUP"}
+	if !reflect.DeepEqual(parameters, want) {
+		t.Errorf("parameters = %#v, want %#v", parameters, want)
+	}
+}
+
+func TestDisplay_TagsRowsWithMemberQmgr(t *testing.T) {
+	transport := newMockTransport()
+	transport.addQsgResponse(
+		qsgMember("QM1", 0, 0, map[string]any{"queue": "APP.Q", "curdepth": 3}),
+		qsgMember("QM2", 0, 0, map[string]any{"queue": "APP.Q", "curdepth": 7}),
+	)
+	session := newTestSessionWithMapping(transport)
+
+	rows, err := session.DisplayQueue(context.Background(), "APP.Q", WithCommandScope("*"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []map[string]any{
+		{"queue_name": "APP.Q", "current_queue_depth": float64(3), QmgrNameKey: "QM1"},
+		{"queue_name": "APP.Q", "current_queue_depth": float64(7), QmgrNameKey: "QM2"},
+	}
+	if !reflect.DeepEqual(rows, want) {
+		t.Errorf("rows = %#v, want %#v", rows, want)
+	}
+}
+
+func TestDisplay_PartialMemberFailure(t *testing.T) {
+	transport := newMockTransport()
+	failed := qsgMember("QM2", 2, 2085, nil)
+	failed["message"] = []any{"CSQM125I !QM2 CSQMDRTC QUEUE(APP.Q) NOT FOUND"}
+	transport.addQsgResponse(
+		qsgMember("QM1", 0, 0, map[string]any{"QUEUE": "APP.Q"}),
+		failed,
+	)
+	session := newTestSession(transport)
+
+	rows, err := session.DisplayQueue(context.Background(), "APP.Q", WithCommandScope("*"))
+
+	var qsgErr *QsgCommandError
+	if !errors.As(err, &qsgErr) {
+		t.Fatalf("error = %v, want QsgCommandError", err)
+	}
+	if !reflect.DeepEqual(rows, []map[string]any{{"QUEUE": "APP.Q", QmgrNameKey: "QM1"}}) {
+		t.Errorf("rows = %#v, want the QM1 row", rows)
+	}
+	wantFailed := []QsgMemberResult{{
+		QmgrName: "QM2", CompletionCode: 2, ReasonCode: 2085,
+		Messages: []string{"CSQM125I !QM2 CSQMDRTC QUEUE(APP.Q) NOT FOUND"},
+	}}
+	if !reflect.DeepEqual(qsgErr.Failed(), wantFailed) {
+		t.Errorf("Failed() = %#v, want %#v", qsgErr.Failed(), wantFailed)
+	}
+	if succeeded := qsgErr.Succeeded(); len(succeeded) != 1 || succeeded[0].QmgrName != "QM1" {
+		t.Errorf("Succeeded() = %#v, want QM1", succeeded)
+	}
+	if len(qsgErr.Members) != 2 {
+		t.Errorf("Members = %#v, want both members", qsgErr.Members)
+	}
+
+	var cmdErr *CommandError
+	if !errors.As(err, &cmdErr) || cmdErr.StatusCode != 200 {
+		t.Errorf("error does not unwrap to CommandError: %v", err)
+	}
+	if !strings.Contains(err.Error(), "failed on 1 of 2 queue managers: QM2 (CC=2 RC=2085)") {
+		t.Errorf("Error() = %q", err.Error())
+	}
+}
+
+func TestDisplay_AllMembersFailed(t *testing.T) {
+	transport := newMockTransport()
+	transport.addQsgResponse(qsgMember("QM1", 2, 2085, nil), qsgMember("QM2", 2, 2085, nil))
+	session := newTestSession(transport)
+
+	rows, err := session.DisplayQueue(context.Background(), "APP.Q", WithCommandScope("*"))
+	var qsgErr *QsgCommandError
+	if !errors.As(err, &qsgErr) || rows != nil {
+		t.Errorf("rows = %v, error = %v; want no rows and QsgCommandError", rows, err)
+	}
+}
+
+func TestDisplay_PartialFailureMappingError(t *testing.T) {
+	transport := newMockTransport()
+	transport.addQsgResponse(
+		qsgMember("QM1", 0, 0, map[string]any{"BOGUS": "x"}),
+		qsgMember("QM2", 2, 2085, nil),
+	)
+	session := newTestSessionWithMapping(transport)
+
+	_, err := session.DisplayQueue(context.Background(), "APP.Q", WithCommandScope("*"))
+	var mappingErr *MappingError
+	if !errors.As(err, &mappingErr) {
+		t.Errorf("error = %v, want MappingError", err)
+	}
+}
+
+func TestVoidCommand_PartialMemberFailure(t *testing.T) {
+	transport := newMockTransport()
+	transport.addQsgResponse(qsgMember("QM1", 0, 0, nil), qsgMember("QM2", 2, 2085, nil))
+	session := newTestSession(transport)
+
+	err := session.DeleteQueue(context.Background(), "APP.Q", WithCommandScope("*"))
+	var qsgErr *QsgCommandError
+	if !errors.As(err, &qsgErr) || len(qsgErr.Failed()) != 1 {
+		t.Errorf("error = %v, want QsgCommandError with one failed member", err)
+	}
+}
+
+func TestDisplaySeq_TagsRowsWithMemberQmgr(t *testing.T) {
+	transport := newMockTransport()
+	transport.addQsgResponse(
+		qsgMember("QM1", 0, 0, map[string]any{"QUEUE": "APP.A"}),
+		qsgMember("QM2", 0, 0, map[string]any{"QUEUE": "APP.B"}),
+	)
+	session := newTestSession(transport)
+
+	var rows []map[string]any
+	for row, err := range session.DisplayQueueSeq(context.Background(), "APP.*", WithCommandScope("*")) {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		rows = append(rows, row)
+	}
+	want := []map[string]any{{"QUEUE": "APP.A", QmgrNameKey: "QM1"}, {"QUEUE": "APP.B", QmgrNameKey: "QM2"}}
+	if !reflect.DeepEqual(rows, want) {
+		t.Errorf("rows = %#v, want %#v", rows, want)
+	}
+}
+
+func TestDisplaySeq_PartialMemberFailure(t *testing.T) {
+	transport := newMockTransport()
+	failed := qsgMember("QM2", 2, 2085, nil)
+	failed["message"] = []any{"CSQM125I !QM2 CSQMDRTC QUEUE(APP.Q) NOT FOUND"}
+	transport.addQsgResponse(
+		failed,
+		qsgMember("QM1", 0, 0, map[string]any{"QUEUE": "APP.Q"}),
+		qsgMember("QM3", 0, 0, map[string]any{"QUEUE": "APP.Q"}),
+	)
+	session := newTestSession(transport)
+
+	rows, err := collectSeq(t, session.DisplayQueueSeq(context.Background(), "APP.Q", WithCommandScope("*")))
+
+	want := []map[string]any{{"QUEUE": "APP.Q", QmgrNameKey: "QM1"}, {"QUEUE": "APP.Q", QmgrNameKey: "QM3"}}
+	if !reflect.DeepEqual(rows, want) {
+		t.Errorf("rows = %#v, want the QM1 and QM3 rows", rows)
+	}
+	var qsgErr *QsgCommandError
+	if !errors.As(err, &qsgErr) {
+		t.Fatalf("error = %v, want QsgCommandError", err)
+	}
+	wantFailed := []QsgMemberResult{{
+		QmgrName: "QM2", CompletionCode: 2, ReasonCode: 2085,
+		Messages: []string{"CSQM125I !QM2 CSQMDRTC QUEUE(APP.Q) NOT FOUND"},
+	}}
+	if !reflect.DeepEqual(qsgErr.Failed(), wantFailed) || len(qsgErr.Succeeded()) != 2 {
+		t.Errorf("Members = %#v, want QM2 failed and QM1, QM3 succeeded", qsgErr.Members)
+	}
+	var cmdErr *CommandError
+	if !errors.As(err, &cmdErr) || cmdErr.StatusCode != 200 {
+		t.Errorf("error does not unwrap to CommandError: %v", err)
+	}
+}
+
+func TestRunMQSC_QsgMembers(t *testing.T) {
+	transport := newMockTransport()
+	ok := qsgMember("QM1", 0, 0, nil)
+	ok["text"] = []any{"CSQ9022I !QM1 CSQMAQLC ' DEFINE QLOCAL' NORMAL COMPLETION"}
+	transport.addQsgResponse(ok, qsgMember("QM2", 2, 2100, nil))
+	session := newTestSession(transport)
+
+	results, err := session.RunMQSC(context.Background(), "DEFINE QLOCAL(APP.Q)",
+		WithCommandScope("*"), WithQsgDisposition(QsgDispositionCopy))
+
+	var qsgErr *QsgCommandError
+	if !errors.As(err, &qsgErr) {
+		t.Fatalf("error = %v, want QsgCommandError", err)
+	}
+	if len(results) != 2 || results[0].QmgrName != "QM1" || results[1].QmgrName != "QM2" || results[1].ReasonCode != 2100 {
+		t.Errorf("results = %#v, want one result per member", results)
+	}
+	command := transport.lastCall().Payload["parameters"].(map[string]any)["command"]
+	if command != "DEFINE QLOCAL(APP.Q) CMDSCOPE(*) QSGDISP(COPY)" {
+		t.Errorf("command = %q", command)
+	}
+}
+
+func TestCheckCommandErrors_SucceededMembersWithOverallError(t *testing.T) {
+	payload := map[string]any{
+		"overallCompletionCode": 2,
+		"commandResponse":       []any{qsgMember("QM1", 0, 0, nil)},
+	}
+	err := checkCommandErrors(payload, 200)
+	var cmdErr *CommandError
+	var qsgErr *QsgCommandError
+	if !errors.As(err, &cmdErr) || errors.As(err, &qsgErr) {
+		t.Errorf("error = %v, want a plain CommandError", err)
+	}
+}
+
+func TestFleetRows_KeepsMemberQmgrName(t *testing.T) {
+	transport := newMockTransport()
+	transport.addQsgResponse(qsgMember("QM2", 0, 0, map[string]any{"QUEUE": "APP.Q"}))
+	fleet, err := NewFleet([]*Session{newTestSession(transport)})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	rows, err := fleet.DisplayQueue(context.Background(), "APP.Q", WithCommandScope("QM2"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if rows[0][QmgrNameKey] != "QM2" {
+		t.Errorf("qmgr_name = %v, want the member QM2", rows[0][QmgrNameKey])
+	}
+}
@@ -0,0 +1,136 @@
+package mqrestadmin
+
+import (
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// RedactedValue replaces secrets redacted by a Redactor.
+const RedactedValue = "[REDACTED]"
+
+// defaultSensitiveAttributes lists the attributes whose values are always
+// redacted, by MQSC and snake_case name.
+var defaultSensitiveAttributes = []string{
+	"PASSWORD", "LDAPPWD", "SSLKEYP", "KEYRPWD", "SSLCRYP",
+	"password", "ldap_password", "ssl_pass_phrase", "ssl_key_repository_password", "ssl_crypto_hardware",
+}
+
+// Redactor redacts credentials from requests before they are logged or
+// recorded: Authorization headers, cookie values, the login password, and
+// the values of sensitive attributes in MQSC parameters and command text.
+// The session logger uses one, as does mqresttest.RecordingTransport.
+type Redactor struct {
+	// attributes holds the upper-cased names of sensitive attributes.
+	attributes map[string]bool
+	// mqscPattern matches sensitive attributes in MQSC command text.
+	mqscPattern *regexp.Regexp
+}
+
+// NewRedactor returns a redactor for the attributes that are always
+// sensitive, such as PASSWORD, LDAPPWD and SSLKEYP, and names, by
+// snake_case or MQSC name. A snake_case name also covers the MQSC
+// attributes it maps to.
+func NewRedactor(names ...string) *Redactor {
+	mapper, _ := newAttributeMapper()
+	return newRedactor(names, mapper)
+}
+
+// newRedactor builds a redactor for the default and given sensitive
+// attributes, adding the MQSC names the snake_case names map to in mapper,
+// when there is one.
+func newRedactor(names []string, mapper *attributeMapper) *Redactor {
+	attributes := map[string]bool{}
+	for _, name := range slices.Concat(defaultSensitiveAttributes, names) {
+		attributes[strings.ToUpper(name)] = true
+		if mapper == nil {
+			continue
+		}
+		for _, qualifier := range mapper.data.Qualifiers {
+			if mqscName, exists := qualifier.RequestKeyMap[name]; exists {
+				attributes[strings.ToUpper(mqscName)] = true
+			}
+		}
+	}
+
+	alternatives := make([]string, 0, len(attributes))
+	for _, name := range slices.Sorted(maps.Keys(attributes)) {
+		alternatives = append(alternatives, regexp.QuoteMeta(name))
+	}
+	return &Redactor{
+		attributes:  attributes,
+		mqscPattern: regexp.MustCompile(`(?i)\b(` + strings.Join(alternatives, "|") + `)\s*\(\s*(?:'(?:[^']|'')*'|[^)]*)\)`),
+	}
+}
+
+// Parameters returns a copy of parameters with sensitive values redacted.
+func (redactor *Redactor) Parameters(parameters map[string]any) map[string]any {
+	if parameters == nil {
+		return nil
+	}
+	redacted := make(map[string]any, len(parameters))
+	for key, value := range parameters {
+		if redactor.attributes[strings.ToUpper(key)] {
+			value = RedactedValue
+		}
+		redacted[key] = value
+	}
+	return redacted
+}
+
+// MQSC returns MQSC command text with sensitive attribute values redacted,
+// such as LDAPPWD('secret') becoming LDAPPWD([REDACTED]).
+func (redactor *Redactor) MQSC(text string) string {
+	return redactor.mqscPattern.ReplaceAllString(text, "$1("+RedactedValue+")")
+}
+
+// Payload returns a copy of a request payload with sensitive values
+// redacted: the login password, sensitive runCommandJSON parameters, and
+// sensitive attributes in the MQSC text of a runCommand payload.
+func (redactor *Redactor) Payload(payload map[string]any) map[string]any {
+	if payload == nil {
+		return nil
+	}
+	redacted := redactor.Parameters(payload)
+	parameters, isMap := payload["parameters"].(map[string]any)
+	if !isMap {
+		return redacted
+	}
+	redacted["parameters"] = redactor.Parameters(parameters)
+	if text, isText := parameters["command"].(string); isText && payload["type"] == "runCommand" {
+		redacted["parameters"].(map[string]any)["command"] = redactor.MQSC(text)
+	}
+	return redacted
+}
+
+// Headers returns a copy of headers with credentials redacted: the
+// Authorization header, and the values of cookies such as LtpaToken2.
+func (*Redactor) Headers(headers map[string]string) map[string]string {
+	if headers == nil {
+		return nil
+	}
+	redacted := make(map[string]string, len(headers))
+	for key, value := range headers {
+		switch strings.ToLower(key) {
+		case "authorization", "proxy-authorization":
+			value = RedactedValue
+		case "cookie", "set-cookie":
+			value = redactCookies(value)
+		}
+		redacted[key] = value
+	}
+	return redacted
+}
+
+// redactCookies redacts each value in a Cookie or Set-Cookie header,
+// keeping the cookie and attribute names.
+func redactCookies(header string) string {
+	parts := strings.Split(header, ";")
+	for index, part := range parts {
+		if name, _, isPair := strings.Cut(part, "="); isPair {
+			parts[index] = name + "=" + RedactedValue
+		}
+	}
+	return strings.Join(parts, ";")
+}
@@ -0,0 +1,63 @@
+package mqrestadmin
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestRedactor_Payload(t *testing.T) {
+	redactor := NewRedactor("ssl_cipher_spec")
+	for _, test := range []struct {
+		name    string
+		payload map[string]any
+		want    map[string]any
+	}{
+		{"nil", nil, nil},
+		{
+			"login",
+			map[string]any{"username": "admin", "password": "secret"},
+			map[string]any{"username": "admin", "password": RedactedValue},
+		},
+		{
+			"runCommandJSON",
+			map[string]any{"type": "runCommandJSON", "command": "DEFINE", "qualifier": "CHANNEL",
+				"parameters": map[string]any{"SSLCIPH": "TLS_AES_128_GCM_SHA256", "PASSWORD": "secret", "DESCR": "x"}},
+			map[string]any{"type": "runCommandJSON", "command": "DEFINE", "qualifier": "CHANNEL",
+				"parameters": map[string]any{"SSLCIPH": RedactedValue, "PASSWORD": RedactedValue, "DESCR": "x"}},
+		},
+		{
+			"runCommand",
+			map[string]any{"type": "runCommand",
+				"parameters": map[string]any{"command": "ALTER QMGR SSLKEYP('k3y') DESCR('x')"}},
+			map[string]any{"type": "runCommand",
+				"parameters": map[string]any{"command": "ALTER QMGR SSLKEYP([REDACTED]) DESCR('x')"}},
+		},
+	} {
+		if got := redactor.Payload(test.payload); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("%s: Payload() = %v, want %v", test.name, got, test.want)
+		}
+	}
+	if got := redactor.Parameters(nil); got != nil {
+		t.Errorf("Parameters(nil) = %v, want nil", got)
+	}
+}
+
+func TestRedactor_Headers(t *testing.T) {
+	redactor := NewRedactor()
+	if got := redactor.Headers(nil); got != nil {
+		t.Errorf("Headers(nil) = %v, want nil", got)
+	}
+	got := redactor.Headers(map[string]string{
+		"Authorization": "Basic YWRtaW46YWRtaW4=",
+		"Cookie":        "LtpaToken2=token; Path=/",
+		"Accept":        "application/json",
+	})
+	want := map[string]string{
+		"Authorization": RedactedValue,
+		"Cookie":        "LtpaToken2=" + RedactedValue + "; Path=" + RedactedValue,
+		"Accept":        "application/json",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Headers() = %v, want %v", got, want)
+	}
+}
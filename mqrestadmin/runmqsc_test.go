@@ -0,0 +1,175 @@
+package mqrestadmin
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func addRunCommandResponse(transport *mockTransport, overallCompletionCode, overallReasonCode int, items ...any) {
+	transport.addResponse(200, map[string]any{
+		"commandResponse":       items,
+		"overallCompletionCode": float64(overallCompletionCode),
+		"overallReasonCode":     float64(overallReasonCode),
+	}, nil)
+}
+
+func TestRunMQSC_Success(t *testing.T) {
+	transport := newMockTransport()
+	addRunCommandResponse(transport, 0, 0, map[string]any{
+		"completionCode": float64(0),
+		"reasonCode":     float64(0),
+		"text": []any{
+			"AMQ8409I: Display Queue details.\n   QUEUE(APP.Q)   TYPE(QLOCAL)\n   CURDEPTH(4)\n",
+		},
+	})
+	session := newTestSessionWithMapping(transport)
+
+	results, err := session.RunMQSC(context.Background(), "  DISPLAY QLOCAL(APP.Q) CURDEPTH ")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	payload := transport.lastCall().Payload
+	if payload["type"] != "runCommand" {
+		t.Errorf("type = %v, want runCommand", payload["type"])
+	}
+	params, _ := payload["parameters"].(map[string]any)
+	if params["command"] != "DISPLAY QLOCAL(APP.Q) CURDEPTH" {
+		t.Errorf("command = %q, want trimmed command text", params["command"])
+	}
+	if _, hasQualifier := payload["qualifier"]; hasQualifier {
+		t.Error("runCommand payload should not include a qualifier")
+	}
+
+	if len(results) != 1 {
+		t.Fatalf("got %d results, want 1", len(results))
+	}
+	if len(results[0].Text) != 3 || results[0].Text[1] != "   QUEUE(APP.Q)   TYPE(QLOCAL)" {
+		t.Errorf("Text = %q, want three split lines", results[0].Text)
+	}
+
+	messages := results[0].Messages()
+	if len(messages) != 1 || messages[0].MessageID != "AMQ8409I" {
+		t.Fatalf("messages = %+v, want one AMQ8409I", messages)
+	}
+	if messages[0].Attributes["CURDEPTH"] != "4" || messages[0].Attributes["QUEUE"] != "APP.Q" {
+		t.Errorf("attributes = %v, want unmapped MQSC attributes", messages[0].Attributes)
+	}
+}
+
+func TestRunMQSC_CommandScope(t *testing.T) {
+	transport := newMockTransport()
+	addRunCommandResponse(transport, 0, 0,
+		map[string]any{"completionCode": float64(0), "reasonCode": float64(0), "text": []any{"CSQM401I QM01 QUEUE(Q1)"}},
+		map[string]any{"completionCode": float64(0), "reasonCode": float64(0), "text": []any{"CSQM401I QM02 QUEUE(Q1)"}},
+		"not an item",
+	)
+	session := newTestSession(transport)
+
+	results, err := session.RunMQSC(context.Background(), "DISPLAY QLOCAL(Q1)", WithCommandScope("*"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	params, _ := transport.lastCall().Payload["parameters"].(map[string]any)
+	if params["command"] != "DISPLAY QLOCAL(Q1) CMDSCOPE(*)" {
+		t.Errorf("command = %q, want CMDSCOPE appended", params["command"])
+	}
+	if len(results) != 2 {
+		t.Errorf("got %d results, want one per queue manager", len(results))
+	}
+}
+
+func TestRunMQSC_CommandError(t *testing.T) {
+	transport := newMockTransport()
+	addRunCommandResponse(transport, 2, 3008, map[string]any{
+		"completionCode": float64(2),
+		"reasonCode":     float64(2085),
+		"text":           []any{"AMQ8147E: IBM MQ object MISSING not found."},
+	})
+	session := newTestSession(transport)
+
+	_, err := session.RunMQSC(context.Background(), "DISPLAY QLOCAL(MISSING)")
+	var cmdErr *CommandError
+	if !errors.As(err, &cmdErr) {
+		t.Fatalf("expected CommandError, got %T: %v", err, err)
+	}
+	items, _ := cmdErr.Payload["commandResponse"].([]any)
+	if len(items) != 1 {
+		t.Errorf("payload = %v, want response text preserved", cmdErr.Payload)
+	}
+}
+
+func TestRunMQSC_EmptyCommand(t *testing.T) {
+	transport := newMockTransport()
+	session := newTestSession(transport)
+
+	if _, err := session.RunMQSC(context.Background(), "   "); err == nil {
+		t.Error("expected error for empty command text")
+	}
+	if transport.callCount() != 0 {
+		t.Errorf("calls = %d, want 0", transport.callCount())
+	}
+}
+
+func TestRunMQSC_TransportError(t *testing.T) {
+	transport := newMockTransport()
+	transport.addErrorResponse(errors.New("connection refused"))
+	session := newTestSession(transport)
+
+	if _, err := session.RunMQSC(context.Background(), "PING QMGR"); err == nil {
+		t.Error("expected transport error")
+	}
+}
+
+func TestRunMQSC_NumberModes(t *testing.T) {
+	for _, mode := range []NumberMode{NumberFloat64, NumberJSON, NumberInt64} {
+		t.Run(mode.String(), func(t *testing.T) {
+			transport := newMockTransport()
+			addRunCommandResponse(transport, 0, 0, map[string]any{
+				"completionCode": float64(1),
+				"reasonCode":     float64(0),
+				"text":           []any{"AMQ8000W: warning", float64(1)},
+			})
+			session := newTestSession(transport)
+			session.numberMode = mode
+
+			_, err := session.RunMQSC(context.Background(), "PING QMGR")
+			var cmdErr *CommandError
+			if !errors.As(err, &cmdErr) {
+				t.Fatalf("expected CommandError, got %T: %v", err, err)
+			}
+		})
+	}
+}
+
+func TestIntValue(t *testing.T) {
+	tests := []struct {
+		value any
+		want  int
+	}{
+		{float64(2), 2},
+		{int64(3), 3},
+		{4, 4},
+		{json.Number("2085"), 2085},
+		{"5", 5},
+		{"bogus", 0},
+		{nil, 0},
+	}
+	for _, tt := range tests {
+		if got := intValue(tt.value); got != tt.want {
+			t.Errorf("intValue(%#v) = %d, want %d", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestResponseTextLines(t *testing.T) {
+	lines := responseTextLines([]any{"first\nsecond\n", 7, "third"})
+	if len(lines) != 3 || lines[0] != "first" || lines[1] != "second" || lines[2] != "third" {
+		t.Errorf("lines = %q, want first, second, third", lines)
+	}
+	if lines := responseTextLines(nil); lines != nil {
+		t.Errorf("lines = %q, want nil", lines)
+	}
+}
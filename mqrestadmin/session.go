@@ -3,9 +3,13 @@ package mqrestadmin
 import (
 	"context"
 	"crypto/tls"
-	"encoding/json"
+	"crypto/x509"
+	"errors"
 	"fmt"
+	"log/slog"
+	"maps"
 	"net/http"
+	"slices"
 	"strings"
 	"time"
 )
@@ -34,9 +38,16 @@ type Session struct {
 	mappingStrict bool
 	csrfToken     *string
 	mapper        *attributeMapper
+	convertValues bool
+	timeLocation  *time.Location
+	numberMode    NumberMode
 	ltpaCookieName string
 	ltpaToken      string
 	clock          clock
+	capabilities   *Capabilities
+	logger         *slog.Logger
+	redactor       *Redactor
+	syncObserver   SyncObserver
 
 	// LastHTTPStatus is the HTTP status code from the most recent command.
 	LastHTTPStatus int
@@ -66,12 +77,21 @@ type sessionConfig struct {
 	transport            Transport
 	gatewayQmgr          string
 	verifyTLS            bool
+	rootCAs              *x509.CertPool
 	timeout              time.Duration
 	mapAttributes        bool
 	mappingStrict        bool
 	csrfToken            *string
 	mappingOverrides     map[string]any
 	mappingOverridesMode MappingOverrideMode
+	convertValues        bool
+	timeLocation         *time.Location
+	numberMode           NumberMode
+	middlewares          []Middleware
+	logger               *slog.Logger
+	sensitiveAttributes  []string
+	syncObserver         SyncObserver
+	loginContext         context.Context
 }
 
 func defaultConfig() sessionConfig {
@@ -82,6 +102,7 @@ func defaultConfig() sessionConfig {
 		mapAttributes: true,
 		mappingStrict: true,
 		csrfToken:     &csrfToken,
+		timeLocation:  time.UTC,
 	}
 }
 
@@ -109,6 +130,15 @@ func WithVerifyTLS(verify bool) Option {
 	}
 }
 
+// WithRootCAs sets the certificate authorities that verify the REST API
+// server's certificate, in place of the system roots. It applies to the
+// HTTP transport the session builds, not to one set with WithTransport.
+func WithRootCAs(pool *x509.CertPool) Option {
+	return func(config *sessionConfig) {
+		config.rootCAs = pool
+	}
+}
+
 // WithTimeout sets the HTTP request timeout. Use zero for no timeout.
 func WithTimeout(timeout time.Duration) Option {
 	return func(config *sessionConfig) {
@@ -140,6 +170,16 @@ func WithCSRFToken(token *string) Option {
 	}
 }
 
+// WithLoginContext sets the context for the LTPA login NewSession performs,
+// so that it can be cancelled and so that middleware, such as a tracing
+// middleware, records it under the caller's span. Defaults to
+// context.Background().
+func WithLoginContext(ctx context.Context) Option {
+	return func(config *sessionConfig) {
+		config.loginContext = ctx
+	}
+}
+
 // WithMappingOverrides provides custom mapping data that is overlaid on or
 // replaces the default mapping definitions.
 func WithMappingOverrides(overrides map[string]any, mode MappingOverrideMode) Option {
@@ -149,6 +189,26 @@ func WithMappingOverrides(overrides map[string]any, mode MappingOverrideMode) Op
 	}
 }
 
+// WithConvertValues controls whether mapped response values are converted to
+// native Go types using the mapping data's response type metadata: numeric
+// strings become int64, delimited lists become slices, blank strings become
+// nil, and <stem>_date/<stem>_time pairs merge into a <stem>_timestamp
+// time.Time. Requires attribute mapping. Defaults to false.
+func WithConvertValues(enabled bool) Option {
+	return func(config *sessionConfig) {
+		config.convertValues = enabled
+	}
+}
+
+// WithTimeLocation sets the time zone used to interpret response dates and
+// times when value conversion is enabled. The REST API reports the queue
+// manager's local time. Defaults to UTC.
+func WithTimeLocation(location *time.Location) Option {
+	return func(config *sessionConfig) {
+		config.timeLocation = location
+	}
+}
+
 // WithBasicAuth configures HTTP Basic authentication.
 func WithBasicAuth(_, _ string) Option {
 	return func(_ *sessionConfig) {
@@ -172,6 +232,9 @@ func NewSession(restBaseURL, qmgrName string, credentials Credentials, opts ...O
 	transport := config.transport
 	if transport == nil {
 		httpTransport := &HTTPTransport{}
+		if config.rootCAs != nil {
+			httpTransport.TLSConfig = &tls.Config{RootCAs: config.rootCAs}
+		}
 		// Configure mTLS if using certificate auth
 		if certAuth, isCert := credentials.(CertificateAuth); isCert {
 			certificate, err := certAuth.loadTLSCertificate()
@@ -179,11 +242,13 @@ func NewSession(restBaseURL, qmgrName string, credentials Credentials, opts ...O
 				return nil, fmt.Errorf("load client certificate: %w", err)
 			}
 			httpTransport.TLSConfig = &tls.Config{
+				RootCAs:      config.rootCAs,
 				Certificates: []tls.Certificate{*certificate},
 			}
 		}
 		transport = httpTransport
 	}
+	transport = applyMiddlewares(transport, config.middlewares)
 
 	// Attribute mapper
 	var mapper *attributeMapper
@@ -211,12 +276,24 @@ func NewSession(restBaseURL, qmgrName string, credentials Credentials, opts ...O
 		mappingStrict: config.mappingStrict,
 		csrfToken:     config.csrfToken,
 		mapper:        mapper,
+		convertValues: config.convertValues,
+		timeLocation:  config.timeLocation,
+		numberMode:    config.numberMode,
 		clock:         systemClock{},
+		logger:        config.logger,
+		syncObserver:  config.syncObserver,
+	}
+	if config.logger != nil {
+		session.redactor = newRedactor(config.sensitiveAttributes, mapper)
 	}
 
 	// LTPA login
 	if ltpaAuth, isLTPA := credentials.(LTPAAuth); isLTPA {
-		if err := session.performLTPALogin(ltpaAuth); err != nil {
+		loginContext := config.loginContext
+		if loginContext == nil {
+			loginContext = context.Background()
+		}
+		if err := session.performLTPALogin(loginContext, ltpaAuth); err != nil {
 			return nil, err
 		}
 	}
@@ -234,19 +311,104 @@ func (session *Session) GatewayQmgr() string {
 	return session.gatewayQmgr
 }
 
+// ForQmgr returns a session that sends commands to another queue manager
+// through this session's gateway queue manager, or through this session's
+// queue manager when it has no gateway. The derived session shares this
+// session's transport, credentials, LTPA token, and settings, so no new
+// login is performed; only the Last* fields are its own.
+func (session *Session) ForQmgr(qmgrName string) *Session {
+	derived := &Session{
+		restBaseURL:    session.restBaseURL,
+		qmgrName:       qmgrName,
+		credentials:    session.credentials,
+		transport:      session.transport,
+		gatewayQmgr:    session.gatewayQmgr,
+		verifyTLS:      session.verifyTLS,
+		timeout:        session.timeout,
+		mapAttributes:  session.mapAttributes,
+		mappingStrict:  session.mappingStrict,
+		csrfToken:      session.csrfToken,
+		mapper:         session.mapper,
+		convertValues:  session.convertValues,
+		timeLocation:   session.timeLocation,
+		numberMode:     session.numberMode,
+		ltpaCookieName: session.ltpaCookieName,
+		ltpaToken:      session.ltpaToken,
+		clock:          session.clock,
+		logger:         session.logger,
+		redactor:       session.redactor,
+		syncObserver:   session.syncObserver,
+	}
+	if derived.gatewayQmgr == "" && qmgrName != session.qmgrName {
+		derived.gatewayQmgr = session.qmgrName
+	}
+	return derived
+}
+
 // mqscCommand is the core dispatch method. It builds the MQSC command payload,
 // sends it to the REST API, parses the response, and optionally maps attribute
 // names.
 func (session *Session) mqscCommand(ctx context.Context, command, mqscQualifier string,
 	name *string, requestParameters map[string]any, responseParameters []string,
-	_ *string, isDisplay bool,
+	where *string, isDisplay bool,
+) ([]map[string]any, error) {
+	return session.configuredCommand(ctx, command, mqscQualifier, name, commandConfig{
+		requestParameters:  requestParameters,
+		responseParameters: responseParameters,
+		where:              where,
+	}, isDisplay)
+}
+
+// configuredCommand runs a command with the settings collected from its
+// CommandOptions.
+func (session *Session) configuredCommand(ctx context.Context, command, mqscQualifier string,
+	name *string, config commandConfig, isDisplay bool,
+) ([]map[string]any, error) {
+	return session.dispatchCommand(ctx, command, mqscQualifier, name, config, isDisplay, session.mappingStrict)
+}
+
+// dispatchCommand runs a command through the mapping pipeline with the given
+// mapping strictness.
+func (session *Session) dispatchCommand(ctx context.Context, command, mqscQualifier string,
+	name *string, config commandConfig, isDisplay, strict bool,
 ) ([]map[string]any, error) {
+	mappingQualifier, payload, err := session.prepareCommand(ctx, command, mqscQualifier,
+		name, config, isDisplay, strict)
+	if err != nil {
+		return nil, err
+	}
+
+	// Execute request and parse response. A QsgCommandError comes with the
+	// rows of the members that succeeded, which are still returned.
+	objects, qmgrNames, commandErr := session.executeAndParseResponse(ctx, payload, config.targetQmgr)
+	if commandErr != nil && len(objects) == 0 {
+		return nil, commandErr
+	}
+
+	// Apply response-side mapping
+	mapped, err := session.applyResponseMapping(ctx, mappingQualifier, objects, strict)
+	if err != nil {
+		return nil, err
+	}
+
+	rows := session.applyValueConversion(mappingQualifier, mapped)
+	tagQmgrNames(rows, qmgrNames)
+	return rows, commandErr
+}
+
+// prepareCommand applies request-side mapping and builds the runCommandJSON
+// payload, recording it in LastCommandPayload. CMDSCOPE and QSGDISP from
+// the command options are added after mapping.
+func (session *Session) prepareCommand(ctx context.Context, command, mqscQualifier string,
+	name *string, config commandConfig, isDisplay, strict bool,
+) (mappingQualifier string, payload map[string]any, err error) {
 	upperCommand := strings.ToUpper(command)
 	upperQualifier := strings.ToUpper(mqscQualifier)
+	responseParameters := config.responseParameters
 
 	// Copy request parameters to avoid mutating caller's map
 	params := make(map[string]any)
-	for key, value := range requestParameters {
+	for key, value := range config.requestParameters {
 		params[key] = value
 	}
 
@@ -256,30 +418,27 @@ func (session *Session) mqscCommand(ctx context.Context, command, mqscQualifier
 	}
 
 	// Apply request-side mapping
-	mappingQualifier, params, responseParameters, err := session.applyRequestMapping(
-		upperCommand, upperQualifier, params, responseParameters)
+	mappingQualifier, params, responseParameters, err = session.applyRequestMapping(ctx,
+		upperCommand, upperQualifier, params, responseParameters, strict)
 	if err != nil {
-		return nil, err
+		return "", nil, err
+	}
+	maps.Copy(params, config.scopeParameters())
+	if err := session.checkSupported(upperCommand, upperQualifier, config.targetQmgr, params); err != nil {
+		return "", nil, err
 	}
 
 	// Build payload
-	payload := session.buildCommandPayload(upperCommand, upperQualifier, name, params, responseParameters)
+	payload = session.buildCommandPayload(upperCommand, upperQualifier, name, params, responseParameters)
 	session.LastCommandPayload = payload
 
-	// Execute request and parse response
-	objects, err := session.executeAndParseResponse(ctx, payload)
-	if err != nil {
-		return nil, err
-	}
-
-	// Apply response-side mapping
-	return session.applyResponseMapping(mappingQualifier, objects)
+	return mappingQualifier, payload, nil
 }
 
 // applyRequestMapping resolves the mapping qualifier and translates request
 // attribute names and response parameter names from snake_case to MQSC names.
-func (session *Session) applyRequestMapping(command, qualifier string,
-	params map[string]any, responseParameters []string,
+func (session *Session) applyRequestMapping(ctx context.Context, command, qualifier string,
+	params map[string]any, responseParameters []string, strict bool,
 ) (mappingQualifier string, mappedParams map[string]any, mappedResponseParams []string, err error) {
 	if !session.mapAttributes || session.mapper == nil {
 		return "", params, responseParameters, nil
@@ -289,10 +448,12 @@ func (session *Session) applyRequestMapping(command, qualifier string,
 
 	// Map request attributes
 	if len(params) > 0 && mappingQualifier != "" {
-		mapped, issues := session.mapper.mapRequestAttributes(mappingQualifier, params, session.mappingStrict)
-		if session.mappingStrict && len(issues) > 0 {
+		mapped, issues := session.mapper.mapRequestAttributes(mappingQualifier, params, strict)
+		if strict && len(issues) > 0 {
 			return "", nil, nil, &MappingError{Issues: issues}
 		}
+		session.logMappingIssues(ctx, mappingQualifier, issues)
+		session.logRequestMapping(ctx, mappingQualifier, params, mapped)
 		params = mapped
 	}
 
@@ -311,15 +472,41 @@ func (session *Session) applyRequestMapping(command, qualifier string,
 }
 
 // executeAndParseResponse sends the command payload to the REST API, validates
-// the HTTP response, parses JSON, and extracts command response objects.
-func (session *Session) executeAndParseResponse(ctx context.Context, payload map[string]any) ([]map[string]any, error) {
-	url := session.buildMQSCURL()
-	headers := session.buildHeaders()
+// the HTTP response, parses JSON, and extracts command response objects
+// along with the queue manager each came from. On a QsgCommandError the
+// objects of the members that succeeded are returned with the error.
+func (session *Session) executeAndParseResponse(ctx context.Context, payload map[string]any,
+	targetQmgr string,
+) (objects []map[string]any, qmgrNames []string, err error) {
+	responsePayload, err := session.executeCommand(ctx, payload, targetQmgr)
+	if responsePayload == nil {
+		return nil, nil, err
+	}
+	objects, qmgrNames = extractCommandResponseObjects(responsePayload)
+	return objects, qmgrNames, err
+}
+
+// executeCommand sends the command payload to the REST API, validates the
+// HTTP response, and returns the parsed response payload after checking
+// completion and reason codes. A QsgCommandError is returned together with
+// the response payload.
+func (session *Session) executeCommand(ctx context.Context, payload map[string]any,
+	targetQmgr string,
+) (responsePayload map[string]any, err error) {
+	url := session.buildMQSCURL(targetQmgr)
+	headers := session.buildHeaders(targetQmgr)
+
+	start := session.clock.now()
+	var statusCode int
+	defer func() {
+		session.logCommand(ctx, url, headers, payload, start, statusCode, responsePayload, err)
+	}()
 
 	response, err := session.transport.PostJSON(ctx, url, payload, headers, session.timeout, session.verifyTLS)
 	if err != nil {
 		return nil, err
 	}
+	statusCode = response.StatusCode
 
 	session.LastHTTPStatus = response.StatusCode
 	session.LastResponseText = response.Body
@@ -328,43 +515,74 @@ func (session *Session) executeAndParseResponse(ctx context.Context, payload map
 		return nil, &AuthError{URL: url, StatusCode: response.StatusCode}
 	}
 
-	responsePayload, err := parseResponsePayload(response.Body)
+	responsePayload, err = parseResponsePayload(response.Body, session.numberMode)
 	if err != nil {
 		return nil, &ResponseError{ResponseText: response.Body, StatusCode: response.StatusCode}
 	}
 	session.LastResponsePayload = responsePayload
 
 	if err := checkCommandErrors(responsePayload, response.StatusCode); err != nil {
+		if _, isQsg := err.(*QsgCommandError); isQsg {
+			return responsePayload, err
+		}
 		return nil, err
 	}
 
-	return extractCommandResponseObjects(responsePayload), nil
+	return responsePayload, nil
 }
 
 // applyResponseMapping translates response attribute names from MQSC names
 // back to snake_case using the mapping qualifier.
-func (session *Session) applyResponseMapping(mappingQualifier string, objects []map[string]any) ([]map[string]any, error) {
+func (session *Session) applyResponseMapping(ctx context.Context, mappingQualifier string, objects []map[string]any,
+	strict bool,
+) ([]map[string]any, error) {
 	if !session.mapAttributes || session.mapper == nil || mappingQualifier == "" || len(objects) == 0 {
 		return objects, nil
 	}
 
 	mapped, issues := session.mapper.mapResponseList(mappingQualifier, objects)
-	if session.mappingStrict && len(issues) > 0 {
+	if strict && len(issues) > 0 {
 		return nil, &MappingError{Issues: issues}
 	}
+	session.logMappingIssues(ctx, mappingQualifier, issues)
 	return mapped, nil
 }
 
-func (session *Session) buildMQSCURL() string {
-	return session.restBaseURL + fmt.Sprintf(mqscEndpoint, session.qmgrName)
+// buildMQSCURL returns the runCommandJSON URL for the target queue manager,
+// or for the session's queue manager when targetQmgr is empty.
+func (session *Session) buildMQSCURL(targetQmgr string) string {
+	if targetQmgr == "" {
+		targetQmgr = session.qmgrName
+	}
+	return session.restBaseURL + fmt.Sprintf(mqscEndpoint, targetQmgr)
 }
 
-func (session *Session) buildHeaders() map[string]string {
-	headers := map[string]string{
-		"Accept":       "application/json",
-		"Content-Type": "application/json",
+// buildHeaders returns the request headers. A command routed to a target
+// queue manager other than the session's own goes through the session's
+// gateway queue manager, or through the session's queue manager when no
+// gateway is configured.
+func (session *Session) buildHeaders(targetQmgr string) map[string]string {
+	headers := session.authHeaders()
+	headers["Accept"] = "application/json"
+	headers["Content-Type"] = "application/json"
+
+	// Gateway queue manager
+	gatewayQmgr := session.gatewayQmgr
+	if gatewayQmgr == "" && targetQmgr != "" && targetQmgr != session.qmgrName {
+		gatewayQmgr = session.qmgrName
+	}
+	if gatewayQmgr != "" {
+		headers["ibm-mq-rest-gateway-qmgr"] = gatewayQmgr
 	}
 
+	return headers
+}
+
+// authHeaders returns the authentication and CSRF headers shared by every
+// REST API request.
+func (session *Session) authHeaders() map[string]string {
+	headers := map[string]string{}
+
 	// Apply auth
 	fakeRequest := &http.Request{Header: make(http.Header)}
 	session.credentials.applyAuth(fakeRequest, session)
@@ -379,12 +597,35 @@ func (session *Session) buildHeaders() map[string]string {
 		headers["ibm-mq-rest-csrf-token"] = *session.csrfToken
 	}
 
-	// Gateway queue manager
-	if session.gatewayQmgr != "" {
-		headers["ibm-mq-rest-gateway-qmgr"] = session.gatewayQmgr
+	return headers
+}
+
+// sendRequest issues a REST API request outside runCommandJSON, adding the
+// session's authentication headers to headers. It records the HTTP status
+// and returns an AuthError for 401 and 403 responses; other error statuses
+// are left to the caller.
+func (session *Session) sendRequest(ctx context.Context, method, requestURL string, body []byte,
+	headers map[string]string,
+) (*TransportResponse, error) {
+	transport, supported := session.transport.(RequestTransport)
+	if !supported {
+		return nil, errors.New("transport does not implement RequestTransport")
 	}
+	maps.Copy(headers, session.authHeaders())
 
-	return headers
+	start := session.clock.now()
+	response, err := transport.SendRequest(ctx, method, requestURL, body, headers, session.timeout, session.verifyTLS)
+	if err != nil {
+		session.logRequest(ctx, method, requestURL, headers, start, 0, err)
+		return nil, err
+	}
+	session.LastHTTPStatus = response.StatusCode
+	session.logRequest(ctx, method, requestURL, headers, start, response.StatusCode, nil)
+
+	if response.StatusCode == http.StatusUnauthorized || response.StatusCode == http.StatusForbidden {
+		return nil, &AuthError{URL: requestURL, StatusCode: response.StatusCode}
+	}
+	return response, nil
 }
 
 func (session *Session) buildCommandPayload(command, qualifier string, name *string,
@@ -431,7 +672,7 @@ func (session *Session) mapResponseParameterNames(qualifier string, params []str
 
 // performLTPALogin authenticates with the MQ REST API using LTPA credentials
 // and stores the resulting LtpaToken2 cookie for subsequent requests.
-func (session *Session) performLTPALogin(auth LTPAAuth) error {
+func (session *Session) performLTPALogin(ctx context.Context, auth LTPAAuth) error {
 	loginURL := session.restBaseURL + ltpaLoginPath
 
 	loginPayload := map[string]any{
@@ -447,8 +688,10 @@ func (session *Session) performLTPALogin(auth LTPAAuth) error {
 		headers["ibm-mq-rest-csrf-token"] = *session.csrfToken
 	}
 
+	start := session.clock.now()
 	response, err := session.transport.PostJSON(
-		context.Background(), loginURL, loginPayload, headers, session.timeout, session.verifyTLS)
+		ctx, loginURL, loginPayload, headers, session.timeout, session.verifyTLS)
+	session.logLogin(ctx, loginURL, headers, loginPayload, start, response, err)
 	if err != nil {
 		return fmt.Errorf("LTPA login request failed: %w", err)
 	}
@@ -486,15 +729,17 @@ func extractLTPAToken(headers map[string]string) (cookieName, cookieValue string
 	return "", ""
 }
 
-func parseResponsePayload(body string) (map[string]any, error) {
-	var result map[string]any
-	if err := json.Unmarshal([]byte(body), &result); err != nil {
-		return nil, err
-	}
-	return result, nil
+func parseResponsePayload(body string, mode NumberMode) (map[string]any, error) {
+	return decodeJSONObject([]byte(body), mode)
 }
 
 func checkCommandErrors(payload map[string]any, httpStatus int) error {
+	// Report queue sharing group members individually
+	members := qsgMemberResults(payload)
+	if slices.ContainsFunc(members, func(member QsgMemberResult) bool { return !member.Succeeded() }) {
+		return &QsgCommandError{Payload: payload, StatusCode: httpStatus, Members: members}
+	}
+
 	// Check overall completion and reason codes
 	if hasErrorCodes(payload["overallCompletionCode"], payload["overallReasonCode"]) {
 		return &CommandError{Payload: payload, StatusCode: httpStatus}
@@ -521,59 +766,65 @@ func hasErrorCodes(completionCode, reasonCode any) bool {
 }
 
 func isNonZeroNumber(value any) bool {
-	if value == nil {
-		return false
-	}
-	switch typed := value.(type) {
-	case float64:
-		return typed != 0
-	case int:
-		return typed != 0
-	default:
-		return false
-	}
+	number, isNumber := Float64Value(value)
+	return isNumber && number != 0
 }
 
-func extractCommandResponseObjects(payload map[string]any) []map[string]any {
+// extractCommandResponseObjects returns the parameter objects of every
+// successful commandResponse item, with a parallel slice naming the queue
+// manager each object came from ("" when the item does not name one).
+func extractCommandResponseObjects(payload map[string]any) (objects []map[string]any, qmgrNames []string) {
 	commandResponse, exists := payload["commandResponse"]
 	if !exists {
-		return nil
+		return nil, nil
 	}
 
 	items, isList := commandResponse.([]any)
 	if !isList {
-		return nil
+		return nil, nil
 	}
 
-	var result []map[string]any
 	for _, item := range items {
-		itemMap, isMap := item.(map[string]any)
-		if !isMap {
+		itemMap, _ := item.(map[string]any)
+		if hasErrorCodes(itemMap["completionCode"], itemMap["reasonCode"]) {
 			continue
 		}
-
-		params, hasParams := itemMap["parameters"]
-		if !hasParams {
-			continue
+		itemObjects := commandResponseItemObjects(item)
+		objects = append(objects, itemObjects...)
+		for range itemObjects {
+			qmgrNames = append(qmgrNames, itemQmgrName(item))
 		}
+	}
 
-		paramsMap, isParamsMap := params.(map[string]any)
-		if !isParamsMap {
-			continue
-		}
+	return objects, qmgrNames
+}
 
-		// Check for nested objects array (multi-row results like QSTATUS HANDLE)
-		if objectsRaw, hasObjects := paramsMap["objects"]; hasObjects {
-			if objects, isObjectList := objectsRaw.([]any); isObjectList {
-				result = append(result, flattenNestedObjects(paramsMap, objects)...)
-				continue
-			}
-		}
+// commandResponseItemObjects extracts the parameter objects from a single
+// commandResponse item, flattening nested objects arrays.
+func commandResponseItemObjects(item any) []map[string]any {
+	itemMap, isMap := item.(map[string]any)
+	if !isMap {
+		return nil
+	}
 
-		result = append(result, paramsMap)
+	params, hasParams := itemMap["parameters"]
+	if !hasParams {
+		return nil
 	}
 
-	return result
+	paramsMap, isParamsMap := params.(map[string]any)
+	if !isParamsMap {
+		return nil
+	}
+
+	// Check for nested objects array (multi-row results like QSTATUS HANDLE)
+	if objectsRaw, hasObjects := paramsMap["objects"]; hasObjects {
+		if objects, isObjectList := objectsRaw.([]any); isObjectList {
+			return flattenNestedObjects(paramsMap, objects)
+		}
+	}
+
+	return []map[string]any{paramsMap}
 }
 
 // flattenNestedObjects merges parent-level fields into each nested object.
@@ -9,6 +9,9 @@ type commandConfig struct {
 	requestParameters  map[string]any
 	responseParameters []string
 	where              *string
+	commandScope       *string
+	qsgDisposition     QsgDisposition
+	targetQmgr         string
 }
 
 func buildCommandConfig(opts []CommandOption) commandConfig {
@@ -42,6 +45,26 @@ func WithWhere(clause string) CommandOption {
 	}
 }
 
+// WithCommandScope sets CMDSCOPE, the queue sharing group members a command
+// runs on: a queue manager name, or "*" for every member. Each member's
+// response rows are tagged with QmgrNameKey.
+func WithCommandScope(scope string) CommandOption {
+	return func(config *commandConfig) {
+		config.commandScope = &scope
+	}
+}
+
+// WithTargetQmgr routes one command to a different queue manager through
+// the session's gateway queue manager, or through the session's own queue
+// manager when it has no gateway. The session's credentials and
+// connection are reused. Use Session.ForQmgr to target another queue
+// manager for many commands.
+func WithTargetQmgr(name string) CommandOption {
+	return func(config *commandConfig) {
+		config.targetQmgr = name
+	}
+}
+
 // BEGIN GENERATED MQSC METHODS
 
 // AlterAuthinfo executes the ALTER AUTHINFO command.
@@ -406,7 +429,7 @@ func (session *Session) DisplayChannel(ctx context.Context, name string, opts ..
 	if displayName == "" {
 		displayName = "*"
 	}
-	return session.mqscCommand(ctx, "DISPLAY", "CHANNEL", &displayName, config.requestParameters, config.responseParameters, config.where, true)
+	return session.configuredCommand(ctx, "DISPLAY", "CHANNEL", &displayName, config, true)
 }
 
 // DisplayChinit executes the DISPLAY CHINIT command.
@@ -432,7 +455,7 @@ func (session *Session) DisplayClusqmgr(ctx context.Context, name string, opts .
 // DisplayCmdserv executes the DISPLAY CMDSERV command.
 func (session *Session) DisplayCmdserv(ctx context.Context, opts ...CommandOption) (map[string]any, error) {
 	config := buildCommandConfig(opts)
-	objects, err := session.mqscCommand(ctx, "DISPLAY", "CMDSERV", nil, config.requestParameters, config.responseParameters, nil, true)
+	objects, err := session.configuredCommand(ctx, "DISPLAY", "CMDSERV", nil, config, true)
 	if err != nil {
 		return nil, err
 	}
@@ -505,7 +528,7 @@ func (session *Session) DisplayPubsub(ctx context.Context, name string, opts ...
 // DisplayQmgr executes the DISPLAY QMGR command.
 func (session *Session) DisplayQmgr(ctx context.Context, opts ...CommandOption) (map[string]any, error) {
 	config := buildCommandConfig(opts)
-	objects, err := session.mqscCommand(ctx, "DISPLAY", "QMGR", nil, config.requestParameters, config.responseParameters, nil, true)
+	objects, err := session.configuredCommand(ctx, "DISPLAY", "QMGR", nil, config, true)
 	if err != nil {
 		return nil, err
 	}
@@ -518,7 +541,7 @@ func (session *Session) DisplayQmgr(ctx context.Context, opts ...CommandOption)
 // DisplayQmstatus executes the DISPLAY QMSTATUS command.
 func (session *Session) DisplayQmstatus(ctx context.Context, opts ...CommandOption) (map[string]any, error) {
 	config := buildCommandConfig(opts)
-	objects, err := session.mqscCommand(ctx, "DISPLAY", "QMSTATUS", nil, config.requestParameters, config.responseParameters, nil, true)
+	objects, err := session.configuredCommand(ctx, "DISPLAY", "QMSTATUS", nil, config, true)
 	if err != nil {
 		return nil, err
 	}
@@ -540,7 +563,7 @@ func (session *Session) DisplayQueue(ctx context.Context, name string, opts ...C
 	if displayName == "" {
 		displayName = "*"
 	}
-	return session.mqscCommand(ctx, "DISPLAY", "QUEUE", &displayName, config.requestParameters, config.responseParameters, config.where, true)
+	return session.configuredCommand(ctx, "DISPLAY", "QUEUE", &displayName, config, true)
 }
 
 // DisplaySbstatus executes the DISPLAY SBSTATUS command.
@@ -852,13 +875,13 @@ func (session *Session) displayList(ctx context.Context, qualifier, name string,
 	if name != "" {
 		namePtr = &name
 	}
-	return session.mqscCommand(ctx, "DISPLAY", qualifier, namePtr, config.requestParameters, config.responseParameters, config.where, true)
+	return session.configuredCommand(ctx, "DISPLAY", qualifier, namePtr, config, true)
 }
 
 // voidCommand dispatches a non-DISPLAY MQSC command and discards the result.
 func (session *Session) voidCommand(ctx context.Context, command, qualifier string, name *string, opts []CommandOption) error {
 	config := buildCommandConfig(opts)
-	_, err := session.mqscCommand(ctx, command, qualifier, name, config.requestParameters, config.responseParameters, nil, false)
+	_, err := session.configuredCommand(ctx, command, qualifier, name, config, false)
 	return err
 }
 
@@ -0,0 +1,234 @@
+package mqrestadmin
+
+import (
+	"context"
+	"iter"
+)
+
+// BEGIN GENERATED MQSC ITERATOR METHODS
+
+// DisplayApstatusSeq streams the DISPLAY APSTATUS command results.
+func (session *Session) DisplayApstatusSeq(ctx context.Context, name string, opts ...CommandOption) iter.Seq2[map[string]any, error] {
+	return session.displaySeqOptionalName(ctx, "APSTATUS", name, opts)
+}
+
+// DisplayArchiveSeq streams the DISPLAY ARCHIVE command results.
+func (session *Session) DisplayArchiveSeq(ctx context.Context, name string, opts ...CommandOption) iter.Seq2[map[string]any, error] {
+	return session.displaySeqOptionalName(ctx, "ARCHIVE", name, opts)
+}
+
+// DisplayAuthinfoSeq streams the DISPLAY AUTHINFO command results.
+func (session *Session) DisplayAuthinfoSeq(ctx context.Context, name string, opts ...CommandOption) iter.Seq2[map[string]any, error] {
+	return session.displaySeqOptionalName(ctx, "AUTHINFO", name, opts)
+}
+
+// DisplayAuthrecSeq streams the DISPLAY AUTHREC command results.
+func (session *Session) DisplayAuthrecSeq(ctx context.Context, name string, opts ...CommandOption) iter.Seq2[map[string]any, error] {
+	return session.displaySeqOptionalName(ctx, "AUTHREC", name, opts)
+}
+
+// DisplayAuthservSeq streams the DISPLAY AUTHSERV command results.
+func (session *Session) DisplayAuthservSeq(ctx context.Context, name string, opts ...CommandOption) iter.Seq2[map[string]any, error] {
+	return session.displaySeqOptionalName(ctx, "AUTHSERV", name, opts)
+}
+
+// DisplayCfstatusSeq streams the DISPLAY CFSTATUS command results.
+func (session *Session) DisplayCfstatusSeq(ctx context.Context, name string, opts ...CommandOption) iter.Seq2[map[string]any, error] {
+	return session.displaySeqOptionalName(ctx, "CFSTATUS", name, opts)
+}
+
+// DisplayCfstructSeq streams the DISPLAY CFSTRUCT command results.
+func (session *Session) DisplayCfstructSeq(ctx context.Context, name string, opts ...CommandOption) iter.Seq2[map[string]any, error] {
+	return session.displaySeqOptionalName(ctx, "CFSTRUCT", name, opts)
+}
+
+// DisplayChannelSeq streams the DISPLAY CHANNEL command results. Name defaults to "*" if empty.
+func (session *Session) DisplayChannelSeq(ctx context.Context, name string, opts ...CommandOption) iter.Seq2[map[string]any, error] {
+	displayName := name
+	if displayName == "" {
+		displayName = "*"
+	}
+	return session.displaySeq(ctx, "CHANNEL", &displayName, opts)
+}
+
+// DisplayChinitSeq streams the DISPLAY CHINIT command results.
+func (session *Session) DisplayChinitSeq(ctx context.Context, name string, opts ...CommandOption) iter.Seq2[map[string]any, error] {
+	return session.displaySeqOptionalName(ctx, "CHINIT", name, opts)
+}
+
+// DisplayChlauthSeq streams the DISPLAY CHLAUTH command results.
+func (session *Session) DisplayChlauthSeq(ctx context.Context, name string, opts ...CommandOption) iter.Seq2[map[string]any, error] {
+	return session.displaySeqOptionalName(ctx, "CHLAUTH", name, opts)
+}
+
+// DisplayChstatusSeq streams the DISPLAY CHSTATUS command results.
+func (session *Session) DisplayChstatusSeq(ctx context.Context, name string, opts ...CommandOption) iter.Seq2[map[string]any, error] {
+	return session.displaySeqOptionalName(ctx, "CHSTATUS", name, opts)
+}
+
+// DisplayClusqmgrSeq streams the DISPLAY CLUSQMGR command results.
+func (session *Session) DisplayClusqmgrSeq(ctx context.Context, name string, opts ...CommandOption) iter.Seq2[map[string]any, error] {
+	return session.displaySeqOptionalName(ctx, "CLUSQMGR", name, opts)
+}
+
+// DisplayComminfoSeq streams the DISPLAY COMMINFO command results.
+func (session *Session) DisplayComminfoSeq(ctx context.Context, name string, opts ...CommandOption) iter.Seq2[map[string]any, error] {
+	return session.displaySeqOptionalName(ctx, "COMMINFO", name, opts)
+}
+
+// DisplayConnSeq streams the DISPLAY CONN command results.
+func (session *Session) DisplayConnSeq(ctx context.Context, name string, opts ...CommandOption) iter.Seq2[map[string]any, error] {
+	return session.displaySeqOptionalName(ctx, "CONN", name, opts)
+}
+
+// DisplayEntauthSeq streams the DISPLAY ENTAUTH command results.
+func (session *Session) DisplayEntauthSeq(ctx context.Context, name string, opts ...CommandOption) iter.Seq2[map[string]any, error] {
+	return session.displaySeqOptionalName(ctx, "ENTAUTH", name, opts)
+}
+
+// DisplayGroupSeq streams the DISPLAY GROUP command results.
+func (session *Session) DisplayGroupSeq(ctx context.Context, name string, opts ...CommandOption) iter.Seq2[map[string]any, error] {
+	return session.displaySeqOptionalName(ctx, "GROUP", name, opts)
+}
+
+// DisplayListenerSeq streams the DISPLAY LISTENER command results.
+func (session *Session) DisplayListenerSeq(ctx context.Context, name string, opts ...CommandOption) iter.Seq2[map[string]any, error] {
+	return session.displaySeqOptionalName(ctx, "LISTENER", name, opts)
+}
+
+// DisplayLogSeq streams the DISPLAY LOG command results.
+func (session *Session) DisplayLogSeq(ctx context.Context, name string, opts ...CommandOption) iter.Seq2[map[string]any, error] {
+	return session.displaySeqOptionalName(ctx, "LOG", name, opts)
+}
+
+// DisplayLsstatusSeq streams the DISPLAY LSSTATUS command results.
+func (session *Session) DisplayLsstatusSeq(ctx context.Context, name string, opts ...CommandOption) iter.Seq2[map[string]any, error] {
+	return session.displaySeqOptionalName(ctx, "LSSTATUS", name, opts)
+}
+
+// DisplayMaxsmsgsSeq streams the DISPLAY MAXSMSGS command results.
+func (session *Session) DisplayMaxsmsgsSeq(ctx context.Context, name string, opts ...CommandOption) iter.Seq2[map[string]any, error] {
+	return session.displaySeqOptionalName(ctx, "MAXSMSGS", name, opts)
+}
+
+// DisplayNamelistSeq streams the DISPLAY NAMELIST command results.
+func (session *Session) DisplayNamelistSeq(ctx context.Context, name string, opts ...CommandOption) iter.Seq2[map[string]any, error] {
+	return session.displaySeqOptionalName(ctx, "NAMELIST", name, opts)
+}
+
+// DisplayPolicySeq streams the DISPLAY POLICY command results.
+func (session *Session) DisplayPolicySeq(ctx context.Context, name string, opts ...CommandOption) iter.Seq2[map[string]any, error] {
+	return session.displaySeqOptionalName(ctx, "POLICY", name, opts)
+}
+
+// DisplayProcessSeq streams the DISPLAY PROCESS command results.
+func (session *Session) DisplayProcessSeq(ctx context.Context, name string, opts ...CommandOption) iter.Seq2[map[string]any, error] {
+	return session.displaySeqOptionalName(ctx, "PROCESS", name, opts)
+}
+
+// DisplayPubsubSeq streams the DISPLAY PUBSUB command results.
+func (session *Session) DisplayPubsubSeq(ctx context.Context, name string, opts ...CommandOption) iter.Seq2[map[string]any, error] {
+	return session.displaySeqOptionalName(ctx, "PUBSUB", name, opts)
+}
+
+// DisplayQstatusSeq streams the DISPLAY QSTATUS command results.
+func (session *Session) DisplayQstatusSeq(ctx context.Context, name string, opts ...CommandOption) iter.Seq2[map[string]any, error] {
+	return session.displaySeqOptionalName(ctx, "QSTATUS", name, opts)
+}
+
+// DisplayQueueSeq streams the DISPLAY QUEUE command results. Name defaults to "*" if empty.
+func (session *Session) DisplayQueueSeq(ctx context.Context, name string, opts ...CommandOption) iter.Seq2[map[string]any, error] {
+	displayName := name
+	if displayName == "" {
+		displayName = "*"
+	}
+	return session.displaySeq(ctx, "QUEUE", &displayName, opts)
+}
+
+// DisplaySbstatusSeq streams the DISPLAY SBSTATUS command results.
+func (session *Session) DisplaySbstatusSeq(ctx context.Context, name string, opts ...CommandOption) iter.Seq2[map[string]any, error] {
+	return session.displaySeqOptionalName(ctx, "SBSTATUS", name, opts)
+}
+
+// DisplaySecuritySeq streams the DISPLAY SECURITY command results.
+func (session *Session) DisplaySecuritySeq(ctx context.Context, name string, opts ...CommandOption) iter.Seq2[map[string]any, error] {
+	return session.displaySeqOptionalName(ctx, "SECURITY", name, opts)
+}
+
+// DisplayServiceSeq streams the DISPLAY SERVICE command results.
+func (session *Session) DisplayServiceSeq(ctx context.Context, name string, opts ...CommandOption) iter.Seq2[map[string]any, error] {
+	return session.displaySeqOptionalName(ctx, "SERVICE", name, opts)
+}
+
+// DisplaySmdsSeq streams the DISPLAY SMDS command results.
+func (session *Session) DisplaySmdsSeq(ctx context.Context, name string, opts ...CommandOption) iter.Seq2[map[string]any, error] {
+	return session.displaySeqOptionalName(ctx, "SMDS", name, opts)
+}
+
+// DisplaySmdsconnSeq streams the DISPLAY SMDSCONN command results.
+func (session *Session) DisplaySmdsconnSeq(ctx context.Context, name string, opts ...CommandOption) iter.Seq2[map[string]any, error] {
+	return session.displaySeqOptionalName(ctx, "SMDSCONN", name, opts)
+}
+
+// DisplayStgclassSeq streams the DISPLAY STGCLASS command results.
+func (session *Session) DisplayStgclassSeq(ctx context.Context, name string, opts ...CommandOption) iter.Seq2[map[string]any, error] {
+	return session.displaySeqOptionalName(ctx, "STGCLASS", name, opts)
+}
+
+// DisplaySubSeq streams the DISPLAY SUB command results.
+func (session *Session) DisplaySubSeq(ctx context.Context, name string, opts ...CommandOption) iter.Seq2[map[string]any, error] {
+	return session.displaySeqOptionalName(ctx, "SUB", name, opts)
+}
+
+// DisplaySvstatusSeq streams the DISPLAY SVSTATUS command results.
+func (session *Session) DisplaySvstatusSeq(ctx context.Context, name string, opts ...CommandOption) iter.Seq2[map[string]any, error] {
+	return session.displaySeqOptionalName(ctx, "SVSTATUS", name, opts)
+}
+
+// DisplaySystemSeq streams the DISPLAY SYSTEM command results.
+func (session *Session) DisplaySystemSeq(ctx context.Context, name string, opts ...CommandOption) iter.Seq2[map[string]any, error] {
+	return session.displaySeqOptionalName(ctx, "SYSTEM", name, opts)
+}
+
+// DisplayTclusterSeq streams the DISPLAY TCLUSTER command results.
+func (session *Session) DisplayTclusterSeq(ctx context.Context, name string, opts ...CommandOption) iter.Seq2[map[string]any, error] {
+	return session.displaySeqOptionalName(ctx, "TCLUSTER", name, opts)
+}
+
+// DisplayThreadSeq streams the DISPLAY THREAD command results.
+func (session *Session) DisplayThreadSeq(ctx context.Context, name string, opts ...CommandOption) iter.Seq2[map[string]any, error] {
+	return session.displaySeqOptionalName(ctx, "THREAD", name, opts)
+}
+
+// DisplayTopicSeq streams the DISPLAY TOPIC command results.
+func (session *Session) DisplayTopicSeq(ctx context.Context, name string, opts ...CommandOption) iter.Seq2[map[string]any, error] {
+	return session.displaySeqOptionalName(ctx, "TOPIC", name, opts)
+}
+
+// DisplayTpstatusSeq streams the DISPLAY TPSTATUS command results.
+func (session *Session) DisplayTpstatusSeq(ctx context.Context, name string, opts ...CommandOption) iter.Seq2[map[string]any, error] {
+	return session.displaySeqOptionalName(ctx, "TPSTATUS", name, opts)
+}
+
+// DisplayTraceSeq streams the DISPLAY TRACE command results.
+func (session *Session) DisplayTraceSeq(ctx context.Context, name string, opts ...CommandOption) iter.Seq2[map[string]any, error] {
+	return session.displaySeqOptionalName(ctx, "TRACE", name, opts)
+}
+
+// DisplayUsageSeq streams the DISPLAY USAGE command results.
+func (session *Session) DisplayUsageSeq(ctx context.Context, name string, opts ...CommandOption) iter.Seq2[map[string]any, error] {
+	return session.displaySeqOptionalName(ctx, "USAGE", name, opts)
+}
+
+// END GENERATED MQSC ITERATOR METHODS
+
+// displaySeqOptionalName streams an optional-name DISPLAY command.
+func (session *Session) displaySeqOptionalName(ctx context.Context, qualifier, name string,
+	opts []CommandOption,
+) iter.Seq2[map[string]any, error] {
+	var namePtr *string
+	if name != "" {
+		namePtr = &name
+	}
+	return session.displaySeq(ctx, qualifier, namePtr, opts)
+}
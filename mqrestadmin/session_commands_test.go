@@ -2,6 +2,7 @@ package mqrestadmin
 
 import (
 	"context"
+	"strings"
 	"testing"
 )
 
@@ -371,3 +372,80 @@ func TestVoidCommands(t *testing.T) {
 		})
 	}
 }
+
+func TestWithTargetQmgr_RoutesThroughSessionQmgr(t *testing.T) {
+	transport := newMockTransport()
+	transport.addSuccessResponse(map[string]any{"QUEUE": "APP.Q"})
+	session := newTestSession(transport)
+
+	if _, err := session.DisplayQueue(context.Background(), "APP.Q", WithTargetQmgr("QM2")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	call := transport.lastCall()
+	if !strings.HasSuffix(call.URL, "/admin/action/qmgr/QM2/mqsc") {
+		t.Errorf("URL = %q, want target QM2", call.URL)
+	}
+	if call.Headers["ibm-mq-rest-gateway-qmgr"] != "QM1" {
+		t.Errorf("gateway header = %q, want QM1", call.Headers["ibm-mq-rest-gateway-qmgr"])
+	}
+}
+
+func TestWithTargetQmgr_UsesConfiguredGateway(t *testing.T) {
+	transport := newMockTransport()
+	transport.addSuccessResponse()
+	transport.addSuccessResponse()
+	session := newTestSession(transport)
+	session.gatewayQmgr = "GW1"
+
+	if err := session.AlterQmgr(context.Background(), WithTargetQmgr("QM3")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	call := transport.lastCall()
+	if !strings.HasSuffix(call.URL, "/qmgr/QM3/mqsc") || call.Headers["ibm-mq-rest-gateway-qmgr"] != "GW1" {
+		t.Errorf("URL = %q, gateway = %q; want QM3 through GW1", call.URL, call.Headers["ibm-mq-rest-gateway-qmgr"])
+	}
+
+	if err := session.AlterQmgr(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if call := transport.lastCall(); !strings.HasSuffix(call.URL, "/qmgr/QM1/mqsc") {
+		t.Errorf("URL = %q, want the session's own queue manager without the option", call.URL)
+	}
+}
+
+func TestWithTargetQmgr_SessionQmgrNeedsNoGateway(t *testing.T) {
+	transport := newMockTransport()
+	transport.addSuccessResponse()
+	session := newTestSession(transport)
+
+	if _, err := session.DisplayQmgr(context.Background(), WithTargetQmgr("QM1")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, exists := transport.lastCall().Headers["ibm-mq-rest-gateway-qmgr"]; exists {
+		t.Error("gateway header should not be set when targeting the session's queue manager")
+	}
+}
+
+func TestWithTargetQmgr_RunMQSCAndSeq(t *testing.T) {
+	transport := newMockTransport()
+	transport.addSuccessResponse()
+	transport.addSuccessResponse(map[string]any{"QUEUE": "APP.Q"})
+	session := newTestSession(transport)
+
+	if _, err := session.RunMQSC(context.Background(), "PING QMGR", WithTargetQmgr("QM2")); err != nil {
+		t.Fatalf("RunMQSC error: %v", err)
+	}
+	if call := transport.lastCall(); !strings.HasSuffix(call.URL, "/qmgr/QM2/mqsc") {
+		t.Errorf("RunMQSC URL = %q, want target QM2", call.URL)
+	}
+
+	for _, err := range session.DisplayQueueSeq(context.Background(), "APP.Q", WithTargetQmgr("QM2")) {
+		if err != nil {
+			t.Fatalf("DisplayQueueSeq error: %v", err)
+		}
+	}
+	if call := transport.lastCall(); !strings.HasSuffix(call.URL, "/qmgr/QM2/mqsc") {
+		t.Errorf("DisplayQueueSeq URL = %q, want target QM2", call.URL)
+	}
+}
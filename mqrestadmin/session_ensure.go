@@ -182,8 +182,51 @@ func diffAttributes(desired, current map[string]any) (changed []string, changedP
 
 // valuesMatch compares two attribute values using case-insensitive string
 // comparison after trimming whitespace, matching the Java port's behavior.
+// A nil current value (a blank string under value conversion) matches an
+// empty desired value. A list current value (a list attribute under value
+// conversion) is compared element by element, splitting a desired string on
+// commas or spaces.
 func valuesMatch(desired, current any) bool {
+	if current == nil {
+		current = ""
+	}
+	if currentItems, isList := listItems(current); isList {
+		desiredItems, isDesiredList := listItems(desired)
+		if !isDesiredList {
+			desiredItems = splitList(fmt.Sprintf("%v", desired))
+		}
+		if len(desiredItems) != len(currentItems) {
+			return false
+		}
+		for idx := range desiredItems {
+			if !valuesMatch(desiredItems[idx], currentItems[idx]) {
+				return false
+			}
+		}
+		return true
+	}
 	desiredStr := strings.TrimSpace(fmt.Sprintf("%v", desired))
 	currentStr := strings.TrimSpace(fmt.Sprintf("%v", current))
 	return strings.EqualFold(desiredStr, currentStr)
 }
+
+// listItems returns the elements of a converted list value as strings, and
+// false when value is not a list.
+func listItems(value any) ([]string, bool) {
+	var items []string
+	switch typed := value.(type) {
+	case []string:
+		items = typed
+	case []int64:
+		for _, item := range typed {
+			items = append(items, fmt.Sprintf("%d", item))
+		}
+	case []any:
+		for _, item := range typed {
+			items = append(items, fmt.Sprintf("%v", item))
+		}
+	default:
+		return nil, false
+	}
+	return items, true
+}
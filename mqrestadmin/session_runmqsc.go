@@ -0,0 +1,102 @@
+package mqrestadmin
+
+import (
+	"context"
+	"errors"
+	"strings"
+)
+
+// MQSCResult is the response to a plain-text MQSC command from one queue
+// manager. In a queue sharing group, a command with CMDSCOPE produces one
+// result per member.
+type MQSCResult struct {
+	// QmgrName is the queue manager that produced the result, when the
+	// REST API reports it.
+	QmgrName       string
+	CompletionCode int
+	ReasonCode     int
+	// Text holds the response lines as runmqsc would print them.
+	Text []string
+}
+
+// Messages parses the result text into MQSC messages with their attributes.
+func (result MQSCResult) Messages() []MQSCMessage {
+	return ParseMQSCOutput(result.Text)
+}
+
+// RunMQSC sends a plain MQSC command string using the runCommand payload
+// type and returns the response text for each commandResponse item. Unlike
+// the generated command methods, the command text is passed through
+// unchanged, so any command and attribute syntax the queue manager accepts
+// can be used. Attribute mapping is not applied.
+//
+// Use WithCommandScope and WithQsgDisposition to append a CMDSCOPE and a
+// QSGDISP to the command. A non-zero completion or reason code is reported
+// as a CommandError whose payload holds the response text. When some queue
+// sharing group members fail, the results of every member are returned with
+// a QsgCommandError.
+func (session *Session) RunMQSC(ctx context.Context, command string, opts ...CommandOption) ([]MQSCResult, error) {
+	text := strings.TrimSpace(command)
+	if text == "" {
+		return nil, errors.New("MQSC command text must not be empty")
+	}
+
+	config := buildCommandConfig(opts)
+	if config.commandScope != nil {
+		text += " CMDSCOPE(" + *config.commandScope + ")"
+	}
+	if config.qsgDisposition != "" {
+		text += " QSGDISP(" + string(config.qsgDisposition) + ")"
+	}
+
+	payload := map[string]any{
+		"type": "runCommand",
+		"parameters": map[string]any{
+			"command": text,
+		},
+	}
+	session.LastCommandPayload = payload
+
+	responsePayload, err := session.executeCommand(ctx, payload, config.targetQmgr)
+	if responsePayload == nil {
+		return nil, err
+	}
+
+	items, _ := responsePayload["commandResponse"].([]any)
+	results := make([]MQSCResult, 0, len(items))
+	for _, item := range items {
+		itemMap, isMap := item.(map[string]any)
+		if !isMap {
+			continue
+		}
+		results = append(results, MQSCResult{
+			QmgrName:       itemQmgrName(itemMap),
+			CompletionCode: intValue(itemMap["completionCode"]),
+			ReasonCode:     intValue(itemMap["reasonCode"]),
+			Text:           responseTextLines(itemMap["text"]),
+		})
+	}
+	return results, err
+}
+
+// responseTextLines flattens a commandResponse text array into individual
+// lines. Each array element may itself hold several newline-separated lines.
+func responseTextLines(value any) []string {
+	entries, _ := value.([]any)
+	var lines []string
+	for _, entry := range entries {
+		text, isString := entry.(string)
+		if !isString {
+			continue
+		}
+		lines = append(lines, strings.Split(strings.TrimRight(text, "\n"), "\n")...)
+	}
+	return lines
+}
+
+// intValue returns a decoded JSON number as an int, whichever NumberMode
+// produced it.
+func intValue(value any) int {
+	number, _ := Int64Value(value)
+	return int(number)
+}
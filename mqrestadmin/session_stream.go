@@ -0,0 +1,282 @@
+package mqrestadmin
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"net/http"
+	"strings"
+)
+
+// displaySeq is the shared implementation for the DISPLAY iterator methods.
+// It decodes commandResponse items one at a time from the response stream,
+// maps each row as it arrives, and stops reading as soon as the caller
+// breaks out of the loop.
+//
+// Errors are yielded as the final element. A per-item error code stops the
+// iteration at that item; rows decoded before it have already been yielded.
+// Failed queue sharing group members do not stop it: the rows of the other
+// members are yielded and a QsgCommandError follows them, as the DISPLAY
+// methods return.
+// LastResponseText is not populated for streamed responses, and
+// LastResponsePayload holds only the top-level fields outside
+// commandResponse.
+func (session *Session) displaySeq(ctx context.Context, qualifier string, name *string,
+	opts []CommandOption,
+) iter.Seq2[map[string]any, error] {
+	return func(yield func(map[string]any, error) bool) {
+		config := buildCommandConfig(opts)
+		mappingQualifier, payload, err := session.prepareCommand(ctx, "DISPLAY", qualifier, name,
+			config, true, session.mappingStrict)
+		if err != nil {
+			yield(nil, err)
+			return
+		}
+
+		body, err := session.openCommandStream(ctx, payload, config.targetQmgr)
+		if err != nil {
+			yield(nil, err)
+			return
+		}
+		defer func() { _ = body.Close() }()
+
+		decoder := json.NewDecoder(body)
+		decoder.UseNumber()
+		stream := &commandResponseStream{
+			decoder:    decoder,
+			topLevel:   make(map[string]any),
+			statusCode: session.LastHTTPStatus,
+			numberMode: session.numberMode,
+		}
+		rowIndex := 0
+		for item, err := range stream.items() {
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+			for _, object := range commandResponseItemObjects(item) {
+				row, err := session.transformResponseObject(ctx, mappingQualifier, rowIndex, object)
+				rowIndex++
+				if qmgrName := itemQmgrName(item); qmgrName != "" && err == nil {
+					row[QmgrNameKey] = qmgrName
+				}
+				if !yield(row, err) || err != nil {
+					return
+				}
+			}
+		}
+
+		session.LastResponsePayload = stream.topLevel
+		if err := stream.memberError(); err != nil {
+			yield(nil, err)
+			return
+		}
+		if hasErrorCodes(stream.topLevel["overallCompletionCode"], stream.topLevel["overallReasonCode"]) {
+			yield(nil, &CommandError{Payload: stream.topLevel, StatusCode: session.LastHTTPStatus})
+		}
+	}
+}
+
+// openCommandStream sends the command payload and returns the unread
+// response body, using the transport's streaming support when available.
+func (session *Session) openCommandStream(ctx context.Context, payload map[string]any,
+	targetQmgr string,
+) (body io.ReadCloser, err error) {
+	url := session.buildMQSCURL(targetQmgr)
+	headers := session.buildHeaders(targetQmgr)
+
+	start := session.clock.now()
+	var statusCode int
+	defer func() {
+		session.logCommand(ctx, url, headers, payload, start, statusCode, nil, err)
+	}()
+
+	if streaming, isStreaming := session.transport.(StreamingTransport); isStreaming {
+		response, err := streaming.PostJSONStream(ctx, url, payload, headers, session.timeout, session.verifyTLS)
+		if err != nil {
+			return nil, err
+		}
+		statusCode, body = response.StatusCode, response.Body
+	} else {
+		response, err := session.transport.PostJSON(ctx, url, payload, headers, session.timeout, session.verifyTLS)
+		if err != nil {
+			return nil, err
+		}
+		statusCode, body = response.StatusCode, io.NopCloser(strings.NewReader(response.Body))
+	}
+
+	session.LastHTTPStatus = statusCode
+	session.LastResponseText = ""
+	session.LastResponsePayload = nil
+
+	if statusCode == http.StatusUnauthorized || statusCode == http.StatusForbidden {
+		_ = body.Close()
+		return nil, &AuthError{URL: url, StatusCode: statusCode}
+	}
+
+	return body, nil
+}
+
+// transformResponseObject applies response mapping and value conversion to
+// a single streamed row.
+func (session *Session) transformResponseObject(ctx context.Context, mappingQualifier string, rowIndex int,
+	object map[string]any,
+) (map[string]any, error) {
+	if !session.mapAttributes || session.mapper == nil || mappingQualifier == "" {
+		return object, nil
+	}
+
+	mapped, issues := session.mapper.mapAttributes(mappingQualifier, object, false, MappingResponse, rowIndex)
+	if session.mappingStrict && len(issues) > 0 {
+		return nil, &MappingError{Issues: issues}
+	}
+	session.logMappingIssues(ctx, mappingQualifier, issues)
+
+	return session.applyValueConversion(mappingQualifier, []map[string]any{mapped})[0], nil
+}
+
+// commandResponseStream walks a runCommandJSON response body with a token
+// decoder, decoding one commandResponse item at a time. Top-level fields
+// other than commandResponse are collected into topLevel, and the codes of
+// items naming a queue sharing group member into members.
+type commandResponseStream struct {
+	decoder    *json.Decoder
+	topLevel   map[string]any
+	members    []any
+	statusCode int
+	numberMode NumberMode
+}
+
+// items yields each commandResponse item. An item whose completion or reason
+// code is non-zero is reported as a CommandError, unless it names a queue
+// sharing group member, which memberError reports once the stream ends.
+func (stream *commandResponseStream) items() iter.Seq2[any, error] {
+	return func(yield func(any, error) bool) {
+		if err := stream.expectDelim('{'); err != nil {
+			yield(nil, err)
+			return
+		}
+
+		for stream.decoder.More() {
+			keyToken, err := stream.decoder.Token()
+			if err != nil {
+				yield(nil, stream.responseError(err))
+				return
+			}
+			key, _ := keyToken.(string)
+
+			if key != "commandResponse" {
+				var value any
+				if err := stream.decoder.Decode(&value); err != nil {
+					yield(nil, stream.responseError(err))
+					return
+				}
+				stream.topLevel[key] = normalizeNumbers(value, stream.numberMode)
+				continue
+			}
+
+			if !stream.decodeItems(yield) {
+				return
+			}
+		}
+	}
+}
+
+// decodeItems decodes the commandResponse array, returning false when
+// iteration must stop.
+func (stream *commandResponseStream) decodeItems(yield func(any, error) bool) bool {
+	token, err := stream.decoder.Token()
+	if err != nil {
+		yield(nil, stream.responseError(err))
+		return false
+	}
+	if token == nil {
+		return true
+	}
+	if delim, isDelim := token.(json.Delim); !isDelim || delim != '[' {
+		yield(nil, stream.responseError(fmt.Errorf("commandResponse is not an array")))
+		return false
+	}
+
+	for stream.decoder.More() {
+		var item any
+		if err := stream.decoder.Decode(&item); err != nil {
+			yield(nil, stream.responseError(err))
+			return false
+		}
+		item = normalizeNumbers(item, stream.numberMode)
+		itemMap, _ := item.(map[string]any)
+		failed := hasErrorCodes(itemMap["completionCode"], itemMap["reasonCode"])
+		if itemQmgrName(item) != "" {
+			stream.members = append(stream.members, memberSummary(itemMap))
+			if failed {
+				continue
+			}
+		}
+		if failed {
+			payload := copyMap(stream.topLevel)
+			payload["commandResponse"] = []any{item}
+			yield(nil, &CommandError{Payload: payload, StatusCode: stream.statusCode})
+			return false
+		}
+		if !yield(item, nil) {
+			return false
+		}
+	}
+
+	if _, err := stream.decoder.Token(); err != nil {
+		yield(nil, stream.responseError(err))
+		return false
+	}
+	return true
+}
+
+// memberError returns a QsgCommandError when a queue sharing group member
+// failed. Its payload holds the top-level fields and the members' codes and
+// messages, without the rows already yielded.
+func (stream *commandResponseStream) memberError() error {
+	payload := copyMap(stream.topLevel)
+	payload["commandResponse"] = stream.members
+	members := qsgMemberResults(payload)
+	for _, member := range members {
+		if !member.Succeeded() {
+			return &QsgCommandError{Payload: payload, StatusCode: stream.statusCode, Members: members}
+		}
+	}
+	return nil
+}
+
+// memberSummary copies the fields of a member's commandResponse item that
+// QsgMemberResult reads, leaving out its rows.
+func memberSummary(item map[string]any) map[string]any {
+	summary := map[string]any{}
+	for _, key := range []string{"qmgrName", "completionCode", "reasonCode", "message"} {
+		if value, present := item[key]; present {
+			summary[key] = value
+		}
+	}
+	return summary
+}
+
+func (stream *commandResponseStream) expectDelim(expected json.Delim) error {
+	token, err := stream.decoder.Token()
+	if err != nil {
+		return stream.responseError(err)
+	}
+	if delim, isDelim := token.(json.Delim); !isDelim || delim != expected {
+		return stream.responseError(fmt.Errorf("expected %q, got %v", expected, token))
+	}
+	return nil
+}
+
+// responseError wraps a decode failure. The full body is not buffered for
+// streamed responses, so the error text stands in for the response text.
+func (stream *commandResponseStream) responseError(err error) error {
+	if errors.Is(err, io.EOF) {
+		err = io.ErrUnexpectedEOF
+	}
+	return &ResponseError{ResponseText: err.Error(), StatusCode: stream.statusCode}
+}
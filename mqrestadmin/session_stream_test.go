@@ -0,0 +1,562 @@
+package mqrestadmin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// streamingMockTransport is a StreamingTransport that serves a fixed body and
+// records whether the body was closed.
+type streamingMockTransport struct {
+	mockTransport
+	statusCode int
+	body       string
+	closed     bool
+	bytesRead  int
+}
+
+func (transport *streamingMockTransport) PostJSONStream(_ context.Context, url string,
+	payload map[string]any, headers map[string]string, timeout time.Duration, verifyTLS bool,
+) (*StreamingResponse, error) {
+	transport.calls = append(transport.calls, mockCall{
+		URL: url, Payload: payload, Headers: headers, Timeout: timeout, VerifyTLS: verifyTLS,
+	})
+	return &StreamingResponse{
+		StatusCode: transport.statusCode,
+		Body:       &trackingReadCloser{transport: transport, reader: strings.NewReader(transport.body)},
+		Headers:    map[string]string{},
+	}, nil
+}
+
+type trackingReadCloser struct {
+	transport *streamingMockTransport
+	reader    io.Reader
+}
+
+func (reader *trackingReadCloser) Read(buffer []byte) (int, error) {
+	// Read one byte at a time so the test can observe how far decoding got.
+	if len(buffer) > 1 {
+		buffer = buffer[:1]
+	}
+	count, err := reader.reader.Read(buffer)
+	reader.transport.bytesRead += count
+	return count, err
+}
+
+func (reader *trackingReadCloser) Close() error {
+	reader.transport.closed = true
+	return nil
+}
+
+func collectSeq(t *testing.T, seq iter.Seq2[map[string]any, error]) ([]map[string]any, error) {
+	t.Helper()
+	var rows []map[string]any
+	for row, err := range seq {
+		if err != nil {
+			return rows, err
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+func streamBody(items ...string) string {
+	return `{"commandResponse":[` + strings.Join(items, ",") +
+		`],"overallCompletionCode":0,"overallReasonCode":0}`
+}
+
+func streamItem(params string) string {
+	return `{"completionCode":0,"reasonCode":0,"parameters":` + params + `}`
+}
+
+func TestDisplayQueueSeq_FallbackTransport(t *testing.T) {
+	transport := newMockTransport()
+	transport.addSuccessResponse(
+		map[string]any{"queue": "Q1", "curdepth": float64(1)},
+		map[string]any{"queue": "Q2", "curdepth": float64(2)},
+	)
+	session := newTestSessionWithMapping(transport)
+
+	rows, err := collectSeq(t, session.DisplayQueueSeq(context.Background(), ""))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(rows) != 2 {
+		t.Fatalf("got %d rows, want 2", len(rows))
+	}
+	if rows[1]["queue_name"] != "Q2" || rows[1]["current_queue_depth"] != float64(2) {
+		t.Errorf("row = %v, want mapped Q2", rows[1])
+	}
+	payload := transport.lastCall().Payload
+	if payload["name"] != "*" {
+		t.Errorf("name = %v, want * default", payload["name"])
+	}
+	if session.LastResponsePayload["overallCompletionCode"] != float64(0) {
+		t.Errorf("LastResponsePayload = %v, want top-level fields", session.LastResponsePayload)
+	}
+	if _, exists := session.LastResponsePayload["commandResponse"]; exists {
+		t.Error("LastResponsePayload should not hold commandResponse for streamed results")
+	}
+}
+
+func TestDisplayChannelSeq_NameDefault(t *testing.T) {
+	transport := newMockTransport()
+	transport.addSuccessResponse()
+	session := newTestSession(transport)
+
+	if _, err := collectSeq(t, session.DisplayChannelSeq(context.Background(), "")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if transport.lastCall().Payload["name"] != "*" {
+		t.Errorf("name = %v, want *", transport.lastCall().Payload["name"])
+	}
+}
+
+func TestDisplayQstatusSeq_OptionalName(t *testing.T) {
+	transport := newMockTransport()
+	transport.addSuccessResponse()
+	transport.addSuccessResponse()
+	session := newTestSession(transport)
+
+	if _, err := collectSeq(t, session.DisplayQstatusSeq(context.Background(), "")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, hasName := transport.lastCall().Payload["name"]; hasName {
+		t.Error("empty name should not include name in payload")
+	}
+
+	if _, err := collectSeq(t, session.DisplayQstatusSeq(context.Background(), "APP.Q")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	payload := transport.lastCall().Payload
+	if payload["name"] != "APP.Q" || payload["qualifier"] != "QSTATUS" || payload["command"] != "DISPLAY" {
+		t.Errorf("payload = %v, want DISPLAY QSTATUS APP.Q", payload)
+	}
+	if responseParams, _ := payload["responseParameters"].([]string); len(responseParams) != 1 || responseParams[0] != "all" {
+		t.Errorf("responseParameters = %v, want [all]", payload["responseParameters"])
+	}
+}
+
+func TestDisplaySeq_StreamingTransportEarlyBreak(t *testing.T) {
+	items := make([]string, 50)
+	for idx := range items {
+		items[idx] = streamItem(fmt.Sprintf(`{"QUEUE":"Q%d"}`, idx))
+	}
+	transport := &streamingMockTransport{statusCode: 200, body: streamBody(items...)}
+	session := newTestSession(&transport.mockTransport)
+	session.transport = transport
+
+	count := 0
+	for row, err := range session.DisplayQueueSeq(context.Background(), "*") {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if row["QUEUE"] != fmt.Sprintf("Q%d", count) {
+			t.Errorf("row %d = %v", count, row)
+		}
+		count++
+		if count == 2 {
+			break
+		}
+	}
+
+	if !transport.closed {
+		t.Error("body should be closed after early break")
+	}
+	if transport.bytesRead >= len(transport.body)/2 {
+		t.Errorf("read %d of %d bytes; decoding should stop at the break", transport.bytesRead, len(transport.body))
+	}
+	if len(transport.calls) != 1 {
+		t.Errorf("calls = %d, want 1", len(transport.calls))
+	}
+}
+
+func TestDisplaySeq_ItemErrorAfterRows(t *testing.T) {
+	transport := &streamingMockTransport{statusCode: 200, body: `{"commandResponse":[` +
+		streamItem(`{"QUEUE":"Q1"}`) + `,{"completionCode":2,"reasonCode":2085,"text":["AMQ8147E"]}` +
+		`],"overallCompletionCode":2,"overallReasonCode":3008}`}
+	session := newTestSession(&transport.mockTransport)
+	session.transport = transport
+
+	rows, err := collectSeq(t, session.DisplayQueueSeq(context.Background(), "*"))
+	if len(rows) != 1 {
+		t.Errorf("got %d rows before the error, want 1", len(rows))
+	}
+	var cmdErr *CommandError
+	if !errors.As(err, &cmdErr) {
+		t.Fatalf("expected CommandError, got %T: %v", err, err)
+	}
+	items, _ := cmdErr.Payload["commandResponse"].([]any)
+	if len(items) != 1 {
+		t.Errorf("CommandError payload = %v, want the failing item", cmdErr.Payload)
+	}
+	if cmdErr.StatusCode != 200 {
+		t.Errorf("StatusCode = %d, want 200", cmdErr.StatusCode)
+	}
+}
+
+func TestDisplaySeq_OverallErrorAtEnd(t *testing.T) {
+	transport := newMockTransport()
+	transport.addCommandErrorResponse(2, 3008)
+	session := newTestSession(transport)
+
+	_, err := collectSeq(t, session.DisplayQueueSeq(context.Background(), "*"))
+	var cmdErr *CommandError
+	if !errors.As(err, &cmdErr) {
+		t.Fatalf("expected CommandError, got %T: %v", err, err)
+	}
+	if cmdErr.Payload["overallReasonCode"] != float64(3008) {
+		t.Errorf("payload = %v, want overall reason code", cmdErr.Payload)
+	}
+}
+
+func TestDisplaySeq_AuthError(t *testing.T) {
+	transport := &streamingMockTransport{statusCode: 401, body: `{}`}
+	session := newTestSession(&transport.mockTransport)
+	session.transport = transport
+
+	_, err := collectSeq(t, session.DisplayQueueSeq(context.Background(), "*"))
+	var authErr *AuthError
+	if !errors.As(err, &authErr) {
+		t.Fatalf("expected AuthError, got %T: %v", err, err)
+	}
+	if !transport.closed {
+		t.Error("body should be closed on auth error")
+	}
+}
+
+func TestDisplaySeq_TransportError(t *testing.T) {
+	transport := newMockTransport()
+	transport.addErrorResponse(errors.New("connection refused"))
+	session := newTestSession(transport)
+
+	_, err := collectSeq(t, session.DisplayQueueSeq(context.Background(), "*"))
+	if err == nil || !strings.Contains(err.Error(), "connection refused") {
+		t.Errorf("err = %v, want transport error", err)
+	}
+}
+
+func TestDisplaySeq_StreamingTransportError(t *testing.T) {
+	session := newTestSession(newMockTransport())
+	session.transport = &failingStreamTransport{}
+
+	_, err := collectSeq(t, session.DisplayQueueSeq(context.Background(), "*"))
+	var transportErr *TransportError
+	if !errors.As(err, &transportErr) {
+		t.Fatalf("expected TransportError, got %T: %v", err, err)
+	}
+}
+
+type failingStreamTransport struct{ mockTransport }
+
+func (*failingStreamTransport) PostJSONStream(_ context.Context, url string, _ map[string]any,
+	_ map[string]string, _ time.Duration, _ bool,
+) (*StreamingResponse, error) {
+	return nil, &TransportError{URL: url, Err: errors.New("refused")}
+}
+
+func TestDisplaySeq_MalformedResponses(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+	}{
+		{"not json", `<html>`},
+		{"not an object", `[1,2]`},
+		{"empty body", ``},
+		{"truncated key", `{"overallCompletionCode":0,`},
+		{"bad top-level value", `{"overallCompletionCode":}`},
+		{"command response not array", `{"commandResponse":{"a":1}}`},
+		{"bad command response token", `{"commandResponse":]`},
+		{"bad item", `{"commandResponse":[{"a":}]}`},
+		{"truncated array", `{"commandResponse":[` + streamItem(`{}`)},
+		{"mismatched array close", `{"commandResponse":[` + streamItem(`{}`) + `}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			transport := &streamingMockTransport{statusCode: 200, body: tt.body}
+			session := newTestSession(&transport.mockTransport)
+			session.transport = transport
+
+			_, err := collectSeq(t, session.DisplayQueueSeq(context.Background(), "*"))
+			var responseErr *ResponseError
+			if !errors.As(err, &responseErr) {
+				t.Fatalf("expected ResponseError, got %T: %v", err, err)
+			}
+			if responseErr.StatusCode != 200 {
+				t.Errorf("StatusCode = %d, want 200", responseErr.StatusCode)
+			}
+		})
+	}
+}
+
+func TestDisplaySeq_NullCommandResponse(t *testing.T) {
+	transport := &streamingMockTransport{statusCode: 200,
+		body: `{"commandResponse":null,"overallCompletionCode":0,"overallReasonCode":0}`}
+	session := newTestSession(&transport.mockTransport)
+	session.transport = transport
+
+	rows, err := collectSeq(t, session.DisplayQueueSeq(context.Background(), "*"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(rows) != 0 {
+		t.Errorf("got %d rows, want 0", len(rows))
+	}
+}
+
+func TestDisplaySeq_NestedObjectsFlattened(t *testing.T) {
+	transport := &streamingMockTransport{statusCode: 200, body: streamBody(
+		streamItem(`{"QUEUE":"Q1","objects":[{"PID":1},{"PID":2}]}`),
+		`"not an item"`,
+	)}
+	session := newTestSession(&transport.mockTransport)
+	session.transport = transport
+
+	rows, err := collectSeq(t, session.DisplayQstatusSeq(context.Background(), "Q1"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(rows) != 2 || rows[1]["QUEUE"] != "Q1" || rows[1]["PID"] != float64(2) {
+		t.Errorf("rows = %v, want two flattened handle rows", rows)
+	}
+}
+
+func TestDisplaySeq_ValueConversion(t *testing.T) {
+	transport := newMockTransport()
+	transport.addSuccessResponse(map[string]any{"CURDEPTH": "7", "LPUTDATE": "2026-01-01", "LPUTTIME": "01.02.03"})
+	session := newConvertingTestSession(transport)
+
+	rows, err := collectSeq(t, session.DisplayQstatusSeq(context.Background(), "Q1"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if rows[0]["current_queue_depth"] != int64(7) {
+		t.Errorf("current_queue_depth = %#v, want int64(7)", rows[0]["current_queue_depth"])
+	}
+	if _, isTime := rows[0]["last_put_timestamp"].(time.Time); !isTime {
+		t.Errorf("last_put_timestamp = %#v, want time.Time", rows[0]["last_put_timestamp"])
+	}
+}
+
+func TestDisplaySeq_StrictResponseMappingError(t *testing.T) {
+	transport := newMockTransport()
+	transport.addSuccessResponse(map[string]any{"QUEUE": "Q1"}, map[string]any{"NOSUCHATTR": "x"})
+	session := newTestSessionWithMapping(transport)
+
+	rows, err := collectSeq(t, session.DisplayQueueSeq(context.Background(), "*"))
+	var mappingErr *MappingError
+	if !errors.As(err, &mappingErr) {
+		t.Fatalf("expected MappingError, got %T: %v", err, err)
+	}
+	if len(rows) != 1 {
+		t.Errorf("got %d rows before the error, want 1", len(rows))
+	}
+	if index := mappingErr.Issues[0].ObjectIndex; index == nil || *index != 1 {
+		t.Errorf("ObjectIndex = %v, want 1", index)
+	}
+}
+
+func TestDisplaySeq_RequestMappingError(t *testing.T) {
+	transport := newMockTransport()
+	session := newTestSessionWithMapping(transport)
+
+	_, err := collectSeq(t, session.DisplayQueueSeq(context.Background(), "*",
+		WithRequestParameters(map[string]any{"nonexistent_attribute": 1})))
+	var mappingErr *MappingError
+	if !errors.As(err, &mappingErr) {
+		t.Fatalf("expected MappingError, got %T: %v", err, err)
+	}
+	if transport.callCount() != 0 {
+		t.Errorf("calls = %d, want 0", transport.callCount())
+	}
+}
+
+func TestDisplaySeq_HTTPTransportStreams(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = io.WriteString(w, streamBody(streamItem(`{"QUEUE":"Q1"}`), streamItem(`{"QUEUE":"Q2"}`)))
+	}))
+	defer server.Close()
+
+	session, err := NewSession(server.URL, "QM1", BasicAuth{Username: "u", Password: "p"},
+		WithVerifyTLS(false), WithMapAttributes(false))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	rows, err := collectSeq(t, session.DisplayQueueSeq(context.Background(), "*"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(rows) != 2 || rows[1]["QUEUE"] != "Q2" {
+		t.Errorf("rows = %v, want Q1 and Q2", rows)
+	}
+}
+
+// displaySeqEntry defines a table entry for testing the iterator variants of
+// DISPLAY commands.
+type displaySeqEntry struct {
+	name      string
+	qualifier string
+	call      func(*Session, context.Context, string) iter.Seq2[map[string]any, error]
+}
+
+func TestDisplaySeqCommands(t *testing.T) {
+	entries := []displaySeqEntry{
+		{"DisplayApstatusSeq", "APSTATUS", func(s *Session, ctx context.Context, name string) iter.Seq2[map[string]any, error] {
+			return s.DisplayApstatusSeq(ctx, name)
+		}},
+		{"DisplayArchiveSeq", "ARCHIVE", func(s *Session, ctx context.Context, name string) iter.Seq2[map[string]any, error] {
+			return s.DisplayArchiveSeq(ctx, name)
+		}},
+		{"DisplayAuthinfoSeq", "AUTHINFO", func(s *Session, ctx context.Context, name string) iter.Seq2[map[string]any, error] {
+			return s.DisplayAuthinfoSeq(ctx, name)
+		}},
+		{"DisplayAuthrecSeq", "AUTHREC", func(s *Session, ctx context.Context, name string) iter.Seq2[map[string]any, error] {
+			return s.DisplayAuthrecSeq(ctx, name)
+		}},
+		{"DisplayAuthservSeq", "AUTHSERV", func(s *Session, ctx context.Context, name string) iter.Seq2[map[string]any, error] {
+			return s.DisplayAuthservSeq(ctx, name)
+		}},
+		{"DisplayCfstatusSeq", "CFSTATUS", func(s *Session, ctx context.Context, name string) iter.Seq2[map[string]any, error] {
+			return s.DisplayCfstatusSeq(ctx, name)
+		}},
+		{"DisplayCfstructSeq", "CFSTRUCT", func(s *Session, ctx context.Context, name string) iter.Seq2[map[string]any, error] {
+			return s.DisplayCfstructSeq(ctx, name)
+		}},
+		{"DisplayChannelSeq", "CHANNEL", func(s *Session, ctx context.Context, name string) iter.Seq2[map[string]any, error] {
+			return s.DisplayChannelSeq(ctx, name)
+		}},
+		{"DisplayChinitSeq", "CHINIT", func(s *Session, ctx context.Context, name string) iter.Seq2[map[string]any, error] {
+			return s.DisplayChinitSeq(ctx, name)
+		}},
+		{"DisplayChlauthSeq", "CHLAUTH", func(s *Session, ctx context.Context, name string) iter.Seq2[map[string]any, error] {
+			return s.DisplayChlauthSeq(ctx, name)
+		}},
+		{"DisplayChstatusSeq", "CHSTATUS", func(s *Session, ctx context.Context, name string) iter.Seq2[map[string]any, error] {
+			return s.DisplayChstatusSeq(ctx, name)
+		}},
+		{"DisplayClusqmgrSeq", "CLUSQMGR", func(s *Session, ctx context.Context, name string) iter.Seq2[map[string]any, error] {
+			return s.DisplayClusqmgrSeq(ctx, name)
+		}},
+		{"DisplayComminfoSeq", "COMMINFO", func(s *Session, ctx context.Context, name string) iter.Seq2[map[string]any, error] {
+			return s.DisplayComminfoSeq(ctx, name)
+		}},
+		{"DisplayConnSeq", "CONN", func(s *Session, ctx context.Context, name string) iter.Seq2[map[string]any, error] {
+			return s.DisplayConnSeq(ctx, name)
+		}},
+		{"DisplayEntauthSeq", "ENTAUTH", func(s *Session, ctx context.Context, name string) iter.Seq2[map[string]any, error] {
+			return s.DisplayEntauthSeq(ctx, name)
+		}},
+		{"DisplayGroupSeq", "GROUP", func(s *Session, ctx context.Context, name string) iter.Seq2[map[string]any, error] {
+			return s.DisplayGroupSeq(ctx, name)
+		}},
+		{"DisplayListenerSeq", "LISTENER", func(s *Session, ctx context.Context, name string) iter.Seq2[map[string]any, error] {
+			return s.DisplayListenerSeq(ctx, name)
+		}},
+		{"DisplayLogSeq", "LOG", func(s *Session, ctx context.Context, name string) iter.Seq2[map[string]any, error] {
+			return s.DisplayLogSeq(ctx, name)
+		}},
+		{"DisplayLsstatusSeq", "LSSTATUS", func(s *Session, ctx context.Context, name string) iter.Seq2[map[string]any, error] {
+			return s.DisplayLsstatusSeq(ctx, name)
+		}},
+		{"DisplayMaxsmsgsSeq", "MAXSMSGS", func(s *Session, ctx context.Context, name string) iter.Seq2[map[string]any, error] {
+			return s.DisplayMaxsmsgsSeq(ctx, name)
+		}},
+		{"DisplayNamelistSeq", "NAMELIST", func(s *Session, ctx context.Context, name string) iter.Seq2[map[string]any, error] {
+			return s.DisplayNamelistSeq(ctx, name)
+		}},
+		{"DisplayPolicySeq", "POLICY", func(s *Session, ctx context.Context, name string) iter.Seq2[map[string]any, error] {
+			return s.DisplayPolicySeq(ctx, name)
+		}},
+		{"DisplayProcessSeq", "PROCESS", func(s *Session, ctx context.Context, name string) iter.Seq2[map[string]any, error] {
+			return s.DisplayProcessSeq(ctx, name)
+		}},
+		{"DisplayPubsubSeq", "PUBSUB", func(s *Session, ctx context.Context, name string) iter.Seq2[map[string]any, error] {
+			return s.DisplayPubsubSeq(ctx, name)
+		}},
+		{"DisplayQstatusSeq", "QSTATUS", func(s *Session, ctx context.Context, name string) iter.Seq2[map[string]any, error] {
+			return s.DisplayQstatusSeq(ctx, name)
+		}},
+		{"DisplayQueueSeq", "QUEUE", func(s *Session, ctx context.Context, name string) iter.Seq2[map[string]any, error] {
+			return s.DisplayQueueSeq(ctx, name)
+		}},
+		{"DisplaySbstatusSeq", "SBSTATUS", func(s *Session, ctx context.Context, name string) iter.Seq2[map[string]any, error] {
+			return s.DisplaySbstatusSeq(ctx, name)
+		}},
+		{"DisplaySecuritySeq", "SECURITY", func(s *Session, ctx context.Context, name string) iter.Seq2[map[string]any, error] {
+			return s.DisplaySecuritySeq(ctx, name)
+		}},
+		{"DisplayServiceSeq", "SERVICE", func(s *Session, ctx context.Context, name string) iter.Seq2[map[string]any, error] {
+			return s.DisplayServiceSeq(ctx, name)
+		}},
+		{"DisplaySmdsSeq", "SMDS", func(s *Session, ctx context.Context, name string) iter.Seq2[map[string]any, error] {
+			return s.DisplaySmdsSeq(ctx, name)
+		}},
+		{"DisplaySmdsconnSeq", "SMDSCONN", func(s *Session, ctx context.Context, name string) iter.Seq2[map[string]any, error] {
+			return s.DisplaySmdsconnSeq(ctx, name)
+		}},
+		{"DisplayStgclassSeq", "STGCLASS", func(s *Session, ctx context.Context, name string) iter.Seq2[map[string]any, error] {
+			return s.DisplayStgclassSeq(ctx, name)
+		}},
+		{"DisplaySubSeq", "SUB", func(s *Session, ctx context.Context, name string) iter.Seq2[map[string]any, error] {
+			return s.DisplaySubSeq(ctx, name)
+		}},
+		{"DisplaySvstatusSeq", "SVSTATUS", func(s *Session, ctx context.Context, name string) iter.Seq2[map[string]any, error] {
+			return s.DisplaySvstatusSeq(ctx, name)
+		}},
+		{"DisplaySystemSeq", "SYSTEM", func(s *Session, ctx context.Context, name string) iter.Seq2[map[string]any, error] {
+			return s.DisplaySystemSeq(ctx, name)
+		}},
+		{"DisplayTclusterSeq", "TCLUSTER", func(s *Session, ctx context.Context, name string) iter.Seq2[map[string]any, error] {
+			return s.DisplayTclusterSeq(ctx, name)
+		}},
+		{"DisplayThreadSeq", "THREAD", func(s *Session, ctx context.Context, name string) iter.Seq2[map[string]any, error] {
+			return s.DisplayThreadSeq(ctx, name)
+		}},
+		{"DisplayTopicSeq", "TOPIC", func(s *Session, ctx context.Context, name string) iter.Seq2[map[string]any, error] {
+			return s.DisplayTopicSeq(ctx, name)
+		}},
+		{"DisplayTpstatusSeq", "TPSTATUS", func(s *Session, ctx context.Context, name string) iter.Seq2[map[string]any, error] {
+			return s.DisplayTpstatusSeq(ctx, name)
+		}},
+		{"DisplayTraceSeq", "TRACE", func(s *Session, ctx context.Context, name string) iter.Seq2[map[string]any, error] {
+			return s.DisplayTraceSeq(ctx, name)
+		}},
+		{"DisplayUsageSeq", "USAGE", func(s *Session, ctx context.Context, name string) iter.Seq2[map[string]any, error] {
+			return s.DisplayUsageSeq(ctx, name)
+		}},
+	}
+
+	for _, entry := range entries {
+		t.Run(entry.name, func(t *testing.T) {
+			transport := newMockTransport()
+			transport.addSuccessResponse(map[string]any{"NAME": "OBJ1"})
+			session := newTestSession(transport)
+
+			result, err := collectSeq(t, entry.call(session, context.Background(), "OBJ1"))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(result) != 1 {
+				t.Fatalf("expected 1 result, got %d", len(result))
+			}
+
+			payload := transport.lastCall().Payload
+			if payload["command"] != "DISPLAY" {
+				t.Errorf("command = %v, want DISPLAY", payload["command"])
+			}
+			if payload["qualifier"] != entry.qualifier {
+				t.Errorf("qualifier = %v, want %s", payload["qualifier"], entry.qualifier)
+			}
+		})
+	}
+}
@@ -100,8 +100,11 @@ func (session *Session) RestartService(ctx context.Context, name string, config
 
 func (session *Session) startAndPoll(ctx context.Context, name string,
 	objectConfig *objectTypeConfig, syncConfig SyncConfig,
-) (SyncResult, error) {
-	syncConfig, err := normalizeSyncConfig(syncConfig)
+) (result SyncResult, err error) {
+	ctx, end := session.observeSync(ctx, SyncStarted, name)
+	defer func() { end(err) }()
+
+	syncConfig, err = normalizeSyncConfig(syncConfig)
 	if err != nil {
 		return SyncResult{}, err
 	}
@@ -121,7 +124,7 @@ func (session *Session) startAndPoll(ctx context.Context, name string,
 		session.clock.sleep(syncConfig.PollInterval)
 
 		var statusRows []map[string]any
-		statusRows, err = session.queryStatus(ctx, name, objectConfig)
+		statusRows, err = session.queryStatus(withSyncPoll(ctx, SyncStarted, name, polls+1), name, objectConfig)
 		if err != nil {
 			return SyncResult{}, err
 		}
@@ -145,8 +148,11 @@ func (session *Session) startAndPoll(ctx context.Context, name string,
 
 func (session *Session) stopAndPoll(ctx context.Context, name string,
 	objectConfig *objectTypeConfig, syncConfig SyncConfig,
-) (SyncResult, error) {
-	syncConfig, err := normalizeSyncConfig(syncConfig)
+) (result SyncResult, err error) {
+	ctx, end := session.observeSync(ctx, SyncStopped, name)
+	defer func() { end(err) }()
+
+	syncConfig, err = normalizeSyncConfig(syncConfig)
 	if err != nil {
 		return SyncResult{}, err
 	}
@@ -166,7 +172,7 @@ func (session *Session) stopAndPoll(ctx context.Context, name string,
 		session.clock.sleep(syncConfig.PollInterval)
 
 		var statusRows []map[string]any
-		statusRows, err = session.queryStatus(ctx, name, objectConfig)
+		statusRows, err = session.queryStatus(withSyncPoll(ctx, SyncStopped, name, polls+1), name, objectConfig)
 		if err != nil {
 			return SyncResult{}, err
 		}
@@ -196,7 +202,10 @@ func (session *Session) stopAndPoll(ctx context.Context, name string,
 
 func (session *Session) restartObject(ctx context.Context, name string,
 	objectConfig *objectTypeConfig, syncConfig SyncConfig,
-) (SyncResult, error) {
+) (result SyncResult, err error) {
+	ctx, end := session.observeSync(ctx, SyncRestarted, name)
+	defer func() { end(err) }()
+
 	stopResult, err := session.stopAndPoll(ctx, name, objectConfig, syncConfig)
 	if err != nil {
 		return SyncResult{}, err
@@ -2,7 +2,11 @@ package mqrestadmin
 
 import (
 	"context"
+	"crypto/x509"
 	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 )
@@ -91,6 +95,28 @@ func TestNewSession_LTPAAuth(t *testing.T) {
 	}
 }
 
+func TestNewSession_WithLoginContext(t *testing.T) {
+	transport := newMockTransport()
+	transport.addResponse(200, map[string]any{}, map[string]string{
+		"Set-Cookie": "LtpaToken2=abc123token; Path=/; Secure",
+	})
+
+	_, err := NewSession(
+		"https://localhost:9443/ibmmq/rest/v2",
+		"QM1",
+		LTPAAuth{Username: "admin", Password: "pass"},
+		WithTransport(transport),
+		WithMiddleware(RequestIDMiddleware("")),
+		WithLoginContext(WithRequestID(context.Background(), "login-1")),
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if requestID := transport.calls[0].Headers[DefaultRequestIDHeader]; requestID != "login-1" {
+		t.Errorf("login request ID = %q, want login-1", requestID)
+	}
+}
+
 func TestNewSession_LTPAAuth_LoginFailure(t *testing.T) {
 	transport := newMockTransport()
 	transport.addResponse(401, map[string]any{}, nil)
@@ -936,6 +962,43 @@ func TestNewSession_CertificateAuth_NoTransport_ValidCert(t *testing.T) {
 	}
 }
 
+func TestNewSession_RootCAs(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"commandResponse":[],"overallCompletionCode":0,"overallReasonCode":0}`))
+	}))
+	defer server.Close()
+	pool := x509.NewCertPool()
+	pool.AddCert(server.Certificate())
+
+	session, err := NewSession(server.URL, "QM1", BasicAuth{Username: "admin", Password: "pass"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var transportErr *TransportError
+	if _, err := session.DisplayQmgr(context.Background()); !errors.As(err, &transportErr) {
+		t.Errorf("DisplayQmgr() without the server's CA = %v, want TransportError", err)
+	}
+
+	session, err = NewSession(server.URL, "QM1", BasicAuth{Username: "admin", Password: "pass"}, WithRootCAs(pool))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := session.DisplayQmgr(context.Background()); err != nil {
+		t.Errorf("DisplayQmgr() with the server's CA = %v", err)
+	}
+
+	certPEM, keyPEM := generateSelfSignedCert(t)
+	certFile := writeTempFile(t, "cert.pem", certPEM)
+	keyFile := writeTempFile(t, "key.pem", keyPEM)
+	session, err = NewSession(server.URL, "QM1", CertificateAuth{CertPath: certFile, KeyPath: keyFile}, WithRootCAs(pool))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := session.DisplayQmgr(context.Background()); err != nil {
+		t.Errorf("DisplayQmgr() with a client certificate and the server's CA = %v", err)
+	}
+}
+
 func TestWithBasicAuth_Option(t *testing.T) {
 	transport := newMockTransport()
 
@@ -1036,7 +1099,7 @@ func TestExtractCommandResponseObjects_NonListCommandResponse(t *testing.T) {
 	payload := map[string]any{
 		"commandResponse": "not a list",
 	}
-	result := extractCommandResponseObjects(payload)
+	result, _ := extractCommandResponseObjects(payload)
 	if result != nil {
 		t.Errorf("expected nil for non-list commandResponse, got %v", result)
 	}
@@ -1046,7 +1109,7 @@ func TestExtractCommandResponseObjects_NonMapItem(t *testing.T) {
 	payload := map[string]any{
 		"commandResponse": []any{"not a map"},
 	}
-	result := extractCommandResponseObjects(payload)
+	result, _ := extractCommandResponseObjects(payload)
 	if len(result) != 0 {
 		t.Errorf("expected 0 results for non-map item, got %d", len(result))
 	}
@@ -1058,7 +1121,7 @@ func TestExtractCommandResponseObjects_MissingParameters(t *testing.T) {
 			map[string]any{"completionCode": float64(0)},
 		},
 	}
-	result := extractCommandResponseObjects(payload)
+	result, _ := extractCommandResponseObjects(payload)
 	if len(result) != 0 {
 		t.Errorf("expected 0 results when parameters missing, got %d", len(result))
 	}
@@ -1073,7 +1136,7 @@ func TestExtractCommandResponseObjects_NonMapParameters(t *testing.T) {
 			},
 		},
 	}
-	result := extractCommandResponseObjects(payload)
+	result, _ := extractCommandResponseObjects(payload)
 	if len(result) != 0 {
 		t.Errorf("expected 0 results for non-map parameters, got %d", len(result))
 	}
@@ -1155,3 +1218,48 @@ func TestIsNonZeroNumber_IntType(t *testing.T) {
 		t.Error("expected string to not be a number")
 	}
 }
+
+func TestForQmgr_SharesConnection(t *testing.T) {
+	transport := newMockTransport()
+	transport.addSuccessResponse(map[string]any{"queue": "APP.Q", "maxdepth": float64(5000)})
+	session := newTestSessionWithMapping(transport)
+	session.credentials = LTPAAuth{Username: "admin", Password: "admin"}
+	session.ltpaCookieName = "LtpaToken2"
+	session.ltpaToken = "shared-token"
+
+	remote := session.ForQmgr("QM2")
+	if remote.QmgrName() != "QM2" || remote.GatewayQmgr() != "QM1" {
+		t.Errorf("remote = %s through %q, want QM2 through QM1", remote.QmgrName(), remote.GatewayQmgr())
+	}
+
+	rows, err := remote.DisplayQueue(context.Background(), "APP.Q")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if rows[0]["max_queue_depth"] != float64(5000) {
+		t.Errorf("rows = %v, want mapped attributes", rows)
+	}
+	call := transport.lastCall()
+	if !strings.HasSuffix(call.URL, "/qmgr/QM2/mqsc") {
+		t.Errorf("URL = %q, want QM2", call.URL)
+	}
+	if call.Headers["Cookie"] != "LtpaToken2=shared-token" || call.Headers["ibm-mq-rest-gateway-qmgr"] != "QM1" {
+		t.Errorf("headers = %v, want shared LTPA cookie and QM1 gateway", call.Headers)
+	}
+	if remote.LastCommandPayload == nil || session.LastCommandPayload != nil {
+		t.Error("Last* fields should belong to the derived session only")
+	}
+}
+
+func TestForQmgr_KeepsGateway(t *testing.T) {
+	session := newTestSession(newMockTransport())
+	session.gatewayQmgr = "GW1"
+	if remote := session.ForQmgr("QM2"); remote.GatewayQmgr() != "GW1" {
+		t.Errorf("gateway = %q, want GW1", remote.GatewayQmgr())
+	}
+
+	session.gatewayQmgr = ""
+	if same := session.ForQmgr("QM1"); same.GatewayQmgr() != "" {
+		t.Errorf("gateway = %q, want none for the session's own queue manager", same.GatewayQmgr())
+	}
+}
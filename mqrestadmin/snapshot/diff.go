@@ -0,0 +1,249 @@
+package snapshot
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Source identifies the snapshot on one side of a Diff.
+type Source struct {
+	QueueManager string    `json:"queue_manager"`
+	CapturedAt   time.Time `json:"captured_at"`
+}
+
+// AttributeChange is one attribute whose value differs between snapshots.
+// Before or After is nil when the attribute is absent on that side.
+type AttributeChange struct {
+	Attribute string `json:"attribute"`
+	Before    any    `json:"before"`
+	After     any    `json:"after"`
+}
+
+// ObjectDiff describes one added, removed, or changed object.
+type ObjectDiff struct {
+	ObjectType string `json:"object_type"`
+	Name       string `json:"name"`
+	// Attributes holds the object's attributes, for added and removed
+	// objects.
+	Attributes map[string]any `json:"attributes,omitempty"`
+	// Changes lists the differing attributes sorted by name, for changed
+	// objects.
+	Changes []AttributeChange `json:"changes,omitempty"`
+}
+
+// Diff is the difference between two snapshots. Objects are sorted by
+// object type, in the order ObjectTypes returns them, then by name.
+type Diff struct {
+	Before  Source       `json:"before"`
+	After   Source       `json:"after"`
+	Added   []ObjectDiff `json:"added"`
+	Removed []ObjectDiff `json:"removed"`
+	Changed []ObjectDiff `json:"changed"`
+}
+
+// Compare returns the objects added, removed, and changed between before and
+// after. Values are compared by their JSON encoding, so a number captured
+// live compares equal to the same number read back from a file.
+func Compare(before, after *Snapshot) *Diff {
+	diff := &Diff{
+		Before:  Source{QueueManager: before.QueueManager, CapturedAt: before.CapturedAt},
+		After:   Source{QueueManager: after.QueueManager, CapturedAt: after.CapturedAt},
+		Added:   []ObjectDiff{},
+		Removed: []ObjectDiff{},
+		Changed: []ObjectDiff{},
+	}
+
+	for _, objectType := range compareOrder(before, after) {
+		beforeObjects := before.Objects[objectType]
+		afterObjects := after.Objects[objectType]
+		names := slices.Sorted(maps.Keys(beforeObjects))
+		for name := range afterObjects {
+			if _, exists := beforeObjects[name]; !exists {
+				names = append(names, name)
+			}
+		}
+		slices.Sort(names)
+
+		for _, name := range names {
+			beforeAttributes, inBefore := beforeObjects[name]
+			afterAttributes, inAfter := afterObjects[name]
+			switch {
+			case !inBefore:
+				diff.Added = append(diff.Added, ObjectDiff{ObjectType: objectType, Name: name, Attributes: afterAttributes})
+			case !inAfter:
+				diff.Removed = append(diff.Removed, ObjectDiff{ObjectType: objectType, Name: name, Attributes: beforeAttributes})
+			default:
+				if changes := compareAttributes(beforeAttributes, afterAttributes); len(changes) > 0 {
+					diff.Changed = append(diff.Changed, ObjectDiff{ObjectType: objectType, Name: name, Changes: changes})
+				}
+			}
+		}
+	}
+	return diff
+}
+
+// compareOrder lists the object types present in either snapshot: known
+// types first in capture order, then any others sorted by name.
+func compareOrder(before, after *Snapshot) []string {
+	present := map[string]bool{}
+	for objectType := range before.Objects {
+		present[objectType] = true
+	}
+	for objectType := range after.Objects {
+		present[objectType] = true
+	}
+
+	var order []string
+	for _, objectType := range ObjectTypes() {
+		if present[objectType] {
+			order = append(order, objectType)
+			delete(present, objectType)
+		}
+	}
+	return append(order, slices.Sorted(maps.Keys(present))...)
+}
+
+func compareAttributes(before, after map[string]any) []AttributeChange {
+	names := slices.Sorted(maps.Keys(before))
+	for name := range after {
+		if _, exists := before[name]; !exists {
+			names = append(names, name)
+		}
+	}
+	slices.Sort(names)
+
+	var changes []AttributeChange
+	for _, name := range names {
+		beforeValue, afterValue := before[name], after[name]
+		if canonicalValue(beforeValue) != canonicalValue(afterValue) {
+			changes = append(changes, AttributeChange{Attribute: name, Before: beforeValue, After: afterValue})
+		}
+	}
+	return changes
+}
+
+// canonicalValue renders a value as JSON for comparison and display.
+func canonicalValue(value any) string {
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(encoded)
+}
+
+// Empty reports whether the snapshots had no differences.
+func (diff *Diff) Empty() bool {
+	return len(diff.Added) == 0 && len(diff.Removed) == 0 && len(diff.Changed) == 0
+}
+
+// WriteText writes a human-readable summary: a "+" line per added object, a
+// "-" line per removed object, and a "~" line per changed object followed by
+// its attribute changes. Values are shown as JSON.
+func (diff *Diff) WriteText(writer io.Writer) error {
+	var builder strings.Builder
+	fmt.Fprintf(&builder, "%s -> %s: %d added, %d removed, %d changed\n",
+		diff.Before.label(), diff.After.label(), len(diff.Added), len(diff.Removed), len(diff.Changed))
+	for _, object := range diff.Added {
+		fmt.Fprintf(&builder, "+ %s %s\n", object.ObjectType, object.Name)
+	}
+	for _, object := range diff.Removed {
+		fmt.Fprintf(&builder, "- %s %s\n", object.ObjectType, object.Name)
+	}
+	for _, object := range diff.Changed {
+		fmt.Fprintf(&builder, "~ %s %s\n", object.ObjectType, object.Name)
+		for _, change := range object.Changes {
+			fmt.Fprintf(&builder, "    %s: %s -> %s\n", change.Attribute,
+				displayValue(change.Before), displayValue(change.After))
+		}
+	}
+	_, err := io.WriteString(writer, builder.String())
+	return err
+}
+
+// WriteJSON writes the diff as indented JSON.
+func (diff *Diff) WriteJSON(writer io.Writer) error {
+	encoder := json.NewEncoder(writer)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(diff)
+}
+
+// WriteUnified writes the diff in unified diff style, with one hunk per
+// object headed by its type and name. Added and removed objects list every
+// attribute; changed objects list only the differing attributes. Each line
+// is "attribute: value" with the value shown as JSON.
+func (diff *Diff) WriteUnified(writer io.Writer) error {
+	var builder strings.Builder
+	fmt.Fprintf(&builder, "--- %s\n+++ %s\n", diff.Before.label(), diff.After.label())
+
+	type hunk struct {
+		object ObjectDiff
+		added  bool
+	}
+	hunks := make([]hunk, 0, len(diff.Added)+len(diff.Removed)+len(diff.Changed))
+	for _, object := range diff.Removed {
+		hunks = append(hunks, hunk{object: object})
+	}
+	for _, object := range diff.Added {
+		hunks = append(hunks, hunk{object: object, added: true})
+	}
+	for _, object := range diff.Changed {
+		hunks = append(hunks, hunk{object: object})
+	}
+	order := ObjectTypes()
+	slices.SortFunc(hunks, func(left, right hunk) int {
+		if byType := typeRank(order, left.object.ObjectType) - typeRank(order, right.object.ObjectType); byType != 0 {
+			return byType
+		}
+		if byType := strings.Compare(left.object.ObjectType, right.object.ObjectType); byType != 0 {
+			return byType
+		}
+		return strings.Compare(left.object.Name, right.object.Name)
+	})
+
+	for _, hunk := range hunks {
+		object := hunk.object
+		fmt.Fprintf(&builder, "@@ %s %s @@\n", object.ObjectType, object.Name)
+		prefix := "-"
+		if hunk.added {
+			prefix = "+"
+		}
+		for _, name := range slices.Sorted(maps.Keys(object.Attributes)) {
+			fmt.Fprintf(&builder, "%s%s: %s\n", prefix, name, canonicalValue(object.Attributes[name]))
+		}
+		for _, change := range object.Changes {
+			if change.Before != nil {
+				fmt.Fprintf(&builder, "-%s: %s\n", change.Attribute, canonicalValue(change.Before))
+			}
+			if change.After != nil {
+				fmt.Fprintf(&builder, "+%s: %s\n", change.Attribute, canonicalValue(change.After))
+			}
+		}
+	}
+	_, err := io.WriteString(writer, builder.String())
+	return err
+}
+
+// typeRank orders known object types by capture order, after which unknown
+// types sort together.
+func typeRank(order []string, objectType string) int {
+	if index := slices.Index(order, objectType); index >= 0 {
+		return index
+	}
+	return len(order)
+}
+
+func (source Source) label() string {
+	return source.QueueManager + " " + source.CapturedAt.UTC().Format(time.RFC3339)
+}
+
+func displayValue(value any) string {
+	if value == nil {
+		return "(absent)"
+	}
+	return canonicalValue(value)
+}
@@ -0,0 +1,204 @@
+package snapshot
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"math"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func diffTestSnapshots() (*Snapshot, *Snapshot) {
+	before := &Snapshot{
+		FormatVersion: FormatVersion,
+		QueueManager:  "QM1",
+		CapturedAt:    time.Date(2026, 10, 18, 9, 0, 0, 0, time.UTC),
+		Objects: map[string]map[string]map[string]any{
+			"queue": {
+				"APP.Q":  {"max_queue_depth": float64(5000), "description": "Orders", "trigger": "yes"},
+				"OLD.Q":  {"max_queue_depth": float64(100)},
+				"SAME.Q": {"max_queue_depth": float64(100)},
+				"LIST.Q": {"names": []any{"A", "B"}},
+			},
+			"widget": {"W1": {"size": float64(1)}},
+		},
+	}
+	after := &Snapshot{
+		FormatVersion: FormatVersion,
+		QueueManager:  "QM2",
+		CapturedAt:    time.Date(2026, 10, 18, 10, 0, 0, 0, time.UTC),
+		Objects: map[string]map[string]map[string]any{
+			"qmgr": {QmgrObjectName: {"description": "Prod"}},
+			"queue": {
+				"APP.Q":  {"max_queue_depth": json.Number("10000"), "description": "Orders", "inhibit_get": "no"},
+				"NEW.Q":  {"max_queue_depth": float64(100)},
+				"SAME.Q": {"max_queue_depth": int64(100)},
+				"LIST.Q": {"names": []string{"A", "B"}},
+			},
+			"gadget": {"G1": {}},
+		},
+	}
+	return before, after
+}
+
+func TestCompare(t *testing.T) {
+	before, after := diffTestSnapshots()
+	diff := Compare(before, after)
+
+	if diff.Empty() {
+		t.Fatal("Empty() = true, want differences")
+	}
+	if diff.Before.QueueManager != "QM1" || diff.After.QueueManager != "QM2" {
+		t.Errorf("sources = %+v %+v", diff.Before, diff.After)
+	}
+
+	wantAdded := []ObjectDiff{
+		{ObjectType: "qmgr", Name: QmgrObjectName, Attributes: map[string]any{"description": "Prod"}},
+		{ObjectType: "queue", Name: "NEW.Q", Attributes: map[string]any{"max_queue_depth": float64(100)}},
+		{ObjectType: "gadget", Name: "G1", Attributes: map[string]any{}},
+	}
+	if !reflect.DeepEqual(diff.Added, wantAdded) {
+		t.Errorf("Added = %#v\nwant %#v", diff.Added, wantAdded)
+	}
+
+	wantRemoved := []ObjectDiff{
+		{ObjectType: "queue", Name: "OLD.Q", Attributes: map[string]any{"max_queue_depth": float64(100)}},
+		{ObjectType: "widget", Name: "W1", Attributes: map[string]any{"size": float64(1)}},
+	}
+	if !reflect.DeepEqual(diff.Removed, wantRemoved) {
+		t.Errorf("Removed = %#v\nwant %#v", diff.Removed, wantRemoved)
+	}
+
+	wantChanged := []ObjectDiff{{
+		ObjectType: "queue",
+		Name:       "APP.Q",
+		Changes: []AttributeChange{
+			{Attribute: "inhibit_get", Before: nil, After: "no"},
+			{Attribute: "max_queue_depth", Before: float64(5000), After: json.Number("10000")},
+			{Attribute: "trigger", Before: "yes", After: nil},
+		},
+	}}
+	if !reflect.DeepEqual(diff.Changed, wantChanged) {
+		t.Errorf("Changed = %#v\nwant %#v", diff.Changed, wantChanged)
+	}
+}
+
+func TestCompare_Identical(t *testing.T) {
+	before, _ := diffTestSnapshots()
+	diff := Compare(before, before)
+	if !diff.Empty() {
+		t.Errorf("Compare(snapshot, snapshot) = %+v, want empty", diff)
+	}
+
+	var buffer bytes.Buffer
+	if err := diff.WriteJSON(&buffer); err != nil {
+		t.Fatalf("WriteJSON error: %v", err)
+	}
+	var decoded map[string]any
+	if err := json.Unmarshal(buffer.Bytes(), &decoded); err != nil {
+		t.Fatalf("decode error: %v", err)
+	}
+	if added, isList := decoded["added"].([]any); !isList || len(added) != 0 {
+		t.Errorf("added = %#v, want empty list rather than null", decoded["added"])
+	}
+}
+
+func TestDiff_WriteText(t *testing.T) {
+	before, after := diffTestSnapshots()
+	var buffer bytes.Buffer
+	if err := Compare(before, after).WriteText(&buffer); err != nil {
+		t.Fatalf("WriteText error: %v", err)
+	}
+
+	want := `QM1 2026-10-18T09:00:00Z -> QM2 2026-10-18T10:00:00Z: 3 added, 2 removed, 1 changed
++ qmgr QMGR
++ queue NEW.Q
++ gadget G1
+- queue OLD.Q
+- widget W1
+~ queue APP.Q
+    inhibit_get: (absent) -> "no"
+    max_queue_depth: 5000 -> 10000
+    trigger: "yes" -> (absent)
+`
+	if buffer.String() != want {
+		t.Errorf("text =\n%s\nwant\n%s", buffer.String(), want)
+	}
+}
+
+func TestDiff_WriteJSON(t *testing.T) {
+	before, after := diffTestSnapshots()
+	var buffer bytes.Buffer
+	if err := Compare(before, after).WriteJSON(&buffer); err != nil {
+		t.Fatalf("WriteJSON error: %v", err)
+	}
+
+	var decoded Diff
+	if err := json.Unmarshal(buffer.Bytes(), &decoded); err != nil {
+		t.Fatalf("decode error: %v", err)
+	}
+	if len(decoded.Added) != 3 || len(decoded.Removed) != 2 || len(decoded.Changed) != 1 {
+		t.Errorf("decoded = %+v, want 3 added, 2 removed, 1 changed", decoded)
+	}
+	if decoded.Changed[0].Changes[1].After != float64(10000) {
+		t.Errorf("after = %#v, want 10000", decoded.Changed[0].Changes[1].After)
+	}
+}
+
+func TestDiff_WriteUnified(t *testing.T) {
+	before, after := diffTestSnapshots()
+	var buffer bytes.Buffer
+	if err := Compare(before, after).WriteUnified(&buffer); err != nil {
+		t.Fatalf("WriteUnified error: %v", err)
+	}
+
+	want := `--- QM1 2026-10-18T09:00:00Z
++++ QM2 2026-10-18T10:00:00Z
+@@ qmgr QMGR @@
++description: "Prod"
+@@ queue APP.Q @@
++inhibit_get: "no"
+-max_queue_depth: 5000
++max_queue_depth: 10000
+-trigger: "yes"
+@@ queue NEW.Q @@
++max_queue_depth: 100
+@@ queue OLD.Q @@
+-max_queue_depth: 100
+@@ gadget G1 @@
+@@ widget W1 @@
+-size: 1
+`
+	if buffer.String() != want {
+		t.Errorf("unified =\n%s\nwant\n%s", buffer.String(), want)
+	}
+}
+
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) {
+	return 0, errors.New("disk full")
+}
+
+func TestDiff_WriteErrors(t *testing.T) {
+	before, after := diffTestSnapshots()
+	diff := Compare(before, after)
+	writers := map[string]func() error{
+		"text":    func() error { return diff.WriteText(failingWriter{}) },
+		"json":    func() error { return diff.WriteJSON(failingWriter{}) },
+		"unified": func() error { return diff.WriteUnified(failingWriter{}) },
+	}
+	for name, write := range writers {
+		if err := write(); err == nil {
+			t.Errorf("%s: error = nil, want write failure", name)
+		}
+	}
+}
+
+func TestCanonicalValue_Unencodable(t *testing.T) {
+	if got := canonicalValue(math.Inf(1)); got != "+Inf" {
+		t.Errorf("canonicalValue(+Inf) = %q, want +Inf", got)
+	}
+}
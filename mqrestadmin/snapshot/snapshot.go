@@ -0,0 +1,331 @@
+// Package snapshot captures a queue manager's configuration as a versioned
+// JSON document and compares two captures.
+//
+// A snapshot holds the attributes of every object of each supported type,
+// as returned by the session's DISPLAY methods, minus status-only and
+// volatile fields such as alteration_date or current_queue_depth. Snapshots
+// of two queue managers, or of one queue manager at two points in time, can
+// be compared with Compare:
+//
+//	before, err := snapshot.Capture(ctx, uat, snapshot.CaptureOptions{})
+//	after, err := snapshot.Capture(ctx, prod, snapshot.CaptureOptions{})
+//	diff := snapshot.Compare(before, after)
+//	err = diff.WriteText(os.Stdout)
+package snapshot
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/wphillipmoore/mq-rest-admin-go/mqrestadmin"
+)
+
+// FormatVersion is the snapshot document format written by Write and
+// accepted by Read.
+const FormatVersion = 1
+
+// QmgrObjectName is the object name under which the queue manager's own
+// attributes are stored, so that snapshots of differently named queue
+// managers compare attribute by attribute.
+const QmgrObjectName = "QMGR"
+
+// Snapshot is a point-in-time capture of a queue manager's configuration.
+type Snapshot struct {
+	FormatVersion int       `json:"format_version"`
+	QueueManager  string    `json:"queue_manager"`
+	CapturedAt    time.Time `json:"captured_at"`
+	// Objects maps an object type (see ObjectTypes) to object names to
+	// attributes. Identifying attributes such as queue_name are held in the
+	// object name rather than repeated in the attributes.
+	Objects map[string]map[string]map[string]any `json:"objects"`
+}
+
+// CaptureOptions configures Capture.
+type CaptureOptions struct {
+	// ObjectTypes limits the capture to the listed types. The default is
+	// every type returned by ObjectTypes.
+	ObjectTypes []string
+	// IncludeSystemObjects includes objects whose names start with SYSTEM.
+	IncludeSystemObjects bool
+	// IgnoreAttributes lists further attribute names to leave out, in
+	// addition to the status-only and volatile attributes always left out.
+	IgnoreAttributes []string
+}
+
+// objectType describes how one object type is listed and identified.
+type objectType struct {
+	name    string
+	display func(ctx context.Context, session *mqrestadmin.Session) ([]map[string]any, error)
+	// identity lists the attributes that identify an object, each as its
+	// mapped and MQSC names. Their values are joined to form the object name.
+	identity [][]string
+	// skip reports rows that are not defined on this queue manager.
+	skip func(row map[string]any) bool
+}
+
+var objectTypes = []objectType{
+	{
+		name: "qmgr",
+		display: func(ctx context.Context, session *mqrestadmin.Session) ([]map[string]any, error) {
+			row, err := session.DisplayQmgr(ctx)
+			if err != nil || row == nil {
+				return nil, err
+			}
+			return []map[string]any{row}, nil
+		},
+		identity: [][]string{{"queue_manager_name", "qmname"}},
+	},
+	{
+		name:     "authinfo",
+		display:  listAll((*mqrestadmin.Session).DisplayAuthinfo, "*"),
+		identity: [][]string{{"authinfo_name", "authinfo"}},
+	},
+	{
+		name:     "queue",
+		display:  listAll((*mqrestadmin.Session).DisplayQueue, "*"),
+		identity: [][]string{{"queue_name", "queue"}},
+		skip:     attributeEquals([]string{"type"}, "QCLUSTER"),
+	},
+	{
+		name:     "namelist",
+		display:  listAll((*mqrestadmin.Session).DisplayNamelist, "*"),
+		identity: [][]string{{"namelist_name", "namelist"}},
+	},
+	{
+		name:     "process",
+		display:  listAll((*mqrestadmin.Session).DisplayProcess, "*"),
+		identity: [][]string{{"process_name", "process"}},
+	},
+	{
+		name:     "channel",
+		display:  listAll((*mqrestadmin.Session).DisplayChannel, "*"),
+		identity: [][]string{{"channel_name", "channel"}},
+	},
+	{
+		name:     "topic",
+		display:  listAll((*mqrestadmin.Session).DisplayTopic, "*"),
+		identity: [][]string{{"topic_name", "topic"}},
+		skip:     attributeEquals([]string{"topic_type", "type"}, "CLUSTER"),
+	},
+	{
+		name:     "sub",
+		display:  listAll((*mqrestadmin.Session).DisplaySub, "*"),
+		identity: [][]string{{"subscription_name", "sub"}},
+		skip: func(row map[string]any) bool {
+			subscriptionType, exists := lookup(row, []string{"subscription_type", "subtype"})
+			return exists && !strings.EqualFold(fmt.Sprint(subscriptionType), "ADMIN")
+		},
+	},
+	{
+		name:     "listener",
+		display:  listAll((*mqrestadmin.Session).DisplayListener, "*"),
+		identity: [][]string{{"listener_name", "listener"}},
+	},
+	{
+		name:     "service",
+		display:  listAll((*mqrestadmin.Session).DisplayService, "*"),
+		identity: [][]string{{"service_name", "service"}},
+	},
+	{
+		name:     "comminfo",
+		display:  listAll((*mqrestadmin.Session).DisplayComminfo, "*"),
+		identity: [][]string{{"comminfo_name", "comminfo"}},
+	},
+	{
+		name: "chlauth",
+		display: func(ctx context.Context, session *mqrestadmin.Session) ([]map[string]any, error) {
+			rows, err := session.DisplayChlauth(ctx, "*")
+			if mqrestadmin.HasReasonCode(err, mqrestadmin.ReasonChlauthNotFound) {
+				return nil, nil
+			}
+			return rows, err
+		},
+		identity: [][]string{
+			{"channel_profile", "chlauth"}, {"type"}, {"address"}, {"ssl_peer_name", "sslpeer"},
+			{"queue_manager_name", "qmname"}, {"client_user", "clntuser"},
+		},
+	},
+	{
+		name:    "authrec",
+		display: listAll((*mqrestadmin.Session).DisplayAuthrec, ""),
+		identity: [][]string{
+			{"profile_name", "profile"}, {"object_type", "objtype"}, {"entity_type", "enttype"}, {"entity"},
+		},
+	},
+}
+
+// volatileAttributes lists status-only and per-queue-manager attributes, by
+// mapped and MQSC name, that Capture always leaves out. The *_timestamp
+// names are the date and time pairs merged by WithConvertValues.
+var volatileAttributes = []string{
+	"alteration_date", "altdate", "alteration_time", "alttime", "alteration_timestamp",
+	"creation_date", "crdate", "creation_time", "crtime", "creation_timestamp",
+	"current_queue_depth", "curdepth", "open_input_count", "ipprocs",
+	"open_output_count", "opprocs", "cluster_date", "clusdate",
+	"cluster_time", "clustime", "cluster_timestamp", "queue_manager_id", "qmid",
+	"subscription_id", "subid", "name_count", "namcount",
+}
+
+// ObjectTypes returns the supported object type names in capture order.
+func ObjectTypes() []string {
+	names := make([]string, len(objectTypes))
+	for index, objectType := range objectTypes {
+		names[index] = objectType.name
+	}
+	return names
+}
+
+// Capture reads the configuration of the session's queue manager. Object
+// names and attributes use whatever naming the session is configured for:
+// snake_case with attribute mapping, or MQSC names without it. Snapshots
+// are only comparable when they were captured with the same naming.
+func Capture(ctx context.Context, session *mqrestadmin.Session, opts CaptureOptions) (*Snapshot, error) {
+	selected := opts.ObjectTypes
+	if len(selected) == 0 {
+		selected = ObjectTypes()
+	}
+	for _, name := range selected {
+		if !slices.Contains(ObjectTypes(), name) {
+			return nil, fmt.Errorf("snapshot: unknown object type %q", name)
+		}
+	}
+
+	ignored := make(map[string]bool, len(volatileAttributes)+len(opts.IgnoreAttributes))
+	for _, name := range append(slices.Clone(volatileAttributes), opts.IgnoreAttributes...) {
+		ignored[strings.ToLower(name)] = true
+	}
+
+	snapshot := &Snapshot{
+		FormatVersion: FormatVersion,
+		QueueManager:  session.QmgrName(),
+		CapturedAt:    time.Now().UTC(),
+		Objects:       map[string]map[string]map[string]any{},
+	}
+	for _, objectType := range objectTypes {
+		if !slices.Contains(selected, objectType.name) {
+			continue
+		}
+		rows, err := objectType.display(ctx, session)
+		if err != nil {
+			return nil, fmt.Errorf("snapshot: display %s: %w", objectType.name, err)
+		}
+		snapshot.Objects[objectType.name] = captureObjects(objectType, rows, ignored, opts.IncludeSystemObjects)
+	}
+	return snapshot, nil
+}
+
+func captureObjects(objectType objectType, rows []map[string]any, ignored map[string]bool,
+	includeSystemObjects bool,
+) map[string]map[string]any {
+	identityNames := map[string]bool{}
+	for _, alternatives := range objectType.identity {
+		for _, name := range alternatives {
+			identityNames[name] = true
+		}
+	}
+
+	objects := make(map[string]map[string]any, len(rows))
+	for _, row := range rows {
+		if objectType.skip != nil && objectType.skip(row) {
+			continue
+		}
+
+		name := QmgrObjectName
+		if objectType.name != "qmgr" {
+			name = objectName(row, objectType.identity)
+			if !includeSystemObjects && strings.HasPrefix(name, "SYSTEM.") {
+				continue
+			}
+		}
+
+		attributes := make(map[string]any, len(row))
+		for key, value := range row {
+			lowered := strings.ToLower(key)
+			if ignored[lowered] || identityNames[lowered] {
+				continue
+			}
+			attributes[key] = value
+		}
+		objects[name] = attributes
+	}
+	return objects
+}
+
+// objectName joins the non-blank identity attribute values of a row.
+func objectName(row map[string]any, identity [][]string) string {
+	var parts []string
+	for _, alternatives := range identity {
+		value, exists := lookup(row, alternatives)
+		if !exists {
+			continue
+		}
+		if text := strings.TrimSpace(fmt.Sprint(value)); text != "" {
+			parts = append(parts, text)
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+// lookup finds the first of names in row, ignoring case, so that mapped
+// names and the lowercase MQSC names the REST API returns both match.
+func lookup(row map[string]any, names []string) (any, bool) {
+	for _, name := range names {
+		for key, value := range row {
+			if strings.EqualFold(key, name) {
+				return value, true
+			}
+		}
+	}
+	return nil, false
+}
+
+func attributeEquals(names []string, want string) func(row map[string]any) bool {
+	return func(row map[string]any) bool {
+		value, exists := lookup(row, names)
+		return exists && strings.EqualFold(fmt.Sprint(value), want)
+	}
+}
+
+// listAll adapts a generated DISPLAY method to list objects by name, where
+// an empty name omits the name from the command.
+func listAll(display func(*mqrestadmin.Session, context.Context, string, ...mqrestadmin.CommandOption) ([]map[string]any, error),
+	name string,
+) func(ctx context.Context, session *mqrestadmin.Session) ([]map[string]any, error) {
+	return func(ctx context.Context, session *mqrestadmin.Session) ([]map[string]any, error) {
+		return display(session, ctx, name)
+	}
+}
+
+// Write encodes the snapshot as indented JSON. Map keys are sorted, so
+// snapshots of the same configuration encode identically apart from
+// captured_at.
+func (snapshot *Snapshot) Write(writer io.Writer) error {
+	encoder := json.NewEncoder(writer)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(snapshot)
+}
+
+// Read decodes a snapshot written by Write. Numbers are decoded as
+// json.Number so that large integers keep their precision. It returns an
+// error for a document in an unsupported format version.
+func Read(reader io.Reader) (*Snapshot, error) {
+	decoder := json.NewDecoder(reader)
+	decoder.UseNumber()
+	var snapshot Snapshot
+	if err := decoder.Decode(&snapshot); err != nil {
+		return nil, fmt.Errorf("snapshot: decode: %w", err)
+	}
+	if snapshot.FormatVersion != FormatVersion {
+		return nil, fmt.Errorf("snapshot: unsupported format version %d (want %d)",
+			snapshot.FormatVersion, FormatVersion)
+	}
+	if snapshot.Objects == nil {
+		snapshot.Objects = map[string]map[string]map[string]any{}
+	}
+	return &snapshot, nil
+}
@@ -0,0 +1,342 @@
+package snapshot
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/wphillipmoore/mq-rest-admin-go/mqrestadmin"
+)
+
+// qualifierTransport is a test Transport that answers DISPLAY commands from
+// rows keyed by qualifier.
+type qualifierTransport struct {
+	rows          map[string][]map[string]any
+	commandErrors map[string]int
+	failures      map[string]error
+	qualifiers    []string
+	names         []any
+}
+
+func (transport *qualifierTransport) PostJSON(_ context.Context, _ string, payload map[string]any,
+	_ map[string]string, _ time.Duration, _ bool,
+) (*mqrestadmin.TransportResponse, error) {
+	qualifier, _ := payload["qualifier"].(string)
+	transport.qualifiers = append(transport.qualifiers, qualifier)
+	transport.names = append(transport.names, payload["name"])
+	if err := transport.failures[qualifier]; err != nil {
+		return nil, err
+	}
+
+	body := map[string]any{"overallCompletionCode": 0, "overallReasonCode": 0}
+	items := []any{}
+	if reasonCode, failed := transport.commandErrors[qualifier]; failed {
+		body = map[string]any{"overallCompletionCode": 2, "overallReasonCode": 3008}
+		items = append(items, map[string]any{"completionCode": 2, "reasonCode": reasonCode})
+	}
+	for _, row := range transport.rows[qualifier] {
+		items = append(items, map[string]any{"completionCode": 0, "reasonCode": 0, "parameters": row})
+	}
+	body["commandResponse"] = items
+
+	encoded, _ := json.Marshal(body)
+	return &mqrestadmin.TransportResponse{StatusCode: 200, Body: string(encoded), Headers: map[string]string{}}, nil
+}
+
+func newTestSession(t *testing.T, transport mqrestadmin.Transport, mapAttributes bool) *mqrestadmin.Session {
+	t.Helper()
+	token := "test"
+	session, err := mqrestadmin.NewSession(
+		"https://localhost:9443/ibmmq/rest/v2",
+		"QM1",
+		mqrestadmin.BasicAuth{Username: "admin", Password: "admin"},
+		mqrestadmin.WithTransport(transport),
+		mqrestadmin.WithCSRFToken(&token),
+		mqrestadmin.WithMapAttributes(mapAttributes),
+	)
+	if err != nil {
+		t.Fatalf("newTestSession: %v", err)
+	}
+	return session
+}
+
+func testRows() map[string][]map[string]any {
+	return map[string][]map[string]any{
+		"QMGR": {{"qmname": "QM1", "qmid": "QM1_2026", "descr": "Test", "maxmsgl": 4194304}},
+		"QUEUE": {
+			{
+				"queue": "APP.Q", "type": "QLOCAL", "maxdepth": 5000, "curdepth": 12,
+				"altdate": "2026-10-18", "alttime": "10.00.00",
+			},
+			{"queue": "CLUS.Q", "type": "QCLUSTER"},
+			{"queue": "SYSTEM.DEFAULT.LOCAL.QUEUE", "type": "QLOCAL", "maxdepth": 5000},
+		},
+		"TOPIC": {
+			{"topic": "APP.T", "type": "LOCAL", "topicstr": "app"},
+			{"topic": "CLUS.T", "type": "CLUSTER", "topicstr": "clus"},
+		},
+		"SUB": {
+			{"sub": "APP.SUB", "subtype": "ADMIN", "subid": "414D51"},
+			{"sub": "API.SUB", "subtype": "API", "subid": "414D52"},
+		},
+		"CHLAUTH": {
+			{"chlauth": "APP.SVRCONN", "type": "ADDRESSMAP", "address": "10.0.0.1", "usersrc": "CHANNEL"},
+			{"chlauth": "APP.SVRCONN", "type": "BLOCKUSER", "userlist": []any{"nobody"}},
+		},
+		"AUTHREC": {
+			{"profile": "APP.Q", "objtype": "QUEUE", "entity": "app", "enttype": "PRINCIPAL", "authlist": []any{"GET"}},
+		},
+	}
+}
+
+func TestObjectTypes(t *testing.T) {
+	want := []string{
+		"qmgr", "authinfo", "queue", "namelist", "process", "channel", "topic", "sub",
+		"listener", "service", "comminfo", "chlauth", "authrec",
+	}
+	if got := ObjectTypes(); !reflect.DeepEqual(got, want) {
+		t.Errorf("ObjectTypes() = %v, want %v", got, want)
+	}
+}
+
+func TestCapture(t *testing.T) {
+	transport := &qualifierTransport{rows: testRows()}
+	session := newTestSession(t, transport, false)
+
+	snapshot, err := Capture(context.Background(), session, CaptureOptions{IgnoreAttributes: []string{"DESCR"}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if snapshot.FormatVersion != FormatVersion || snapshot.QueueManager != "QM1" || snapshot.CapturedAt.IsZero() {
+		t.Errorf("header = %d %q %v", snapshot.FormatVersion, snapshot.QueueManager, snapshot.CapturedAt)
+	}
+	wantQualifiers := []string{
+		"QMGR", "AUTHINFO", "QUEUE", "NAMELIST", "PROCESS", "CHANNEL", "TOPIC", "SUB",
+		"LISTENER", "SERVICE", "COMMINFO", "CHLAUTH", "AUTHREC",
+	}
+	if !reflect.DeepEqual(transport.qualifiers, wantQualifiers) {
+		t.Errorf("qualifiers = %v, want %v", transport.qualifiers, wantQualifiers)
+	}
+	if transport.names[2] != "*" || transport.names[12] != nil {
+		t.Errorf("names = %v, want generic QUEUE and unnamed AUTHREC", transport.names)
+	}
+
+	want := map[string]map[string]map[string]any{
+		"qmgr":  {QmgrObjectName: {"maxmsgl": float64(4194304)}},
+		"queue": {"APP.Q": {"type": "QLOCAL", "maxdepth": float64(5000)}},
+		"topic": {"APP.T": {"type": "LOCAL", "topicstr": "app"}},
+		"sub":   {"APP.SUB": {"subtype": "ADMIN"}},
+		"chlauth": {
+			"APP.SVRCONN ADDRESSMAP 10.0.0.1": {"usersrc": "CHANNEL"},
+			"APP.SVRCONN BLOCKUSER":           {"userlist": []any{"nobody"}},
+		},
+		"authrec":  {"APP.Q QUEUE PRINCIPAL app": {"authlist": []any{"GET"}}},
+		"authinfo": {}, "namelist": {}, "process": {}, "channel": {},
+		"listener": {}, "service": {}, "comminfo": {},
+	}
+	if !reflect.DeepEqual(snapshot.Objects, want) {
+		t.Errorf("Objects = %#v\nwant %#v", snapshot.Objects, want)
+	}
+}
+
+func TestCapture_MappedSelectedTypesWithSystemObjects(t *testing.T) {
+	transport := &qualifierTransport{rows: testRows()}
+	session := newTestSession(t, transport, true)
+
+	snapshot, err := Capture(context.Background(), session, CaptureOptions{
+		ObjectTypes:          []string{"queue"},
+		IncludeSystemObjects: true,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !reflect.DeepEqual(transport.qualifiers, []string{"QUEUE"}) {
+		t.Errorf("qualifiers = %v, want only QUEUE", transport.qualifiers)
+	}
+	queues := snapshot.Objects["queue"]
+	if len(snapshot.Objects) != 1 || len(queues) != 2 {
+		t.Fatalf("Objects = %v, want two queues", snapshot.Objects)
+	}
+	if queues["APP.Q"]["max_queue_depth"] != float64(5000) {
+		t.Errorf("APP.Q = %v, want mapped attributes", queues["APP.Q"])
+	}
+	if _, exists := queues["APP.Q"]["current_queue_depth"]; exists {
+		t.Errorf("APP.Q = %v, want current_queue_depth left out", queues["APP.Q"])
+	}
+	if _, exists := queues["SYSTEM.DEFAULT.LOCAL.QUEUE"]; !exists {
+		t.Errorf("queues = %v, want SYSTEM queue included", queues)
+	}
+}
+
+func TestCapture_ConvertValuesLeavesOutTimestamps(t *testing.T) {
+	transport := &qualifierTransport{rows: map[string][]map[string]any{
+		"QUEUE": {{
+			"queue": "APP.Q", "type": "QLOCAL", "maxdepth": 5000,
+			"altdate": "2026-10-18", "alttime": "10.00.00", "crdate": "2026-01-01", "crtime": "09.00.00",
+		}},
+	}}
+	token := "test"
+	session, err := mqrestadmin.NewSession("https://localhost:9443/ibmmq/rest/v2", "QM1",
+		mqrestadmin.BasicAuth{Username: "admin", Password: "admin"},
+		mqrestadmin.WithTransport(transport), mqrestadmin.WithCSRFToken(&token), mqrestadmin.WithConvertValues(true))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	snapshot, err := Capture(context.Background(), session, CaptureOptions{ObjectTypes: []string{"queue"}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := map[string]map[string]map[string]any{
+		"queue": {"APP.Q": {"type": "QLOCAL", "max_queue_depth": int64(5000)}},
+	}
+	if !reflect.DeepEqual(snapshot.Objects, want) {
+		t.Errorf("Objects = %#v\nwant %#v", snapshot.Objects, want)
+	}
+}
+
+func TestCapture_ChlauthNotFoundIsEmpty(t *testing.T) {
+	transport := &qualifierTransport{
+		rows: testRows(), commandErrors: map[string]int{"CHLAUTH": 3346}, // MQRCCF_CHLAUTH_NOT_FOUND
+	}
+	session := newTestSession(t, transport, false)
+
+	snapshot, err := Capture(context.Background(), session, CaptureOptions{ObjectTypes: []string{"chlauth"}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(snapshot.Objects["chlauth"]) != 0 {
+		t.Errorf("chlauth = %v, want empty", snapshot.Objects["chlauth"])
+	}
+}
+
+func TestCapture_EmptyQmgrResponse(t *testing.T) {
+	transport := &qualifierTransport{}
+	session := newTestSession(t, transport, false)
+
+	snapshot, err := Capture(context.Background(), session, CaptureOptions{ObjectTypes: []string{"qmgr"}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(snapshot.Objects["qmgr"]) != 0 {
+		t.Errorf("qmgr = %v, want empty", snapshot.Objects["qmgr"])
+	}
+}
+
+func TestCapture_Errors(t *testing.T) {
+	transportErr := errors.New("connection refused")
+	tests := []struct {
+		name      string
+		transport *qualifierTransport
+		opts      CaptureOptions
+		wantText  string
+	}{
+		{
+			name:      "unknown type",
+			transport: &qualifierTransport{},
+			opts:      CaptureOptions{ObjectTypes: []string{"queue", "widget"}},
+			wantText:  `unknown object type "widget"`,
+		},
+		{
+			name:      "transport error",
+			transport: &qualifierTransport{failures: map[string]error{"CHANNEL": transportErr}},
+			wantText:  "display channel",
+		},
+		{
+			name:      "command error",
+			transport: &qualifierTransport{commandErrors: map[string]int{"QMGR": 2035}},
+			wantText:  "display qmgr",
+		},
+		{
+			name:      "chlauth command error",
+			transport: &qualifierTransport{commandErrors: map[string]int{"CHLAUTH": 2035}},
+			opts:      CaptureOptions{ObjectTypes: []string{"chlauth"}},
+			wantText:  "display chlauth",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			session := newTestSession(t, tt.transport, false)
+			snapshot, err := Capture(context.Background(), session, tt.opts)
+			if err == nil || snapshot != nil {
+				t.Fatalf("Capture = %v, %v; want error", snapshot, err)
+			}
+			if !strings.Contains(err.Error(), tt.wantText) {
+				t.Errorf("error = %q, want it to contain %q", err, tt.wantText)
+			}
+		})
+	}
+}
+
+func TestWriteRead_RoundTrip(t *testing.T) {
+	original := &Snapshot{
+		FormatVersion: FormatVersion,
+		QueueManager:  "QM1",
+		CapturedAt:    time.Date(2026, 10, 18, 9, 30, 0, 0, time.UTC),
+		Objects: map[string]map[string]map[string]any{
+			"queue": {"APP.Q": {"max_queue_depth": 9007199254740993, "description": "Orders"}},
+		},
+	}
+
+	var buffer bytes.Buffer
+	if err := original.Write(&buffer); err != nil {
+		t.Fatalf("Write error: %v", err)
+	}
+	if !strings.Contains(buffer.String(), "\n  \"format_version\": 1,\n") {
+		t.Errorf("document = %s, want indented format_version", buffer.String())
+	}
+
+	loaded, err := Read(&buffer)
+	if err != nil {
+		t.Fatalf("Read error: %v", err)
+	}
+	if !loaded.CapturedAt.Equal(original.CapturedAt) || loaded.QueueManager != "QM1" {
+		t.Errorf("header = %q %v", loaded.QueueManager, loaded.CapturedAt)
+	}
+	depth := loaded.Objects["queue"]["APP.Q"]["max_queue_depth"]
+	if depth != json.Number("9007199254740993") {
+		t.Errorf("max_queue_depth = %#v, want exact json.Number", depth)
+	}
+	if diff := Compare(original, loaded); !diff.Empty() {
+		t.Errorf("Compare(original, loaded) = %+v, want empty", diff)
+	}
+}
+
+func TestRead_Errors(t *testing.T) {
+	tests := []struct {
+		name     string
+		document string
+		wantText string
+	}{
+		{"malformed", "{", "snapshot: decode"},
+		{"version", `{"format_version": 2}`, "unsupported format version 2"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			snapshot, err := Read(strings.NewReader(tt.document))
+			if err == nil || snapshot != nil {
+				t.Fatalf("Read = %v, %v; want error", snapshot, err)
+			}
+			if !strings.Contains(err.Error(), tt.wantText) {
+				t.Errorf("error = %q, want it to contain %q", err, tt.wantText)
+			}
+		})
+	}
+}
+
+func TestRead_MissingObjects(t *testing.T) {
+	snapshot, err := Read(strings.NewReader(`{"format_version": 1, "queue_manager": "QM1"}`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if snapshot.Objects == nil || len(snapshot.Objects) != 0 {
+		t.Errorf("Objects = %#v, want empty map", snapshot.Objects)
+	}
+}
@@ -1,6 +1,9 @@
 package mqrestadmin
 
-import "time"
+import (
+	"context"
+	"time"
+)
 
 // SyncOperation describes the type of state transition performed by a sync
 // method.
@@ -13,6 +16,10 @@ const (
 	SyncStopped
 	// SyncRestarted indicates the object was stopped then started.
 	SyncRestarted
+	// SyncPublished indicates a publication reached a topic's subscriptions.
+	SyncPublished
+	// SyncTransferred indicates a Managed File Transfer transfer finished.
+	SyncTransferred
 )
 
 func (operation SyncOperation) String() string {
@@ -23,6 +30,10 @@ func (operation SyncOperation) String() string {
 		return "stopped"
 	case SyncRestarted:
 		return "restarted"
+	case SyncPublished:
+		return "published"
+	case SyncTransferred:
+		return "transferred"
 	default:
 		return "unknown"
 	}
@@ -38,6 +49,59 @@ type SyncConfig struct {
 	PollInterval time.Duration
 }
 
+// SyncPoll identifies a status check made by a sync or wait method. The
+// context passed to the transport for each poll carries it, so middlewares
+// can tell polls apart from other requests; see SyncPollFromContext.
+type SyncPoll struct {
+	// Operation is the transition being waited for.
+	Operation SyncOperation
+	// Name is the object, topic or transfer being polled.
+	Name string
+	// Poll is the number of this status check, starting at 1.
+	Poll int
+}
+
+type syncPollKey struct{}
+
+// SyncPollFromContext returns the sync poll a request belongs to, if any.
+func SyncPollFromContext(ctx context.Context) (SyncPoll, bool) {
+	poll, exists := ctx.Value(syncPollKey{}).(SyncPoll)
+	return poll, exists
+}
+
+// withSyncPoll returns a context marking requests as a sync poll.
+func withSyncPoll(ctx context.Context, operation SyncOperation, name string, poll int) context.Context {
+	return context.WithValue(ctx, syncPollKey{}, SyncPoll{Operation: operation, Name: name, Poll: poll})
+}
+
+// SyncObserver is notified when a sync or wait method, such as
+// StartChannelSync, PublishAndVerify or WaitForTransfer, begins. The
+// returned context is used for every request the call makes, including its
+// status checks, and end is called with the call's error when it returns.
+// RestartChannel and the other restart methods are observed as a restart
+// that contains the stop and the start. otelmq.SyncObserver uses it to
+// record a span for the whole call.
+type SyncObserver func(ctx context.Context, operation SyncOperation, name string) (context.Context, func(err error))
+
+// WithSyncObserver sets the observer notified of sync and wait calls.
+func WithSyncObserver(observer SyncObserver) Option {
+	return func(config *sessionConfig) {
+		config.syncObserver = observer
+	}
+}
+
+// observeSync notifies the session's sync observer, if any, that a sync
+// call has begun. The returned function must be called with the call's
+// error when it returns.
+func (session *Session) observeSync(ctx context.Context, operation SyncOperation,
+	name string,
+) (context.Context, func(error)) {
+	if session.syncObserver == nil {
+		return ctx, func(error) {}
+	}
+	return session.syncObserver(ctx, operation, name)
+}
+
 // SyncResult describes the outcome of a synchronous polling operation.
 type SyncResult struct {
 	// Operation indicates whether the object was started, stopped, or restarted.
@@ -3,6 +3,7 @@ package mqrestadmin
 import (
 	"context"
 	"errors"
+	"fmt"
 	"strings"
 	"testing"
 	"time"
@@ -152,6 +153,84 @@ func TestRestartChannel_Success(t *testing.T) {
 	}
 }
 
+func TestSyncPollFromContext(t *testing.T) {
+	transport := newMockTransport()
+	transport.addSuccessResponse()
+	transport.addSuccessResponse(map[string]any{"CHANNEL": "TO.REMOTE", "STATUS": "STOPPING"})
+	transport.addCommandErrorResponse(2, 2085)
+	transport.addSuccessResponse()
+	transport.addSuccessResponse(map[string]any{"CHANNEL": "TO.REMOTE", "STATUS": "RUNNING"})
+
+	session := newTestSessionWithClock(transport, newMockClock())
+	var polls []string
+	session.transport = NewMiddleware(func(next RoundTrip) RoundTrip {
+		return func(ctx context.Context, request *TransportRequest) (*TransportResponse, error) {
+			entry := request.Command
+			if poll, exists := SyncPollFromContext(ctx); exists {
+				entry = fmt.Sprintf("%s %s #%d", poll.Operation, poll.Name, poll.Poll)
+			}
+			polls = append(polls, entry)
+			return next(ctx, request)
+		}
+	})(transport)
+
+	if _, err := session.RestartChannel(context.Background(), "TO.REMOTE", SyncConfig{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := "STOP,stopped TO.REMOTE #1,stopped TO.REMOTE #2,START,started TO.REMOTE #1"
+	if got := strings.Join(polls, ","); got != want {
+		t.Errorf("requests = %s, want %s", got, want)
+	}
+}
+
+func TestWithSyncObserver(t *testing.T) {
+	transport := newMockTransport()
+	transport.addSuccessResponse()
+	transport.addSuccessResponse()
+	transport.addSuccessResponse()
+	transport.addSuccessResponse(map[string]any{"CHANNEL": "TO.REMOTE", "STATUS": "RUNNING"})
+	transport.addCommandErrorResponse(2, 2085)
+
+	type callKey struct{}
+	var calls []string
+	observer := func(ctx context.Context, operation SyncOperation, name string) (context.Context, func(error)) {
+		calls = append(calls, fmt.Sprintf("begin %s %s", operation, name))
+		return context.WithValue(ctx, callKey{}, operation), func(err error) {
+			calls = append(calls, fmt.Sprintf("end %s %v", operation, err != nil))
+		}
+	}
+	session := newTestSessionWithClock(transport, newMockClock())
+	session.syncObserver = observer
+	session.transport = NewMiddleware(func(next RoundTrip) RoundTrip {
+		return func(ctx context.Context, request *TransportRequest) (*TransportResponse, error) {
+			calls = append(calls, fmt.Sprintf("%s in %v", request.Command, ctx.Value(callKey{})))
+			return next(ctx, request)
+		}
+	})(transport)
+
+	if _, err := session.RestartChannel(context.Background(), "TO.REMOTE", SyncConfig{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := session.ForQmgr("QM2").StartChannelSync(context.Background(), "MISSING", SyncConfig{}); err == nil {
+		t.Fatal("expected command error")
+	}
+	want := []string{
+		"begin restarted TO.REMOTE", "begin stopped TO.REMOTE", "STOP in stopped", "DISPLAY in stopped",
+		"end stopped false", "begin started TO.REMOTE", "START in started", "DISPLAY in started",
+		"end started false", "end restarted false",
+		"begin started MISSING", "START in started", "end started true",
+	}
+	if got := strings.Join(calls, ","); got != strings.Join(want, ",") {
+		t.Errorf("calls = %s, want %s", got, strings.Join(want, ","))
+	}
+
+	config := defaultConfig()
+	WithSyncObserver(observer)(&config)
+	if config.syncObserver == nil {
+		t.Error("WithSyncObserver did not set the observer")
+	}
+}
+
 func TestSyncConfig_Defaults(t *testing.T) {
 	config, err := normalizeSyncConfig(SyncConfig{})
 	if err != nil {
@@ -35,17 +35,102 @@ type HTTPTransport struct {
 	TLSConfig *tls.Config
 }
 
+// StreamingTransport is an optional extension of Transport for
+// implementations that can return the response body as a stream instead of
+// buffering it. The DISPLAY iterator methods use it to decode large results
+// incrementally; transports without it fall back to PostJSON.
+type StreamingTransport interface {
+	// PostJSONStream sends a JSON POST request and returns the response with
+	// an unread body. The caller must close the body.
+	PostJSONStream(ctx context.Context, url string, payload map[string]any,
+		headers map[string]string, timeout time.Duration, verifyTLS bool,
+	) (*StreamingResponse, error)
+}
+
+// StreamingResponse holds the HTTP response data from a streaming transport
+// call. Body must be closed by the caller.
+type StreamingResponse struct {
+	StatusCode int
+	Body       io.ReadCloser
+	Headers    map[string]string
+}
+
+// RequestTransport is an optional extension of Transport for
+// implementations that can send a request with any HTTP method and a raw
+// body. The Messaging client requires it; HTTPTransport implements it.
+type RequestTransport interface {
+	// SendRequest sends a request with the given method and body, which may
+	// be nil, and returns the response.
+	SendRequest(ctx context.Context, method, url string, body []byte,
+		headers map[string]string, timeout time.Duration, verifyTLS bool,
+	) (*TransportResponse, error)
+}
+
 // PostJSON sends a JSON POST request using net/http.
 func (transport *HTTPTransport) PostJSON(ctx context.Context, url string,
 	payload map[string]any, headers map[string]string, timeout time.Duration,
 	verifyTLS bool,
 ) (*TransportResponse, error) {
+	response, err := transport.PostJSONStream(ctx, url, payload, headers, timeout, verifyTLS)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = response.Body.Close() }()
+
+	responseBody, err := io.ReadAll(response.Body)
+	if err != nil { // coverage-ignore -- io.ReadAll after successful HTTP response
+		return nil, &TransportError{URL: url, Err: fmt.Errorf("read response: %w", err)}
+	}
+
+	return &TransportResponse{
+		StatusCode: response.StatusCode,
+		Body:       string(responseBody),
+		Headers:    response.Headers,
+	}, nil
+}
+
+// PostJSONStream sends a JSON POST request using net/http and returns the
+// response body unread.
+func (transport *HTTPTransport) PostJSONStream(ctx context.Context, url string,
+	payload map[string]any, headers map[string]string, timeout time.Duration,
+	verifyTLS bool,
+) (*StreamingResponse, error) {
 	body, err := json.Marshal(payload)
 	if err != nil { // coverage-ignore -- json.Marshal on map[string]any cannot fail
 		return nil, &TransportError{URL: url, Err: fmt.Errorf("marshal payload: %w", err)}
 	}
 
-	request, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	return transport.send(ctx, http.MethodPost, url, body, headers, timeout, verifyTLS)
+}
+
+// SendRequest sends a request with any method and a raw body using
+// net/http.
+func (transport *HTTPTransport) SendRequest(ctx context.Context, method, url string,
+	body []byte, headers map[string]string, timeout time.Duration, verifyTLS bool,
+) (*TransportResponse, error) {
+	response, err := transport.send(ctx, method, url, body, headers, timeout, verifyTLS)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = response.Body.Close() }()
+
+	responseBody, err := io.ReadAll(response.Body)
+	if err != nil { // coverage-ignore -- io.ReadAll after successful HTTP response
+		return nil, &TransportError{URL: url, Err: fmt.Errorf("read response: %w", err)}
+	}
+
+	return &TransportResponse{
+		StatusCode: response.StatusCode,
+		Body:       string(responseBody),
+		Headers:    response.Headers,
+	}, nil
+}
+
+// send issues a request and returns the response with its body unread.
+func (transport *HTTPTransport) send(ctx context.Context, method, url string,
+	body []byte, headers map[string]string, timeout time.Duration, verifyTLS bool,
+) (*StreamingResponse, error) {
+	request, err := http.NewRequestWithContext(ctx, method, url, bytes.NewReader(body))
 	if err != nil {
 		return nil, &TransportError{URL: url, Err: fmt.Errorf("create request: %w", err)}
 	}
@@ -60,25 +145,22 @@ func (transport *HTTPTransport) PostJSON(ctx context.Context, url string,
 	if err != nil {
 		return nil, &TransportError{URL: url, Err: err}
 	}
-	defer func() { _ = response.Body.Close() }()
 
-	responseBody, err := io.ReadAll(response.Body)
-	if err != nil { // coverage-ignore -- io.ReadAll after successful HTTP response
-		return nil, &TransportError{URL: url, Err: fmt.Errorf("read response: %w", err)}
-	}
+	return &StreamingResponse{
+		StatusCode: response.StatusCode,
+		Body:       response.Body,
+		Headers:    flattenHeaders(response.Header),
+	}, nil
+}
 
-	responseHeaders := make(map[string]string, len(response.Header))
-	for key, values := range response.Header {
+func flattenHeaders(header http.Header) map[string]string {
+	headers := make(map[string]string, len(header))
+	for key, values := range header {
 		if len(values) > 0 {
-			responseHeaders[key] = values[0]
+			headers[key] = values[0]
 		}
 	}
-
-	return &TransportResponse{
-		StatusCode: response.StatusCode,
-		Body:       string(responseBody),
-		Headers:    responseHeaders,
-	}, nil
+	return headers
 }
 
 func (transport *HTTPTransport) buildClient(timeout time.Duration, verifyTLS bool) *http.Client {
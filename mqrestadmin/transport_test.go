@@ -132,3 +132,31 @@ func TestBuildClient_VerifyTLSFalse(t *testing.T) {
 		t.Error("expected InsecureSkipVerify = true when verifyTLS = false")
 	}
 }
+
+func TestHTTPTransport_SendRequest(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if r.Method != http.MethodDelete || string(body) != "raw" || r.URL.RawQuery != "wait=5" {
+			t.Errorf("request = %s %q %q, want DELETE raw wait=5", r.Method, r.URL.RawQuery, body)
+		}
+		w.Header().Set("ibm-mq-md-messageId", "414D51")
+		_, _ = w.Write([]byte("hello"))
+	}))
+	defer server.Close()
+
+	transport := &HTTPTransport{TLSConfig: server.TLS.Clone()}
+	response, err := transport.SendRequest(context.Background(), http.MethodDelete, server.URL+"/message?wait=5",
+		[]byte("raw"), map[string]string{"Content-Type": "text/plain"}, 30*time.Second, false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if response.StatusCode != 200 || response.Body != "hello" || response.Headers["Ibm-Mq-Md-Messageid"] != "414D51" {
+		t.Errorf("response = %+v", response)
+	}
+
+	_, err = transport.SendRequest(context.Background(), "BAD METHOD", server.URL, nil, nil, time.Second, false)
+	var transportErr *TransportError
+	if !errors.As(err, &transportErr) {
+		t.Errorf("error = %v, want TransportError", err)
+	}
+}